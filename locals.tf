@@ -21,6 +21,10 @@ locals {
   workers_security_group_id = var.workers_security_group_id == null ? aws_security_group.workers_security_group[0].id : var.workers_security_group_id
   # Name of the EKS cluster
   cluster_name = "${var.prefix}-eks"
+  # Major and minor number of kubernetes_version, since comparing the strings gets "1.4" >= "1.30" wrong
+  kubernetes_version_parts = [for part in slice(split(".", var.kubernetes_version), 0, 2) : tonumber(part)]
+  # Clusters from 1.30 on no longer get gp2 marked as the default StorageClass
+  kubernetes_version_at_least_1_30 = local.kubernetes_version_parts[0] > 1 || (local.kubernetes_version_parts[0] == 1 && local.kubernetes_version_parts[1] >= 30)
   # Default tags applied to all resources when caller input is null or empty.
   default_tags = { project_name = "viya" }
   # Merge caller-provided tags over the default set so project_name always has a baseline value.
@@ -95,6 +99,11 @@ locals {
   # Certificate authority data for the Kubernetes cluster
   kubeconfig_ca_cert = module.eks.cluster_certificate_authority_data
 
+  # Kubernetes label keys, an optional DNS subdomain prefix and '/' followed by a name,
+  # and label values, empty or a name, for the validations of the node pool labels
+  label_key_regex   = "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$"
+  label_value_regex = "^([A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?)?$"
+
   # Mapping node_pools to node_groups
   # Default node pool configuration
   default_node_pool = {
@@ -136,7 +145,7 @@ locals {
             spec:
               kubelet:
                 flags:
-                  - "--node-labels=${replace(replace(jsonencode(var.default_nodepool_labels), "/[\"\\{\\}]/", ""), ":", "=")}"
+                  - "--node-labels=${join(",", [for k, v in var.default_nodepool_labels : "${k}=${v}"])}"
                   - "--register-with-taints=${join(",", var.default_nodepool_taints)}"
           EOT
         }
@@ -210,7 +219,7 @@ locals {
             spec:
               kubelet:
                 flags:
-                  - "--node-labels=${replace(replace(jsonencode(np_value.node_labels), "/[\"\\{\\}]/", ""), ":", "=")}"
+                  - "--node-labels=${join(",", [for k, v in np_value.node_labels : "${k}=${v}"])}"
                   - "--register-with-taints=${join(",", np_value.node_taints)}"
           EOT
         }
@@ -297,4 +306,89 @@ locals {
     ]
   ])
 
+  # Input variables behind the tfvars checksum of the sas-iac-buildinfo ConfigMap: every variable
+  # but the AWS credentials and the passwords, so the checksum covers -var-file files as well.
+  # test/buildinfo checks that new variables are added here.
+  buildinfo_inputs = {
+    prefix                                                = var.prefix
+    location                                              = var.location
+    iac_tooling                                           = var.iac_tooling
+    default_public_access_cidrs                           = var.default_public_access_cidrs
+    default_private_access_cidrs                          = var.default_private_access_cidrs
+    cluster_enabled_log_types                             = var.cluster_enabled_log_types
+    cluster_endpoint_public_access_cidrs                  = var.cluster_endpoint_public_access_cidrs
+    cluster_endpoint_private_access_cidrs                 = var.cluster_endpoint_private_access_cidrs
+    vpc_endpoint_private_access_cidrs                     = var.vpc_endpoint_private_access_cidrs
+    vm_public_access_cidrs                                = var.vm_public_access_cidrs
+    vm_private_access_cidrs                               = var.vm_private_access_cidrs
+    postgres_public_access_cidrs                          = var.postgres_public_access_cidrs
+    ssh_public_key                                        = var.ssh_public_key
+    efs_performance_mode                                  = var.efs_performance_mode
+    efs_throughput_mode                                   = var.efs_throughput_mode
+    efs_throughput_rate                                   = var.efs_throughput_rate
+    kubernetes_version                                    = var.kubernetes_version
+    tags                                                  = var.tags
+    enable_tagged_default_storage_class                   = var.enable_tagged_default_storage_class
+    tagged_default_storage_class_volume_type              = var.tagged_default_storage_class_volume_type
+    create_default_nodepool                               = var.create_default_nodepool
+    default_nodepool_vm_type                              = var.default_nodepool_vm_type
+    default_nodepool_os_disk_type                         = var.default_nodepool_os_disk_type
+    default_nodepool_os_disk_size                         = var.default_nodepool_os_disk_size
+    default_nodepool_os_disk_iops                         = var.default_nodepool_os_disk_iops
+    default_nodepool_node_count                           = var.default_nodepool_node_count
+    default_nodepool_max_nodes                            = var.default_nodepool_max_nodes
+    default_nodepool_min_nodes                            = var.default_nodepool_min_nodes
+    default_nodepool_taints                               = var.default_nodepool_taints
+    default_nodepool_labels                               = var.default_nodepool_labels
+    default_nodepool_custom_data                          = var.default_nodepool_custom_data
+    default_nodepool_metadata_http_endpoint               = var.default_nodepool_metadata_http_endpoint
+    default_nodepool_metadata_http_tokens                 = var.default_nodepool_metadata_http_tokens
+    default_nodepool_metadata_http_put_response_hop_limit = var.default_nodepool_metadata_http_put_response_hop_limit
+    node_pools                                            = var.node_pools
+    vpc_id                                                = var.vpc_id
+    subnet_ids                                            = var.subnet_ids
+    vpc_cidr                                              = var.vpc_cidr
+    subnets                                               = var.subnets
+    subnet_azs                                            = var.subnet_azs
+    security_group_id                                     = var.security_group_id
+    cluster_security_group_id                             = var.cluster_security_group_id
+    workers_security_group_id                             = var.workers_security_group_id
+    nat_id                                                = var.nat_id
+    cluster_iam_role_arn                                  = var.cluster_iam_role_arn
+    workers_iam_role_arn                                  = var.workers_iam_role_arn
+    create_jump_vm                                        = var.create_jump_vm
+    create_jump_public_ip                                 = var.create_jump_public_ip
+    jump_vm_admin                                         = var.jump_vm_admin
+    jump_vm_type                                          = var.jump_vm_type
+    jump_rwx_filestore_path                               = var.jump_rwx_filestore_path
+    nfs_raid_disk_size                                    = var.nfs_raid_disk_size
+    nfs_raid_disk_type                                    = var.nfs_raid_disk_type
+    nfs_raid_disk_iops                                    = var.nfs_raid_disk_iops
+    create_nfs_public_ip                                  = var.create_nfs_public_ip
+    nfs_vm_admin                                          = var.nfs_vm_admin
+    nfs_vm_type                                           = var.nfs_vm_type
+    os_disk_size                                          = var.os_disk_size
+    os_disk_type                                          = var.os_disk_type
+    os_disk_delete_on_termination                         = var.os_disk_delete_on_termination
+    os_disk_iops                                          = var.os_disk_iops
+    postgres_server_defaults                              = merge(var.postgres_server_defaults, { administrator_password = null })
+    postgres_servers                                      = var.postgres_servers == null ? null : { for k, v in var.postgres_servers : k => merge(v, { administrator_password = null }) }
+    storage_type                                          = var.storage_type
+    storage_type_backend                                  = var.storage_type_backend
+    create_static_kubeconfig                              = var.create_static_kubeconfig
+    cluster_api_mode                                      = var.cluster_api_mode
+    vpc_private_endpoints                                 = var.vpc_private_endpoints
+    vpc_private_endpoints_enabled                         = var.vpc_private_endpoints_enabled
+    cluster_node_pool_mode                                = var.cluster_node_pool_mode
+    autoscaling_enabled                                   = var.autoscaling_enabled
+    enable_ebs_encryption                                 = var.enable_ebs_encryption
+    enable_efs_encryption                                 = var.enable_efs_encryption
+    aws_fsx_ontap_deployment_type                         = var.aws_fsx_ontap_deployment_type
+    aws_fsx_ontap_file_system_storage_capacity            = var.aws_fsx_ontap_file_system_storage_capacity
+    aws_fsx_ontap_file_system_throughput_capacity         = var.aws_fsx_ontap_file_system_throughput_capacity
+    enable_nist_features                                  = var.enable_nist_features
+    authentication_mode                                   = var.authentication_mode
+    admin_access_entry_role_arns                          = var.admin_access_entry_role_arns
+  }
+
 }
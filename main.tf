@@ -32,14 +32,22 @@ data "aws_availability_zones" "available" {}
 # Data source to get information about the current AWS caller identity (account, user, etc).
 data "aws_caller_identity" "terraform" {}
 
-# Data source to get the current git commit hash for build info. Uses an external script.
-data "external" "git_hash" {
-  program = ["files/tools/iac_git_info.sh"]
-}
+# Data source to get the build info: git commit, dirty state and tag, Terraform version and provider
+# selections, and a checksum of the input variables. Runs files/tools/iac-buildinfo, built from
+# test/cmd/buildinfo (see README.md).
+data "external" "buildinfo" {
+  program = ["files/tools/iac-buildinfo"]
+  query = {
+    dir    = path.root
+    inputs = jsonencode(local.buildinfo_inputs)
+  }
 
-# Data source to get the current IAC tooling version and provider info. Uses an external script.
-data "external" "iac_tooling_version" {
-  program = ["files/tools/iac_tooling_version.sh"]
+  lifecycle {
+    precondition {
+      condition     = fileexists("${path.module}/files/tools/iac-buildinfo")
+      error_message = "ERROR: files/tools/iac-buildinfo is missing, build it with `cd test && go build -o ../files/tools/iac-buildinfo ./cmd/buildinfo && cd ..` (see README.md)."
+    }
+  }
 }
 
 # Resource to create a Kubernetes ConfigMap in the kube-system namespace with build info (git hash, timestamp, tooling version, etc).
@@ -49,15 +57,18 @@ resource "kubernetes_config_map" "sas_iac_buildinfo" {
     namespace = "kube-system"
   }
   data = {
-    git-hash    = data.external.git_hash.result["git-hash"] # Current git commit hash
-    timestamp   = chomp(timestamp())                        # Current timestamp
-    iac-tooling = var.iac_tooling                           # Tooling identifier (see variables.tf: iac_tooling)
-    terraform   = <<EOT
-version: ${data.external.iac_tooling_version.result["terraform_version"]}
-revision: ${data.external.iac_tooling_version.result["terraform_revision"]}
-provider-selections: ${data.external.iac_tooling_version.result["provider_selections"]}
-outdated: ${data.external.iac_tooling_version.result["terraform_outdated"]}
-EOT
+    git-hash        = data.external.buildinfo.result["git_hash"]        # Current git commit hash
+    git-dirty       = data.external.buildinfo.result["git_dirty"]       # Whether the work tree has uncommitted changes
+    git-tag         = data.external.buildinfo.result["git_tag"]         # Tag of the commit, empty without one
+    tfvars-checksum = data.external.buildinfo.result["tfvars_checksum"] # Checksum of the input variables
+    timestamp       = chomp(timestamp())                                # Current timestamp
+    iac-tooling     = var.iac_tooling                                   # Tooling identifier (see variables.tf: iac_tooling)
+    terraform       = yamlencode({
+      version             = data.external.buildinfo.result["terraform_version"]
+      revision            = data.external.buildinfo.result["terraform_revision"]
+      provider-selections = jsondecode(data.external.buildinfo.result["provider_selections"])
+      outdated            = data.external.buildinfo.result["terraform_outdated"] == "true"
+    })
   }
   depends_on = [module.kubeconfig.kube_config] # Wait for kubeconfig to be ready
 }
@@ -317,7 +328,7 @@ resource "kubernetes_storage_class_v1" "ebs_csi_tagged_default" {
 # Normally, the use of local-exec below is avoided. It is used here to patch the gp2 storage class as the default storage class for EKS 1.30 and later clusters.
 # PSKD-667 will track the move to a newer version of the aws-ebs-csi-driver creating a gp3 storage class which will then become the default storage class.
 resource "terraform_data" "run_command" {
-  count = var.kubernetes_version >= "1.30" ? 1 : 0
+  count = local.kubernetes_version_at_least_1_30 ? 1 : 0
   provisioner "local-exec" {
     command = "kubectl --kubeconfig=${local.kubeconfig_path} patch storageclass gp2 --patch '{\"metadata\": {\"annotations\":{\"storageclass.kubernetes.io/is-default-class\":\"true\"}}}' "
   }
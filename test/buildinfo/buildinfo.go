@@ -0,0 +1,307 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package buildinfo collects the build information main.tf writes to the
+// sas-iac-buildinfo ConfigMap: the git commit of the repository, the Terraform
+// version and provider selections, and a checksum of the input variables. It
+// speaks the protocol of the buildinfo external data source, which ran
+// iac_git_info.sh and iac_tooling_version.sh before: they needed bash and jq
+// and passed jq output that was already quoted back through --arg. It also
+// reads the ConfigMap back for audits.
+package buildinfo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap main.tf writes
+	ConfigMapName = "sas-iac-buildinfo"
+	// ConfigMapNamespace is the namespace of the ConfigMap
+	ConfigMapNamespace = "kube-system"
+
+	// NotAvailable is the git hash outside a git work tree, like
+	// iac_git_info.sh reported it
+	NotAvailable = "N/A"
+)
+
+// Query is the query of the external data source. Every value of the
+// external protocol is a string, so var_files is a comma separated list and
+// inputs is JSON.
+type Query struct {
+	// Dir is the directory git and terraform run in, the working directory
+	// when empty
+	Dir string
+	// Inputs are the input variables of the run as JSON, main.tf sends
+	// jsonencode(local.buildinfo_inputs). The checksum covers them instead of
+	// the tfvars files when set, since the program does not see -var-file.
+	Inputs string
+	// VarFiles are the tfvars files of the checksum, in the order given, the
+	// files Terraform loads automatically from Dir when empty
+	VarFiles []string
+	// Terraform is the terraform binary, terraform when empty
+	Terraform string
+}
+
+// queryKeys are the keys ParseQuery accepts
+var queryKeys = map[string]bool{"dir": true, "inputs": true, "var_files": true, "terraform": true}
+
+// ParseQuery reads the JSON object the external data source writes to the
+// program's stdin. Terraform sends {} without a query argument.
+func ParseQuery(r io.Reader) (Query, error) {
+	var q Query
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return q, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return q, nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return q, fmt.Errorf("query is not a JSON object of strings: %w", err)
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !queryKeys[k] {
+			return q, fmt.Errorf("query key %q is unknown, expected dir, inputs, var_files or terraform", k)
+		}
+	}
+
+	q.Dir, q.Inputs, q.Terraform = values["dir"], values["inputs"], values["terraform"]
+	for _, f := range strings.Split(values["var_files"], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			q.VarFiles = append(q.VarFiles, f)
+		}
+	}
+	return q, nil
+}
+
+// Git is the commit the repository is at
+type Git struct {
+	// Hash is the commit hash, NotAvailable outside a git work tree
+	Hash string `json:"hash"`
+	// Dirty reports uncommitted changes to tracked or untracked files
+	Dirty bool `json:"dirty"`
+	// Tag is a tag of the commit, empty when it has none
+	Tag string `json:"tag,omitempty"`
+}
+
+// Terraform is the version information `terraform version -json` reports
+type Terraform struct {
+	Version            string            `json:"version"`
+	Revision           string            `json:"revision,omitempty"`
+	ProviderSelections map[string]string `json:"providerSelections"`
+	Outdated           bool              `json:"outdated"`
+}
+
+// Info is the build information of a run
+type Info struct {
+	Git       Git       `json:"git"`
+	Terraform Terraform `json:"terraform"`
+	// TfvarsChecksum is the checksum of the input variables or the tfvars
+	// files, empty without either
+	TfvarsChecksum string `json:"tfvarsChecksum,omitempty"`
+}
+
+// command runs a program in dir and returns its stdout, with its stderr in
+// the error
+func command(dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, msg)
+		}
+		return nil, fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// GitInfo returns the commit of the git work tree at dir. Without git, or
+// outside a work tree, the hash is NotAvailable and the error nil, like
+// iac_git_info.sh had it.
+func GitInfo(dir string) (Git, error) {
+	g := Git{Hash: NotAvailable}
+	if _, err := exec.LookPath("git"); err != nil {
+		return g, nil
+	}
+	if out, err := command(dir, "git", "rev-parse", "--is-inside-work-tree"); err != nil || strings.TrimSpace(string(out)) != "true" {
+		return g, nil
+	}
+
+	out, err := command(dir, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return g, err
+	}
+	g.Hash = strings.TrimSpace(string(out))
+	if out, err = command(dir, "git", "status", "--porcelain"); err != nil {
+		return g, err
+	}
+	g.Dirty = len(bytes.TrimSpace(out)) > 0
+	// describe fails when no tag points at the commit
+	if out, err = command(dir, "git", "describe", "--tags", "--exact-match", "HEAD"); err == nil {
+		g.Tag = strings.TrimSpace(string(out))
+	}
+	return g, nil
+}
+
+// TerraformInfo runs `terraform version -json` in dir, the provider
+// selections are those of the .terraform.lock.hcl there
+func TerraformInfo(binary, dir string) (Terraform, error) {
+	if binary == "" {
+		binary = "terraform"
+	}
+	out, err := command(dir, binary, "version", "-json")
+	if err != nil {
+		return Terraform{}, err
+	}
+	var v tfjson.VersionOutput
+	if err := json.Unmarshal(out, &v); err != nil {
+		return Terraform{}, fmt.Errorf("%s version -json: %w", binary, err)
+	}
+	if v.Version == "" {
+		return Terraform{}, fmt.Errorf("%s version -json has no terraform_version", binary)
+	}
+	t := Terraform{Version: v.Version, Revision: v.Revision, ProviderSelections: v.ProviderSelections, Outdated: v.Outdated}
+	if t.ProviderSelections == nil {
+		t.ProviderSelections = map[string]string{}
+	}
+	return t, nil
+}
+
+// Checksum returns the sha256 of the tfvars files, each preceded by its
+// length so moving a line between files changes the checksum. It is empty
+// without files.
+func Checksum(files []string) (string, error) {
+	if len(files) == 0 {
+		return "", nil
+	}
+	h := sha256.New()
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%d\n", len(data))
+		h.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// InputsChecksum returns the sha256 of the input variables as JSON
+func InputsChecksum(inputs string) string {
+	sum := sha256.Sum256([]byte(inputs))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// AutoVarFiles returns the tfvars files Terraform loads without -var-file:
+// terraform.tfvars, terraform.tfvars.json, then the *.auto.tfvars and
+// *.auto.tfvars.json files in lexical order
+func AutoVarFiles(dir string) ([]string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	var files []string
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			files = append(files, name)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	// ReadDir sorts by name
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && (strings.HasSuffix(name, ".auto.tfvars") || strings.HasSuffix(name, ".auto.tfvars.json")) {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// Collect returns the build information of a query. Relative var files are
+// relative to the query directory.
+func Collect(q Query) (*Info, error) {
+	g, err := GitInfo(q.Dir)
+	if err != nil {
+		return nil, err
+	}
+	t, err := TerraformInfo(q.Terraform, q.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if q.Inputs != "" {
+		return &Info{Git: g, Terraform: t, TfvarsChecksum: InputsChecksum(q.Inputs)}, nil
+	}
+	if len(q.VarFiles) == 0 {
+		if q.VarFiles, err = AutoVarFiles(q.Dir); err != nil {
+			return nil, err
+		}
+	}
+	files := make([]string, len(q.VarFiles))
+	for i, f := range q.VarFiles {
+		if q.Dir != "" && !filepath.IsAbs(f) {
+			f = filepath.Join(q.Dir, f)
+		}
+		files[i] = f
+	}
+	checksum, err := Checksum(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Git: g, Terraform: t, TfvarsChecksum: checksum}, nil
+}
+
+// Result returns the result of the external data source, a flat object of
+// strings. The values are no longer quoted like those of the former shell
+// scripts: terraform_version is 1.10.5, not "1.10.5". provider_selections is
+// a compact JSON object, main.tf decodes it.
+func (i *Info) Result() (map[string]string, error) {
+	selections, err := json.Marshal(i.Terraform.ProviderSelections)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"git_hash":            i.Git.Hash,
+		"git_dirty":           strconv.FormatBool(i.Git.Dirty),
+		"git_tag":             i.Git.Tag,
+		"terraform_version":   i.Terraform.Version,
+		"terraform_revision":  i.Terraform.Revision,
+		"terraform_outdated":  strconv.FormatBool(i.Terraform.Outdated),
+		"provider_selections": string(selections),
+		"tfvars_checksum":     i.TfvarsChecksum,
+	}, nil
+}
+
+// WriteResult writes the result of the external data source
+func (i *Info) WriteResult(w io.Writer) error {
+	result, err := i.Result()
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(result)
+}
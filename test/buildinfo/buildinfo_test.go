@@ -0,0 +1,364 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"test/tfvars"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const versionJSON = `{
+  "terraform_version": "1.10.0",
+  "platform": "linux_amd64",
+  "provider_selections": {
+    "registry.terraform.io/hashicorp/aws": "5.100.0",
+    "registry.terraform.io/hashicorp/external": "2.3.4"
+  },
+  "terraform_outdated": true
+}`
+
+// fakeTerraform writes a terraform that prints versionJSON
+func fakeTerraform(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "terraform")
+	script := "#!/bin/sh\ncat <<'EOF'\n" + versionJSON + "\nEOF\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+// gitRepo returns a work tree with one commit of terraform.tfvars
+func gitRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte("prefix = \"viya\"\n"), 0o644))
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "terraform.tfvars"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "tfvars"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return dir
+}
+
+// keys returns the sorted keys of a ConfigMap data
+func keys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	q, err := ParseQuery(strings.NewReader(`{"dir": "/work", "var_files": "a.tfvars, b.tfvars,", "terraform": "tofu"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Query{Dir: "/work", VarFiles: []string{"a.tfvars", "b.tfvars"}, Terraform: "tofu"}, q)
+
+	q, err = ParseQuery(strings.NewReader(`{"dir": ".", "inputs": "{\"prefix\":\"viya\"}"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Query{Dir: ".", Inputs: `{"prefix":"viya"}`}, q)
+
+	q, err = ParseQuery(strings.NewReader("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, Query{}, q)
+
+	_, err = ParseQuery(strings.NewReader(`{"var_file": "a.tfvars"}`))
+	assert.EqualError(t, err, `query key "var_file" is unknown, expected dir, inputs, var_files or terraform`)
+	_, err = ParseQuery(strings.NewReader(`{"dir": 1}`))
+	assert.ErrorContains(t, err, "query is not a JSON object of strings")
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	dir := gitRepo(t)
+	query := Query{Dir: dir, VarFiles: []string{"terraform.tfvars"}, Terraform: fakeTerraform(t)}
+	info, err := Collect(query)
+	require.NoError(t, err)
+	assert.Len(t, info.Git.Hash, 40)
+	assert.False(t, info.Git.Dirty)
+	assert.Empty(t, info.Git.Tag)
+	assert.Equal(t, Terraform{
+		Version:            "1.10.0",
+		ProviderSelections: map[string]string{"registry.terraform.io/hashicorp/aws": "5.100.0", "registry.terraform.io/hashicorp/external": "2.3.4"},
+		Outdated:           true,
+	}, info.Terraform)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, info.TfvarsChecksum)
+
+	// without var_files or inputs, the auto-loaded files
+	auto, err := Collect(Query{Dir: dir, Terraform: query.Terraform})
+	require.NoError(t, err)
+	assert.Equal(t, info.TfvarsChecksum, auto.TfvarsChecksum)
+
+	// the external data source takes a flat object of unquoted strings
+	var out bytes.Buffer
+	require.NoError(t, info.WriteResult(&out))
+	var result map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, info.Git.Hash, result["git_hash"])
+	assert.Equal(t, "false", result["git_dirty"])
+	assert.Equal(t, "1.10.0", result["terraform_version"])
+	assert.Equal(t, "", result["terraform_revision"])
+	assert.Equal(t, "true", result["terraform_outdated"])
+	assert.JSONEq(t, `{"registry.terraform.io/hashicorp/aws": "5.100.0", "registry.terraform.io/hashicorp/external": "2.3.4"}`, result["provider_selections"])
+	assert.Equal(t, info.TfvarsChecksum, result["tfvars_checksum"])
+
+	// a tag and an edit of the tfvars
+	out2, err := exec.Command("git", "-C", dir, "tag", "v9.1.0").CombinedOutput()
+	require.NoError(t, err, string(out2))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte("prefix = \"sas\"\n"), 0o644))
+	edited, err := Collect(query)
+	require.NoError(t, err)
+	assert.Equal(t, info.Git.Hash, edited.Git.Hash)
+	assert.True(t, edited.Git.Dirty)
+	assert.Equal(t, "v9.1.0", edited.Git.Tag)
+	assert.NotEqual(t, info.TfvarsChecksum, edited.TfvarsChecksum)
+}
+
+// TestCollectInputs is the Docker flow, terraform plan -var-file
+// /workspace/terraform.tfvars: no tfvars file is auto-loaded, main.tf sends
+// the inputs
+func TestCollectInputs(t *testing.T) {
+	t.Parallel()
+
+	query := Query{Dir: t.TempDir(), Inputs: `{"prefix":"viya"}`, Terraform: fakeTerraform(t)}
+	info, err := Collect(query)
+	require.NoError(t, err)
+	assert.Equal(t, InputsChecksum(query.Inputs), info.TfvarsChecksum)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, info.TfvarsChecksum)
+
+	query.Inputs = `{"prefix":"sas"}`
+	edited, err := Collect(query)
+	require.NoError(t, err)
+	assert.NotEqual(t, info.TfvarsChecksum, edited.TfvarsChecksum)
+
+	// without inputs only the auto-loaded files count, and there are none
+	query.Inputs = ""
+	none, err := Collect(query)
+	require.NoError(t, err)
+	assert.Empty(t, none.TfvarsChecksum)
+}
+
+// TestBuildInfoInputs checks that local.buildinfo_inputs of locals.tf, the
+// inputs main.tf sends, has every variable but the credentials and passwords
+func TestBuildInfoInputs(t *testing.T) {
+	t.Parallel()
+
+	secrets := map[string]bool{
+		"aws_profile":                     true,
+		"aws_shared_credentials_file":     true,
+		"aws_shared_credentials_files":    true,
+		"aws_session_token":               true,
+		"aws_access_key_id":               true,
+		"aws_secret_access_key":           true,
+		"aws_fsx_ontap_fsxadmin_password": true,
+		"aws_fsx_ontap_svmadmin_password": true,
+	}
+	variables, err := tfvars.LoadVariables("../..")
+	require.NoError(t, err)
+	var expected []string
+	for name := range variables {
+		if !secrets[name] {
+			expected = append(expected, name)
+		}
+	}
+	sort.Strings(expected)
+
+	f, diags := hclparse.NewParser().ParseHCLFile("../../locals.tf")
+	require.False(t, diags.HasErrors(), diags.Error())
+	content, _, diags := f.Body.PartialContent(&hcl.BodySchema{Blocks: []hcl.BlockHeaderSchema{{Type: "locals"}}})
+	require.False(t, diags.HasErrors(), diags.Error())
+	var inputs []string
+	for _, block := range content.Blocks {
+		attrs, diags := block.Body.JustAttributes()
+		require.False(t, diags.HasErrors(), diags.Error())
+		attr, ok := attrs["buildinfo_inputs"]
+		if !ok {
+			continue
+		}
+		pairs, diags := hcl.ExprMap(attr.Expr)
+		require.False(t, diags.HasErrors(), diags.Error())
+		for _, pair := range pairs {
+			inputs = append(inputs, hcl.ExprAsKeyword(pair.Key))
+		}
+	}
+	sort.Strings(inputs)
+	assert.Equal(t, expected, inputs)
+}
+
+func TestGitInfoOutsideWorkTree(t *testing.T) {
+	t.Parallel()
+
+	g, err := GitInfo(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, Git{Hash: NotAvailable}, g)
+}
+
+func TestAutoVarFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"z.auto.tfvars", "terraform.tfvars", "a.auto.tfvars.json", "sample.tfvars", "terraform.tfvars.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	files, err := AutoVarFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"terraform.tfvars", "terraform.tfvars.json", "a.auto.tfvars.json", "z.auto.tfvars"}, files)
+
+	files, err = AutoVarFiles(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	a, b := write("a.tfvars", "x = 1\n"), write("b.tfvars", "y = 2\n")
+	joined, split := write("ab.tfvars", "x = 1\ny = 2\n"), write("empty.tfvars", "")
+
+	sum := func(files ...string) string {
+		s, err := Checksum(files)
+		require.NoError(t, err)
+		return s
+	}
+	assert.Empty(t, sum())
+	assert.Equal(t, sum(a, b), sum(a, b))
+	assert.NotEqual(t, sum(a, b), sum(b, a))
+	assert.NotEqual(t, sum(a, b), sum(joined, split))
+	_, err := Checksum([]string{filepath.Join(dir, "missing.tfvars")})
+	assert.Error(t, err)
+}
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	info := &Info{
+		Git:            Git{Hash: "4f1c2d3e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d", Dirty: true, Tag: "v9.1.0"},
+		Terraform:      Terraform{Version: "1.10", Revision: "", ProviderSelections: map[string]string{"registry.terraform.io/hashicorp/aws": "5.100.0"}},
+		TfvarsChecksum: "sha256:0123",
+	}
+	timestamp := time.Date(2026, 3, 2, 15, 4, 5, 0, time.UTC)
+	cm, err := info.ConfigMap("terraform", timestamp)
+	require.NoError(t, err)
+	data, err := yaml.Marshal(cm)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"git-dirty", "git-hash", "git-tag", "iac-tooling", "terraform", "tfvars-checksum", "timestamp"}, keys(cm.Data))
+
+	b, err := ParseConfigMap(data)
+	require.NoError(t, err)
+	dirty := true
+	assert.Equal(t, &BuildInfo{
+		GitHash:        info.Git.Hash,
+		Timestamp:      timestamp,
+		IACTooling:     "terraform",
+		Terraform:      info.Terraform,
+		GitDirty:       &dirty,
+		GitTag:         "v9.1.0",
+		TfvarsChecksum: "sha256:0123",
+	}, b)
+}
+
+func TestParseLegacyConfigMap(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/legacy-configmap.yaml")
+	require.NoError(t, err)
+	b, err := ParseConfigMap(data)
+	require.NoError(t, err)
+	assert.Equal(t, &BuildInfo{
+		GitHash:    "4f1c2d3e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d",
+		Timestamp:  time.Date(2026, 3, 2, 15, 4, 5, 0, time.UTC),
+		IACTooling: "docker",
+		Terraform: Terraform{
+			Version:            "1.10.5",
+			ProviderSelections: map[string]string{"registry.terraform.io/hashicorp/aws": "5.100.0", "registry.terraform.io/hashicorp/external": "2.3.4"},
+			Outdated:           true,
+		},
+	}, b)
+	assert.Len(t, b.Tables(), 2)
+}
+
+func TestFromConfigMapErrors(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(data map[string]string) corev1.ConfigMap {
+		cm := corev1.ConfigMap{Data: map[string]string{"git-hash": NotAvailable, "timestamp": "2026-03-02T15:04:05Z", "terraform": "version: \"1.10.5\"\n"}}
+		cm.Name = ConfigMapName
+		for k, v := range data {
+			if v == "" {
+				delete(cm.Data, k)
+			} else {
+				cm.Data[k] = v
+			}
+		}
+		return cm
+	}
+	other := configMap(nil)
+	other.Name = "kube-proxy"
+
+	tests := map[string]struct {
+		cm       corev1.ConfigMap
+		expected string
+	}{
+		"other name": {
+			cm:       other,
+			expected: `not the sas-iac-buildinfo ConfigMap: name is "kube-proxy"`,
+		},
+		"missing key": {
+			cm:       configMap(map[string]string{"timestamp": ""}),
+			expected: "sas-iac-buildinfo has no timestamp",
+		},
+		"unquoted version": {
+			cm:       configMap(map[string]string{"terraform": "version: 1.10\n"}),
+			expected: "sas-iac-buildinfo terraform: version is a float64, not a string",
+		},
+		"selection not a string": {
+			cm:       configMap(map[string]string{"terraform": "version: \"1.10.5\"\nprovider-selections: {\"registry.terraform.io/hashicorp/aws\": 5}\n"}),
+			expected: "sas-iac-buildinfo terraform: provider-selections registry.terraform.io/hashicorp/aws is a float64, not a string",
+		},
+		"dirty": {
+			cm:       configMap(map[string]string{"git-dirty": "maybe"}),
+			expected: `sas-iac-buildinfo git-dirty: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromConfigMap(&tc.cm)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+
+	_, err := FromConfigMap(&other)
+	assert.True(t, errors.Is(err, ErrNotBuildInfo))
+}
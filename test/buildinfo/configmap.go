@@ -0,0 +1,191 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrNotBuildInfo is returned for a ConfigMap other than sas-iac-buildinfo
+var ErrNotBuildInfo = errors.New("not the " + ConfigMapName + " ConfigMap")
+
+// BuildInfo is the content of a sas-iac-buildinfo ConfigMap
+type BuildInfo struct {
+	GitHash    string    `json:"gitHash"`
+	Timestamp  time.Time `json:"timestamp"`
+	IACTooling string    `json:"iacTooling"`
+	Terraform  Terraform `json:"terraform"`
+	// GitDirty, GitTag and TfvarsChecksum are not in the ConfigMaps of the
+	// former shell scripts, GitDirty is nil for those
+	GitDirty       *bool  `json:"gitDirty,omitempty"`
+	GitTag         string `json:"gitTag,omitempty"`
+	TfvarsChecksum string `json:"tfvarsChecksum,omitempty"`
+}
+
+// terraformData is the terraform key of the ConfigMap, the object main.tf
+// passes to yamlencode
+type terraformData struct {
+	Version            string            `json:"version"`
+	Revision           string            `json:"revision"`
+	ProviderSelections map[string]string `json:"provider-selections"`
+	Outdated           bool              `json:"outdated"`
+}
+
+// ConfigMapData returns the data of the ConfigMap main.tf writes from the
+// build information, tooling is var.iac_tooling
+func (i *Info) ConfigMapData(tooling string, timestamp time.Time) (map[string]string, error) {
+	terraform, err := yaml.Marshal(terraformData{
+		Version:            i.Terraform.Version,
+		Revision:           i.Terraform.Revision,
+		ProviderSelections: i.Terraform.ProviderSelections,
+		Outdated:           i.Terraform.Outdated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"git-hash":        i.Git.Hash,
+		"git-dirty":       strconv.FormatBool(i.Git.Dirty),
+		"git-tag":         i.Git.Tag,
+		"tfvars-checksum": i.TfvarsChecksum,
+		"timestamp":       timestamp.UTC().Format(time.RFC3339),
+		"iac-tooling":     tooling,
+		"terraform":       string(terraform),
+	}, nil
+}
+
+// ConfigMap returns the ConfigMap main.tf writes from the build information
+func (i *Info) ConfigMap(tooling string, timestamp time.Time) (*corev1.ConfigMap, error) {
+	data, err := i.ConfigMapData(tooling, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: ConfigMapNamespace},
+		Data:       data,
+	}, nil
+}
+
+// ParseConfigMap parses the YAML or JSON of a ConfigMap, as
+// `kubectl get configmap sas-iac-buildinfo -n kube-system -o yaml` prints it
+func ParseConfigMap(data []byte) (*BuildInfo, error) {
+	var cm corev1.ConfigMap
+	if err := yaml.Unmarshal(data, &cm); err != nil {
+		return nil, err
+	}
+	if cm.Kind != "" && cm.Kind != "ConfigMap" {
+		return nil, fmt.Errorf("%w: kind is %s", ErrNotBuildInfo, cm.Kind)
+	}
+	return FromConfigMap(&cm)
+}
+
+// FromConfigMap reads a sas-iac-buildinfo ConfigMap. It reads the values the
+// former shell scripts wrote, which the heredoc main.tf had then happened to
+// leave valid YAML, as well as those of ConfigMapData.
+func FromConfigMap(cm *corev1.ConfigMap) (*BuildInfo, error) {
+	if cm.Name != ConfigMapName {
+		return nil, fmt.Errorf("%w: name is %q", ErrNotBuildInfo, cm.Name)
+	}
+	for _, key := range []string{"git-hash", "timestamp", "terraform"} {
+		if _, ok := cm.Data[key]; !ok {
+			return nil, fmt.Errorf("%s has no %s", ConfigMapName, key)
+		}
+	}
+
+	b := &BuildInfo{
+		GitHash:        cm.Data["git-hash"],
+		IACTooling:     cm.Data["iac-tooling"],
+		GitTag:         cm.Data["git-tag"],
+		TfvarsChecksum: cm.Data["tfvars-checksum"],
+	}
+	var err error
+	if b.Timestamp, err = time.Parse(time.RFC3339, cm.Data["timestamp"]); err != nil {
+		return nil, fmt.Errorf("%s timestamp: %w", ConfigMapName, err)
+	}
+	if v, ok := cm.Data["git-dirty"]; ok {
+		dirty, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s git-dirty: %w", ConfigMapName, err)
+		}
+		b.GitDirty = &dirty
+	}
+	if b.Terraform, err = parseTerraform(cm.Data["terraform"]); err != nil {
+		return nil, fmt.Errorf("%s terraform: %w", ConfigMapName, err)
+	}
+	return b, nil
+}
+
+// parseTerraform parses the terraform key. An unquoted version like 1.10
+// decodes as a number, which would lose its trailing zero, so the version must
+// be a string.
+func parseTerraform(data string) (Terraform, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(data), &raw); err != nil {
+		return Terraform{}, err
+	}
+	t := Terraform{ProviderSelections: map[string]string{}}
+
+	switch v := raw["version"].(type) {
+	case string:
+		t.Version = v
+	case nil:
+		return t, errors.New("has no version")
+	default:
+		return t, fmt.Errorf("version is a %T, not a string", v)
+	}
+	switch v := raw["revision"].(type) {
+	case string:
+		t.Revision = v
+	case nil:
+	default:
+		return t, fmt.Errorf("revision is a %T, not a string", v)
+	}
+
+	switch v := raw["outdated"].(type) {
+	case bool:
+		t.Outdated = v
+	case string:
+		outdated, err := strconv.ParseBool(v)
+		if err != nil {
+			return t, fmt.Errorf("outdated: %w", err)
+		}
+		t.Outdated = outdated
+	case nil:
+	default:
+		return t, fmt.Errorf("outdated is a %T, not a boolean", v)
+	}
+
+	selections := raw["provider-selections"]
+	// a quoted selection is the JSON text of the mapping
+	if s, ok := selections.(string); ok {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return t, fmt.Errorf("provider-selections: %w", err)
+		}
+		selections = m
+	}
+	switch v := selections.(type) {
+	case map[string]interface{}:
+		for provider, version := range v {
+			s, ok := version.(string)
+			if !ok {
+				return t, fmt.Errorf("provider-selections %s is a %T, not a string", provider, version)
+			}
+			t.ProviderSelections[provider] = s
+		}
+	case nil:
+	default:
+		return t, fmt.Errorf("provider-selections is a %T, not a mapping", v)
+	}
+	return t, nil
+}
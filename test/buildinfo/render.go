@@ -0,0 +1,55 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package buildinfo
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"test/report"
+)
+
+// Tables renders the build information and the provider selections
+func (b *BuildInfo) Tables() []*report.Table {
+	info := &report.Table{
+		Title:   ConfigMapName,
+		Columns: []report.Column{{Header: "Field"}, {Header: "Value"}},
+	}
+	info.AddRow("Git hash", b.GitHash)
+	if b.GitDirty != nil {
+		info.AddRow("Git dirty", strconv.FormatBool(*b.GitDirty))
+	}
+	if b.GitTag != "" {
+		info.AddRow("Git tag", b.GitTag)
+	}
+	info.AddRow("Timestamp", b.Timestamp.Format(time.RFC3339))
+	info.AddRow("IaC tooling", b.IACTooling)
+	info.AddRow("Terraform version", b.Terraform.Version)
+	if b.Terraform.Revision != "" {
+		info.AddRow("Terraform revision", b.Terraform.Revision)
+	}
+	info.AddRow("Terraform outdated", strconv.FormatBool(b.Terraform.Outdated))
+	if b.TfvarsChecksum != "" {
+		info.AddRow("tfvars checksum", b.TfvarsChecksum)
+	}
+	tables := []*report.Table{info}
+
+	if len(b.Terraform.ProviderSelections) > 0 {
+		providers := &report.Table{
+			Title:   "Provider selections",
+			Columns: []report.Column{{Header: "Provider"}, {Header: "Version"}},
+		}
+		names := make([]string, 0, len(b.Terraform.ProviderSelections))
+		for name := range b.Terraform.ProviderSelections {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			providers.AddRow(name, b.Terraform.ProviderSelections[name])
+		}
+		tables = append(tables, providers)
+	}
+	return tables
+}
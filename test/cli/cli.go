@@ -0,0 +1,28 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli has the flag and error helpers of the commands under cmd.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StringList is a flag that may be given more than once, collecting each value
+type StringList []string
+
+func (s *StringList) String() string { return strings.Join(*s, ",") }
+
+func (s *StringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// Fail prints a message and an error to stderr and exits with status 2, the
+// status of the commands for errors other than their findings
+func Fail(msg string, err error) {
+	fmt.Fprintln(os.Stderr, msg, err)
+	os.Exit(2)
+}
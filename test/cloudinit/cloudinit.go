@@ -0,0 +1,181 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudinit renders the cloud-config templates of the jump and NFS
+// VMs offline, the way vms.tf renders them with templatefile, and lints the
+// result: it must be cloud-config YAML, and it must agree with the rest of
+// the stack, like the RAID disk count of the NFS server and the mount the
+// jump server makes of the RWX file store.
+package cloudinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// JumpTemplate is the cloud-config template of the jump VM
+	JumpTemplate = "files/cloud-init/jump/cloud-config"
+	// NFSTemplate is the cloud-config template of the NFS VM
+	NFSTemplate = "files/cloud-init/nfs/cloud-config"
+)
+
+// MountOptions are the NFS mount options of the jump VM, see vms.tf
+const MountOptions = "rsize=1048576,wsize=1048576,hard,timeo=600,retrans=2,noresvport"
+
+// UnknownEndpoint stands in for rwx_filestore_endpoint before apply, when the
+// address of the file store is not known yet
+const UnknownEndpoint = "rwx-filestore.invalid"
+
+// Render renders a template file the way templatefile does, failing when the
+// template references a variable missing from vars
+func Render(path string, vars map[string]string) ([]byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	expr, diags := hclsyntax.ParseTemplate(src, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := make(map[string]cty.Value, len(vars))
+	for name, v := range vars {
+		values[name] = cty.StringVal(v)
+	}
+	for _, traversal := range expr.Variables() {
+		name := traversal.RootName()
+		if _, ok := values[name]; !ok {
+			return nil, fmt.Errorf("%s: vars map does not contain key %q", traversal.SourceRange(), name)
+		}
+	}
+
+	val, diags := expr.Value(&hcl.EvalContext{Variables: values})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if !val.Type().Equals(cty.String) || val.IsNull() {
+		return nil, fmt.Errorf("%s: template did not render to a string", path)
+	}
+	return []byte(val.AsString()), nil
+}
+
+// Backend returns the storage backend of the storage_type and
+// storage_type_backend inputs, like local.storage_type_backend
+func Backend(storageType, storageTypeBackend string) string {
+	switch {
+	case storageType == "standard":
+		return "nfs"
+	case storageType == "ha" && storageTypeBackend == "ontap":
+		return "ontap"
+	case storageType == "ha":
+		return "efs"
+	}
+	return "none"
+}
+
+// FilestorePath returns the path the RWX file store of a storage backend
+// exports, like local.rwx_filestore_path
+func FilestorePath(backend string) string {
+	switch backend {
+	case "efs":
+		return "/"
+	case "ontap":
+		return "/ontap"
+	case "nfs":
+		return "/export"
+	}
+	return ""
+}
+
+// JumpVars are the variables of the jump VM template
+type JumpVars struct {
+	VMAdmin string
+	// StorageType is the storage_type input, none renders no mount
+	StorageType          string
+	RWXFilestoreEndpoint string
+	RWXFilestorePath     string
+	JumpRWXFilestorePath string
+}
+
+// Mount returns the fstab entry of the RWX file store, nil when storage_type
+// is none
+func (v JumpVars) Mount() []string {
+	if v.StorageType == "none" {
+		return nil
+	}
+	return []string{v.RWXFilestoreEndpoint + ":" + v.RWXFilestorePath, v.JumpRWXFilestorePath, "nfs", MountOptions, "0", "0"}
+}
+
+// Map returns the variables as vms.tf passes them to templatefile
+func (v JumpVars) Map() map[string]string {
+	mounts := "[]"
+	if mount := v.Mount(); mount != nil {
+		data, _ := json.Marshal(mount)
+		mounts = string(data)
+	}
+	return map[string]string{
+		"mounts":                  mounts,
+		"rwx_filestore_endpoint":  v.RWXFilestoreEndpoint,
+		"rwx_filestore_path":      v.RWXFilestorePath,
+		"jump_rwx_filestore_path": v.JumpRWXFilestorePath,
+		"vm_admin":                v.VMAdmin,
+	}
+}
+
+// NFSVars are the variables of the NFS VM template
+type NFSVars struct {
+	VMAdmin            string
+	PublicSubnetCIDRs  []string
+	PrivateSubnetCIDRs []string
+}
+
+// Map returns the variables as vms.tf passes them to templatefile
+func (v NFSVars) Map() map[string]string {
+	return map[string]string{
+		"vm_admin":             v.VMAdmin,
+		"public_subnet_cidrs":  strings.Join(v.PublicSubnetCIDRs, " "),
+		"private_subnet_cidrs": strings.Join(v.PrivateSubnetCIDRs, " "),
+	}
+}
+
+// RAIDDisks returns the data_disk_count vms.tf gives the NFS VM, which must be
+// a literal number
+func RAIDDisks(dir string) (int, error) {
+	path := filepath.Join(dir, "vms.tf")
+	f, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return 0, diags
+	}
+	content, _, diags := f.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+	})
+	if diags.HasErrors() {
+		return 0, diags
+	}
+	for _, block := range content.Blocks {
+		if block.Labels[0] != "nfs" {
+			continue
+		}
+		attrs, _ := block.Body.JustAttributes()
+		attr, ok := attrs["data_disk_count"]
+		if !ok {
+			return 0, fmt.Errorf("%s: module nfs has no data_disk_count", path)
+		}
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || !val.Type().Equals(cty.Number) || val.IsNull() {
+			return 0, fmt.Errorf("%s: module nfs data_disk_count is not a literal number", attr.Expr.Range())
+		}
+		n, _ := val.AsBigFloat().Int64()
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("%s: no module nfs", path)
+}
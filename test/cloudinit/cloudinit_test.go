@@ -0,0 +1,213 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jumpVars = JumpVars{
+		VMAdmin:              "jumpuser",
+		StorageType:          "standard",
+		RWXFilestoreEndpoint: "192.168.0.10",
+		RWXFilestorePath:     "/export",
+		JumpRWXFilestorePath: "/viya-share",
+	}
+	nfsVars = NFSVars{
+		VMAdmin:            "nfsuser",
+		PublicSubnetCIDRs:  []string{"192.168.129.0/25", "192.168.129.128/25"},
+		PrivateSubnetCIDRs: []string{"192.168.0.0/18"},
+	}
+)
+
+func render(t *testing.T, template string, vars map[string]string) []byte {
+	data, err := Render(filepath.Join("..", "..", template), vars)
+	require.NoError(t, err)
+	return data
+}
+
+// messages returns the messages of the findings of a severity
+func messages(r *Result, severity Severity) []string {
+	var messages []string
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			messages = append(messages, f.Message)
+		}
+	}
+	return messages
+}
+
+func TestRenderTemplates(t *testing.T) {
+	t.Parallel()
+
+	disks, err := RAIDDisks("../..")
+	require.NoError(t, err)
+	assert.Equal(t, 4, disks)
+
+	efsVars := jumpVars
+	efsVars.StorageType, efsVars.RWXFilestoreEndpoint, efsVars.RWXFilestorePath = "ha", "fs-0123.efs.us-east-1.amazonaws.com", FilestorePath("efs")
+
+	tests := map[string]*Result{
+		"jump":        LintJump(render(t, JumpTemplate, jumpVars.Map()), jumpVars),
+		"jump on efs": LintJump(render(t, JumpTemplate, efsVars.Map()), efsVars),
+		"nfs":         LintNFS(render(t, NFSTemplate, nfsVars.Map()), nfsVars, disks),
+	}
+	for name, result := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, result.Findings)
+		})
+	}
+}
+
+func TestRenderMissingVar(t *testing.T) {
+	t.Parallel()
+
+	vars := jumpVars.Map()
+	delete(vars, "vm_admin")
+	_, err := Render(filepath.Join("..", "..", JumpTemplate), vars)
+	assert.ErrorContains(t, err, `vars map does not contain key "vm_admin"`)
+}
+
+func TestRenderStorageNone(t *testing.T) {
+	t.Parallel()
+
+	vars := jumpVars
+	vars.StorageType, vars.RWXFilestoreEndpoint, vars.RWXFilestorePath = "none", "", ""
+	result := LintJump(render(t, JumpTemplate, vars.Map()), vars)
+	assert.True(t, result.Passed())
+	assert.Equal(t, []string{"mounts item 1 is empty, which the cloud-config schema rejects"}, messages(result, Warning))
+}
+
+func TestLintJumpMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		vars     func(v *JumpVars)
+		expected []string
+	}{
+		"filestore path": {
+			vars: func(v *JumpVars) { v.RWXFilestorePath = "/ontap" },
+			expected: []string{
+				"the mount source is 192.168.0.10:/export, rwx_filestore_endpoint:rwx_filestore_path is 192.168.0.10:/ontap",
+				"runcmd does not wait for 192.168.0.10:/ontap to be mounted",
+			},
+		},
+		"mount point": {
+			vars: func(v *JumpVars) { v.JumpRWXFilestorePath = "/mnt/viya-share" },
+			expected: []string{
+				"the mount point is /viya-share, jump_rwx_filestore_path is /mnt/viya-share",
+				"runcmd does not create /mnt/viya-share/pvs",
+			},
+		},
+		"admin": {
+			vars:     func(v *JumpVars) { v.VMAdmin = "ubuntu" },
+			expected: []string{"system_info.default_user.name is jumpuser, vm_admin is ubuntu"},
+		},
+	}
+	data := render(t, JumpTemplate, jumpVars.Map())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			vars := jumpVars
+			tc.vars(&vars)
+			assert.Equal(t, tc.expected, messages(LintJump(data, vars), Error))
+		})
+	}
+}
+
+func TestLintNFSDiskCount(t *testing.T) {
+	t.Parallel()
+
+	result := LintNFS(render(t, NFSTemplate, nfsVars.Map()), nfsVars, 6)
+	assert.False(t, result.Passed())
+	assert.Equal(t, []string{
+		"bootcmd waits for 4 NVMe disks, the VM has 6 RAID disks, boot hangs or builds the array early",
+		"mdadm creates the array of 4 devices, the VM has 6 RAID disks",
+	}, messages(result, Error))
+}
+
+func TestLintNFSExports(t *testing.T) {
+	t.Parallel()
+
+	data := render(t, NFSTemplate, nfsVars.Map())
+	vars := nfsVars
+	vars.PrivateSubnetCIDRs = append(vars.PrivateSubnetCIDRs, "192.168.64.0/18")
+	assert.Equal(t, []string{"/export is not exported to 192.168.64.0/18"}, messages(LintNFS(data, vars, 4), Error))
+
+	empty := NFSVars{VMAdmin: "nfsuser"}
+	assert.Equal(t, []string{"no subnet CIDRs, /export is not exported to any client"}, messages(LintNFS(render(t, NFSTemplate, empty.Map()), empty, 4), Error))
+}
+
+func TestLintSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data     string
+		errors   []string
+		warnings []string
+	}{
+		"not yaml": {
+			data:   "#cloud-config\npackages: [nfs-common\n",
+			errors: []string{"not YAML: error converting YAML to JSON: yaml: line 2: did not find expected ',' or ']'"},
+		},
+		"empty": {
+			data:   "#cloud-config\n",
+			errors: []string{"the cloud-config is empty"},
+		},
+		"types": {
+			data: strings.Join([]string{
+				"#cloud-config",
+				"package_update: yes please",
+				"packages: nfs-common",
+				"runcmd:",
+				"  - echo key: value",
+				"  - [mount, -a]",
+				"  - 42",
+				"mounts:",
+				"  - [a, b, c, d, e, f, g]",
+				"  - [server:/export, /mnt, nfs, defaults, 0, 0]",
+				"write_files:",
+				"  - content: x",
+			}, "\n"),
+			errors: []string{
+				"mounts item 1 has 7 fields, fstab has 6",
+				"mounts item 2 field 5 is a number, quote it",
+				"mounts item 2 field 6 is a number, quote it",
+				"package_update is a string, not a boolean",
+				"packages is a string, not a list",
+				"runcmd item 1 is a mapping, quote the command, it contains \": \"",
+				"runcmd item 3 is a number, not a command",
+				"write_files item 1 has no path",
+			},
+		},
+		"header and unknown keys": {
+			data:     "package_update: true\napt_update: true\n",
+			warnings: []string{"the first line is not #cloud-config, cloud-init only reads it as cloud-config with a text/cloud-config MIME part", "apt_update is not a cloud-config key the linter knows"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &Result{}
+			lint(r, []byte(tc.data))
+			assert.Equal(t, tc.errors, messages(r, Error))
+			assert.Equal(t, tc.warnings, messages(r, Warning))
+		})
+	}
+}
+
+func TestRAIDDisksNotLiteral(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := "module \"nfs\" {\n  source          = \"./modules/aws_vm\"\n  data_disk_count = var.nfs_raid_disk_count\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vms.tf"), []byte(src), 0o644))
+	_, err := RAIDDisks(dir)
+	assert.ErrorContains(t, err, "data_disk_count is not a literal number")
+}
@@ -0,0 +1,363 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Header is the first line cloud-init expects of a cloud-config
+const Header = "#cloud-config"
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Finding is a problem of a rendered cloud-config
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+}
+
+// Result is the findings of a rendered template
+type Result struct {
+	Template string    `json:"template"`
+	Findings []Finding `json:"findings"`
+}
+
+// Passed reports whether no finding is an error
+func (r *Result) Passed() bool {
+	for _, f := range r.Findings {
+		if f.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Result) add(severity Severity, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// kind is the schema of a cloud-config key
+type kind int
+
+const (
+	kindBool kind = iota
+	kindStrings
+	kindObject
+	// kindCommands is a list of shell strings or argument lists
+	kindCommands
+	// kindPackages is a list of names or [name, version] lists
+	kindPackages
+	// kindMounts is a list of fstab entries of one to six fields
+	kindMounts
+	// kindFiles is a list of write_files entries
+	kindFiles
+	kindSystemInfo
+)
+
+// schema is the subset of the cloud-config schema the templates use, plus the
+// keys they are likely to grow
+var schema = map[string]kind{
+	"system_info":                kindSystemInfo,
+	"package_update":             kindBool,
+	"package_upgrade":            kindBool,
+	"package_reboot_if_required": kindBool,
+	"packages":                   kindPackages,
+	"mounts":                     kindMounts,
+	"bootcmd":                    kindCommands,
+	"runcmd":                     kindCommands,
+	"write_files":                kindFiles,
+	"ssh_authorized_keys":        kindStrings,
+	"users":                      kindObject,
+	"final_message":              kindObject,
+}
+
+// typeName names the type of a decoded YAML value
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "mapping"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// lint parses a rendered cloud-config and checks it against the schema, it
+// returns nil when the document does not parse
+func lint(r *Result, data []byte) map[string]interface{} {
+	if first, _, _ := bytes.Cut(data, []byte("\n")); string(bytes.TrimSpace(first)) != Header {
+		r.add(Warning, "the first line is not %s, cloud-init only reads it as cloud-config with a text/cloud-config MIME part", Header)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		r.add(Error, "not YAML: %v", err)
+		return nil
+	}
+	if doc == nil {
+		r.add(Error, "the cloud-config is empty")
+		return nil
+	}
+
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		k, ok := schema[key]
+		if !ok {
+			r.add(Warning, "%s is not a cloud-config key the linter knows", key)
+			continue
+		}
+		checkKey(r, key, k, doc[key])
+	}
+	return doc
+}
+
+func checkKey(r *Result, key string, k kind, v interface{}) {
+	switch k {
+	case kindBool:
+		if _, ok := v.(bool); !ok {
+			r.add(Error, "%s is a %s, not a boolean", key, typeName(v))
+		}
+		return
+	case kindObject:
+		return
+	case kindSystemInfo:
+		if _, ok := v.(map[string]interface{}); !ok {
+			r.add(Error, "%s is a %s, not a mapping", key, typeName(v))
+		}
+		return
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		r.add(Error, "%s is a %s, not a list", key, typeName(v))
+		return
+	}
+	for i, item := range items {
+		switch k {
+		case kindStrings:
+			if _, ok := item.(string); !ok {
+				r.add(Error, "%s item %d is a %s, not a string", key, i+1, typeName(item))
+			}
+		case kindCommands:
+			switch item := item.(type) {
+			case string:
+			case []interface{}:
+				checkStrings(r, key, i, item)
+			case map[string]interface{}:
+				r.add(Error, "%s item %d is a mapping, quote the command, it contains \": \"", key, i+1)
+			default:
+				r.add(Error, "%s item %d is a %s, not a command", key, i+1, typeName(item))
+			}
+		case kindPackages:
+			switch item := item.(type) {
+			case string:
+			case []interface{}:
+				if len(item) < 1 || len(item) > 2 {
+					r.add(Error, "%s item %d has %d fields, expected a name and an optional version", key, i+1, len(item))
+				}
+				checkStrings(r, key, i, item)
+			default:
+				r.add(Error, "%s item %d is a %s, not a package", key, i+1, typeName(item))
+			}
+		case kindMounts:
+			fields, ok := item.([]interface{})
+			switch {
+			case !ok:
+				r.add(Error, "%s item %d is a %s, not a list of fstab fields", key, i+1, typeName(item))
+			case len(fields) == 0:
+				r.add(Warning, "%s item %d is empty, which the cloud-config schema rejects", key, i+1)
+			case len(fields) > 6:
+				r.add(Error, "%s item %d has %d fields, fstab has 6", key, i+1, len(fields))
+			default:
+				checkStrings(r, key, i, fields)
+			}
+		case kindFiles:
+			file, ok := item.(map[string]interface{})
+			if !ok {
+				r.add(Error, "%s item %d is a %s, not a mapping", key, i+1, typeName(item))
+				continue
+			}
+			if path, _ := file["path"].(string); path == "" {
+				r.add(Error, "%s item %d has no path", key, i+1)
+			}
+		}
+	}
+}
+
+func checkStrings(r *Result, key string, i int, items []interface{}) {
+	for j, item := range items {
+		if _, ok := item.(string); !ok {
+			r.add(Error, "%s item %d field %d is a %s, quote it", key, i+1, j+1, typeName(item))
+		}
+	}
+}
+
+// commands returns the commands of a bootcmd or runcmd list as shell strings
+func commands(doc map[string]interface{}, key string) []string {
+	items, _ := doc[key].([]interface{})
+	var commands []string
+	for _, item := range items {
+		switch item := item.(type) {
+		case string:
+			commands = append(commands, item)
+		case []interface{}:
+			args := make([]string, len(item))
+			for i, arg := range item {
+				args[i] = fmt.Sprint(arg)
+			}
+			commands = append(commands, strings.Join(args, " "))
+		}
+	}
+	return commands
+}
+
+// find returns the first command containing substr
+func find(commands []string, substr string) (string, bool) {
+	for _, c := range commands {
+		if strings.Contains(c, substr) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// checkAdmin checks the default user is the VM admin
+func checkAdmin(r *Result, doc map[string]interface{}, admin string) {
+	systemInfo, _ := doc["system_info"].(map[string]interface{})
+	defaultUser, _ := systemInfo["default_user"].(map[string]interface{})
+	name, _ := defaultUser["name"].(string)
+	switch {
+	case name == "":
+		r.add(Error, "system_info.default_user has no name, vm_admin is empty")
+	case name != admin:
+		r.add(Error, "system_info.default_user.name is %s, vm_admin is %s", name, admin)
+	}
+}
+
+// LintJump lints the rendered jump VM template
+func LintJump(data []byte, vars JumpVars) *Result {
+	r := &Result{Template: JumpTemplate, Findings: []Finding{}}
+	doc := lint(r, data)
+	if doc == nil {
+		return r
+	}
+	checkAdmin(r, doc, vars.VMAdmin)
+
+	mount := vars.Mount()
+	if mount == nil {
+		return r
+	}
+	mounts, _ := doc["mounts"].([]interface{})
+	if len(mounts) != 1 {
+		r.add(Error, "mounts has %d entries, expected the RWX file store only", len(mounts))
+		return r
+	}
+	fields, _ := mounts[0].([]interface{})
+	if len(fields) < 3 {
+		r.add(Error, "the RWX file store mount has %d fields, expected source, mount point and type", len(fields))
+		return r
+	}
+	if fields[0] != mount[0] {
+		r.add(Error, "the mount source is %v, rwx_filestore_endpoint:rwx_filestore_path is %s", fields[0], mount[0])
+	}
+	if fields[1] != mount[1] {
+		r.add(Error, "the mount point is %v, jump_rwx_filestore_path is %s", fields[1], mount[1])
+	}
+	if fields[2] != "nfs" {
+		r.add(Error, "the mount type is %v, not nfs", fields[2])
+	}
+
+	runcmd := commands(doc, "runcmd")
+	if _, ok := find(runcmd, fmt.Sprintf(`grep "%s"`, mount[0])); !ok {
+		r.add(Error, "runcmd does not wait for %s to be mounted", mount[0])
+	}
+	if _, ok := find(runcmd, "mkdir -p "+vars.JumpRWXFilestorePath+"/pvs"); !ok {
+		r.add(Error, "runcmd does not create %s/pvs", vars.JumpRWXFilestorePath)
+	}
+	return r
+}
+
+var (
+	diskWait    = regexp.MustCompile(`lsblk.*nvme.*-lt\s+(\d+)`)
+	raidDevices = regexp.MustCompile(`--raid-devices=(\d+)`)
+)
+
+// LintNFS lints the rendered NFS VM template, disks is the number of RAID
+// disks attached to the VM
+func LintNFS(data []byte, vars NFSVars, disks int) *Result {
+	r := &Result{Template: NFSTemplate, Findings: []Finding{}}
+	doc := lint(r, data)
+	if doc == nil {
+		return r
+	}
+	checkAdmin(r, doc, vars.VMAdmin)
+
+	if c, ok := find(commands(doc, "bootcmd"), "nvme"); !ok {
+		r.add(Error, "bootcmd does not wait for the RAID disks")
+	} else if m := diskWait.FindStringSubmatch(c); m == nil {
+		r.add(Error, "bootcmd does not compare the NVMe disk count: %s", c)
+	} else if n, _ := strconv.Atoi(m[1]); n != disks {
+		r.add(Error, "bootcmd waits for %d NVMe disks, the VM has %d RAID disks, boot hangs or builds the array early", n, disks)
+	}
+
+	runcmd := commands(doc, "runcmd")
+	if c, ok := find(runcmd, "mdadm --create"); !ok {
+		r.add(Error, "runcmd does not create the RAID array")
+	} else if m := raidDevices.FindStringSubmatch(c); m == nil {
+		r.add(Error, "mdadm --create has no --raid-devices: %s", c)
+	} else if n, _ := strconv.Atoi(m[1]); n != disks {
+		r.add(Error, "mdadm creates the array of %d devices, the VM has %d RAID disks", n, disks)
+	}
+
+	export := FilestorePath("nfs")
+	if _, ok := find(runcmd, fmt.Sprintf("mntDir='%s'", export)); !ok {
+		r.add(Error, "runcmd does not mount the array at %s, the rwx_filestore_path of the nfs backend", export)
+	}
+	if _, ok := find(runcmd, fmt.Sprintf(`echo "%s `, export)); !ok {
+		r.add(Error, "runcmd does not add %s to /etc/exports", export)
+	}
+	cidrs := append(append([]string{}, vars.PublicSubnetCIDRs...), vars.PrivateSubnetCIDRs...)
+	if len(cidrs) == 0 {
+		r.add(Error, "no subnet CIDRs, %s is not exported to any client", export)
+	}
+	if c, ok := find(runcmd, "for cidr_block in"); ok {
+		for _, cidr := range cidrs {
+			if !strings.Contains(" "+c+" ", " "+cidr+" ") {
+				r.add(Error, "%s is not exported to %s", export, cidr)
+			}
+		}
+	} else {
+		r.add(Error, "runcmd does not loop over the subnet CIDRs")
+	}
+	return r
+}
@@ -0,0 +1,22 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"fmt"
+
+	"test/report"
+)
+
+// Table renders the findings of a template
+func (r *Result) Table() *report.Table {
+	t := &report.Table{
+		Title:   fmt.Sprintf("%s (%d findings)", r.Template, len(r.Findings)),
+		Columns: []report.Column{{Header: "Severity"}, {Header: "Message"}},
+	}
+	for _, f := range r.Findings {
+		t.AddRow(string(f.Severity), f.Message)
+	}
+	return t
+}
@@ -0,0 +1,69 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// buildinfo is the program of the buildinfo external data source of main.tf,
+// behind the sas-iac-buildinfo ConfigMap. It reads the query of the data
+// source from stdin and writes the git hash, dirty state and tag, the
+// Terraform version, revision and provider selections, and the checksum of
+// the inputs or tfvars files as one JSON object. With -configmap it reads a
+// sas-iac-buildinfo ConfigMap back instead.
+//
+// Usage:
+//
+//	go build -o ../files/tools/iac-buildinfo ./cmd/buildinfo
+//	echo '{"dir": "..", "var_files": "terraform.tfvars"}' | go run ./cmd/buildinfo
+//	kubectl get configmap sas-iac-buildinfo -n kube-system -o yaml > buildinfo.yaml
+//	go run ./cmd/buildinfo -configmap buildinfo.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/buildinfo"
+	"test/report"
+)
+
+// fail exits 1, the external data source shows stderr when the program fails
+func fail(msg string, err error) {
+	fmt.Fprintln(os.Stderr, msg, err)
+	os.Exit(1)
+}
+
+func main() {
+	configMap := flag.String("configmap", "", "Path to a sas-iac-buildinfo ConfigMap, YAML or JSON, to read instead of collecting")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format of -configmap, one of %v", report.Formats))
+	flag.Parse()
+
+	if *configMap == "" {
+		query, err := buildinfo.ParseQuery(os.Stdin)
+		if err != nil {
+			fail("Error reading query:", err)
+		}
+		info, err := buildinfo.Collect(query)
+		if err != nil {
+			fail("Error collecting build info:", err)
+		}
+		if err := info.WriteResult(os.Stdout); err != nil {
+			fail("Error writing result:", err)
+		}
+		return
+	}
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		fail("Error:", err)
+	}
+	data, err := os.ReadFile(*configMap)
+	if err != nil {
+		fail("Error reading ConfigMap:", err)
+	}
+	info, err := buildinfo.ParseConfigMap(data)
+	if err != nil {
+		fail("Error parsing ConfigMap:", err)
+	}
+	if err := report.Write(os.Stdout, outputFormat, info, info.Tables()...); err != nil {
+		fail("Error writing report:", err)
+	}
+}
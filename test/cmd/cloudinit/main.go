@@ -0,0 +1,115 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// cloudinit renders the cloud-config templates of the jump and NFS VMs with
+// the values of the given tfvars, lints them and exits 1 when cloud-init would
+// reject them or they disagree with the rest of the stack.
+//
+// Usage:
+//
+//	go run ./cmd/cloudinit -var-file ../terraform.tfvars
+//	go run ./cmd/cloudinit -var-file ../terraform.tfvars -print nfs
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"test/cli"
+	"test/cloudinit"
+	"test/report"
+	"test/tfvars"
+)
+
+func main() {
+	var varFiles cli.StringList
+	dir := flag.String("dir", "..", "Path to the viya4-iac-aws repository")
+	flag.Var(&varFiles, "var-file", "Path to a .tfvars file, may be repeated")
+	endpoint := flag.String("rwx-filestore-endpoint", cloudinit.UnknownEndpoint, "Address of the RWX file store, known after apply")
+	printVM := flag.String("print", "", "Print the rendered template of the jump or nfs VM instead of linting")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	inputs, err := tfvars.LoadInputs(*dir, varFiles...)
+	if err != nil {
+		cli.Fail("Error reading inputs:", err)
+	}
+	var subnets map[string][]string
+	if err := inputs.Decode("subnets", &subnets); err != nil {
+		cli.Fail("Error reading inputs:", err)
+	}
+
+	storageType := inputs.String("storage_type")
+	backend := cloudinit.Backend(storageType, inputs.String("storage_type_backend"))
+	jump := cloudinit.JumpVars{
+		VMAdmin:              inputs.String("jump_vm_admin"),
+		StorageType:          storageType,
+		RWXFilestoreEndpoint: *endpoint,
+		RWXFilestorePath:     cloudinit.FilestorePath(backend),
+		JumpRWXFilestorePath: inputs.String("jump_rwx_filestore_path"),
+	}
+	if backend == "none" {
+		jump.RWXFilestoreEndpoint = ""
+	}
+	nfs := cloudinit.NFSVars{
+		VMAdmin:            inputs.String("nfs_vm_admin"),
+		PublicSubnetCIDRs:  subnets["public"],
+		PrivateSubnetCIDRs: subnets["private"],
+	}
+
+	if *printVM != "" {
+		var data []byte
+		switch *printVM {
+		case "jump":
+			data, err = cloudinit.Render(filepath.Join(*dir, cloudinit.JumpTemplate), jump.Map())
+		case "nfs":
+			data, err = cloudinit.Render(filepath.Join(*dir, cloudinit.NFSTemplate), nfs.Map())
+		default:
+			err = fmt.Errorf("unknown VM %q, expected jump or nfs", *printVM)
+		}
+		if err != nil {
+			cli.Fail("Error:", err)
+		}
+		os.Stdout.Write(data)
+		return
+	}
+
+	var results []*cloudinit.Result
+	if inputs.Bool("create_jump_vm") {
+		data, err := cloudinit.Render(filepath.Join(*dir, cloudinit.JumpTemplate), jump.Map())
+		if err != nil {
+			cli.Fail("Error rendering the jump VM template:", err)
+		}
+		results = append(results, cloudinit.LintJump(data, jump))
+	}
+	if backend == "nfs" {
+		disks, err := cloudinit.RAIDDisks(*dir)
+		if err != nil {
+			cli.Fail("Error reading the RAID disk count:", err)
+		}
+		data, err := cloudinit.Render(filepath.Join(*dir, cloudinit.NFSTemplate), nfs.Map())
+		if err != nil {
+			cli.Fail("Error rendering the NFS VM template:", err)
+		}
+		results = append(results, cloudinit.LintNFS(data, nfs, disks))
+	}
+
+	tables := make([]*report.Table, len(results))
+	passed := true
+	for i, r := range results {
+		tables[i] = r.Table()
+		passed = passed && r.Passed()
+	}
+	if err := report.Write(os.Stdout, outputFormat, results, tables...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// cost estimates the monthly cost of a plan, or the cost difference between two
+// plans, from `terraform show -json` output or a saved PlanStruct.
+//
+// Usage:
+//
+//	terraform show -json plan.tfplan > plan.json
+//	go run ./cmd/cost -plan plan.json
+//	go run ./cmd/cost -format markdown -plan before.json -compare after.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/cost"
+	"test/planfile"
+	"test/report"
+)
+
+func main() {
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	comparePath := flag.String("compare", "", "Path to a later plan, to report the cost difference from -plan")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	pricesPath := flag.String("prices", "", "Path to a price table to use instead of the embedded one")
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	prices := cost.DefaultPrices()
+	if *pricesPath != "" {
+		f, err := os.Open(*pricesPath)
+		if err != nil {
+			cli.Fail("Error reading price table:", err)
+		}
+		prices, err = cost.LoadPrices(f)
+		f.Close()
+		if err != nil {
+			cli.Fail("Error reading price table:", err)
+		}
+	}
+
+	var estimates []*cost.Estimate
+	paths := []string{*planPath}
+	if *comparePath != "" {
+		paths = append(paths, *comparePath)
+	}
+	for _, path := range paths {
+		plan, err := planfile.Load(path)
+		if err != nil {
+			cli.Fail("Error reading plan:", err)
+		}
+		estimates = append(estimates, cost.EstimatePlan(plan, prices))
+	}
+
+	if len(estimates) == 1 {
+		e := estimates[0]
+		tables := []*report.Table{e.Table()}
+		if unpriced := e.UnpricedTable(); unpriced != nil {
+			tables = append(tables, unpriced)
+		}
+		err = report.Write(os.Stdout, outputFormat, e, tables...)
+	} else {
+		d := cost.Compare(estimates[0], estimates[1])
+		err = report.Write(os.Stdout, outputFormat, d, d.Table(), d.TotalsTable())
+	}
+	if err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// drift compares a state with the tfvars it was applied with, without
+// refreshing, and exits 1 when the next apply would revert changes made
+// outside terraform.
+//
+// Usage:
+//
+//	go run ./cmd/drift -state ../terraform.tfstate -var-file ../terraform.tfvars
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/drift"
+	"test/report"
+	"test/statefile"
+	"test/tfvars"
+)
+
+func main() {
+	var varFiles cli.StringList
+	dir := flag.String("dir", "..", "Path to the viya4-iac-aws repository")
+	statePath := flag.String("state", "../terraform.tfstate", "Path to a .tfstate file or `terraform show -json` output")
+	flag.Var(&varFiles, "var-file", "Path to a .tfvars file, may be repeated")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+
+	state, err := statefile.Load(*statePath)
+	if err != nil {
+		cli.Fail("Error reading state:", err)
+	}
+	inputs, err := tfvars.LoadInputs(*dir, varFiles...)
+	if err != nil {
+		cli.Fail("Error reading inputs:", err)
+	}
+	result, err := drift.Detect(state, inputs)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if err := report.Write(os.Stdout, outputFormat, result, result.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !result.Passed() {
+		os.Exit(1)
+	}
+}
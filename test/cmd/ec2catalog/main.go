@@ -0,0 +1,79 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// ec2catalog refreshes the embedded EC2 instance type catalog from the output
+// of the AWS CLI. Entries that are not in the new output are kept.
+//
+// Usage:
+//
+//	aws ec2 describe-instance-types --output json > types.json
+//	go run ./cmd/ec2catalog -describe types.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"test/cli"
+	"test/ec2catalog"
+)
+
+func main() {
+	var describeFiles cli.StringList
+	catalogPath := flag.String("catalog", "ec2catalog/catalog.json", "Path to the catalog to update")
+	out := flag.String("out", "", "Path to write the updated catalog to (default: -catalog)")
+	flag.Var(&describeFiles, "describe", "Path to `aws ec2 describe-instance-types --output json` output, may be repeated")
+	flag.Parse()
+
+	if len(describeFiles) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: at least one -describe file is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *out == "" {
+		*out = *catalogPath
+	}
+
+	f, err := os.Open(*catalogPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading catalog:", err)
+		os.Exit(2)
+	}
+	catalog, err := ec2catalog.Load(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading catalog:", err)
+		os.Exit(2)
+	}
+
+	for _, path := range describeFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading describe output:", err)
+			os.Exit(2)
+		}
+		types, err := ec2catalog.ParseDescribeInstanceTypes(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+			os.Exit(2)
+		}
+		catalog.Merge(types...)
+		fmt.Printf("%s: %d instance type(s)\n", path, len(types))
+	}
+	catalog.Generated = time.Now().UTC().Format("2006-01-02")
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error encoding catalog:", err)
+		os.Exit(2)
+	}
+	if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing catalog:", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Wrote %d instance types to %s\n", len(catalog.InstanceTypes), *out)
+}
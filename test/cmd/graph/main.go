@@ -0,0 +1,75 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// graph exports the resource dependency graph of the configuration in a plan
+// as DOT or Mermaid, with the critical path highlighted.
+//
+// Usage:
+//
+//	terraform show -json plan.tfplan > plan.json
+//	go run ./cmd/graph -plan plan.json -collapse-external | dot -Tsvg > graph.svg
+//	go run ./cmd/graph -plan plan.json -format mermaid -module module.nfs -collapse module.eks
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/graph"
+	"test/planfile"
+)
+
+func main() {
+	var modules, collapse cli.StringList
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	flag.Var(&modules, "module", "Only show the resources of this module path and their direct neighbors, \"root\" for the root module, may be repeated")
+	flag.Var(&collapse, "collapse", "Module path to show as a single node, may be repeated")
+	collapseExternal := flag.Bool("collapse-external", false, "Show every module call with a registry or remote source as a single node")
+	critical := flag.Bool("critical-path", true, "Highlight the longest chain of dependencies")
+	format := flag.String("format", string(graph.DOT), fmt.Sprintf("Output format, one of %v", graph.Formats))
+	flag.Parse()
+
+	outputFormat, err := graph.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	if plan.RawPlan.Config == nil {
+		cli.Fail("Error:", fmt.Errorf("%s has no configuration section", *planPath))
+	}
+
+	g := graph.FromPlan(plan)
+	if *collapseExternal {
+		collapse = append(collapse, g.ExternalModules()...)
+	}
+	if len(collapse) > 0 {
+		g = g.Collapse(collapse...)
+	}
+	if len(modules) > 0 {
+		for i, m := range modules {
+			if m == "root" {
+				modules[i] = ""
+			}
+		}
+		g = g.Filter(modules...)
+	}
+
+	var path []string
+	if *critical {
+		path = g.CriticalPath()
+	}
+	if err := g.Write(os.Stdout, outputFormat, path); err != nil {
+		cli.Fail("Error writing graph:", err)
+	}
+}
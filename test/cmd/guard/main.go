@@ -0,0 +1,55 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// guard lists the deletes and replacements of a plan by what they destroy, and
+// exits 1 when a data-destroying change is not on the allow-list.
+//
+// Usage:
+//
+//	go run ./cmd/guard -plan plan.json
+//	go run ./cmd/guard -plan plan.json -allow 'module.nfs[0].aws_ebs_volume.raid_disk[*]'
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/guard"
+	"test/planfile"
+	"test/report"
+)
+
+func main() {
+	var allow cli.StringList
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	flag.Var(&allow, "allow", "Address of a resource whose data may be destroyed, where * matches any characters, may be repeated")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	result := guard.Check(plan, allow...)
+	for _, a := range result.UnusedAllows {
+		fmt.Fprintln(os.Stderr, "Warning: -allow matched no data-destroying change:", a)
+	}
+	if err := report.Write(os.Stdout, outputFormat, result, result.Table()); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if result.Failed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// handoff writes the bundle the viya4-deployment stage starts from: the
+// handoff document, the kubeconfig, an SSH config and the secrets, from the
+// state or the outputs of an applied stack.
+//
+// Usage:
+//
+//	terraform output -json > outputs.json
+//	go run ./cmd/handoff -in outputs.json -out ../handoff -ssh-key ~/.ssh/id_rsa
+//	go run ./cmd/handoff -in ../terraform.tfstate -out ../handoff
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"test/cli"
+	"test/handoff"
+	"test/outputs"
+)
+
+func main() {
+	in := flag.String("in", "", "Path to `terraform output -json` output, a .tfstate file or `terraform show -json` output")
+	out := flag.String("out", "", "Directory to write the bundle to, keep it out of version control")
+	sshKey := flag.String("ssh-key", "", "Path to the private key of the ssh_public_key input, for the SSH config")
+	flag.Parse()
+
+	if *in == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "Error: -in and -out are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	o, err := outputs.Load(*in)
+	if err != nil {
+		cli.Fail("Error reading outputs:", err)
+	}
+	bundle, err := handoff.Build(o, handoff.Options{SSHPrivateKey: *sshKey})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	if err := bundle.Write(*out); err != nil {
+		cli.Fail("Error writing bundle:", err)
+	}
+	for _, path := range bundle.Paths() {
+		fmt.Println(filepath.Join(*out, filepath.FromSlash(path)))
+	}
+}
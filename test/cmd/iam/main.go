@@ -0,0 +1,93 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// iam derives the IAM actions a plan needs, and either writes them as a
+// minimal policy or reports the actions the shipped policy is missing and the
+// ones it grants without need. The report exits 1 when actions are missing.
+//
+// Usage:
+//
+//	terraform plan -var-file terraform.tfvars -out plan.tfplan
+//	terraform show -json plan.tfplan > plan.json
+//	go run ./cmd/iam -plan plan.json
+//	go run ./cmd/iam -plan plan.json -generate > minimal-policy.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/iam"
+	"test/planfile"
+	"test/report"
+)
+
+func main() {
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	policyPath := flag.String("policy", "../files/policies/devops-iac-eks-policy.json", "Path to the policy to compare with")
+	tablePath := flag.String("actions", "", "Path to an action table to use instead of the embedded one")
+	generate := flag.Bool("generate", false, "Write the minimal policy for the plan instead of the report")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	table := iam.DefaultTable()
+	if *tablePath != "" {
+		f, err := os.Open(*tablePath)
+		if err != nil {
+			cli.Fail("Error reading action table:", err)
+		}
+		table, err = iam.LoadTable(f)
+		f.Close()
+		if err != nil {
+			cli.Fail("Error reading action table:", err)
+		}
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	req := iam.Generate(plan, table)
+	for _, u := range req.Unmapped {
+		fmt.Fprintln(os.Stderr, "Warning: no actions for", u)
+	}
+
+	if *generate {
+		data, err := req.Policy().MarshalIndent()
+		if err != nil {
+			cli.Fail("Error writing policy:", err)
+		}
+		os.Stdout.Write(data)
+		return
+	}
+
+	policy, err := iam.LoadPolicy(*policyPath)
+	if err != nil {
+		cli.Fail("Error reading policy:", err)
+	}
+	diff := iam.Compare(req, policy)
+	result := struct {
+		*iam.Requirements
+		Missing  []iam.Required `json:"missing"`
+		Unneeded []string       `json:"unneeded"`
+	}{req, diff.Missing, diff.Unneeded}
+	tables := append([]*report.Table{req.Table()}, diff.Tables()...)
+	if err := report.Write(os.Stdout, outputFormat, result, tables...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if len(diff.Missing) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// inventory lists what a deployed environment contains from its terraform
+// state and exits 1 when the state has problems, like tainted resources.
+//
+// Usage:
+//
+//	go run ./cmd/inventory -state ../terraform.tfstate
+//	go run ./cmd/inventory -state ../terraform.tfstate -format markdown
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/inventory"
+	"test/report"
+	"test/statefile"
+)
+
+func main() {
+	statePath := flag.String("state", "../terraform.tfstate", "Path to a .tfstate file or `terraform show -json` output")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+
+	state, err := statefile.Load(*statePath)
+	if err != nil {
+		cli.Fail("Error reading state:", err)
+	}
+	inv := inventory.Build(state)
+	if err := report.Write(os.Stdout, outputFormat, inv, inv.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !inv.Passed() {
+		os.Exit(1)
+	}
+}
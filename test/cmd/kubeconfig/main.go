@@ -0,0 +1,88 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// kubeconfig checks a kubeconfig written by modules/kubeconfig and exits 1
+// when it is broken or leaks a token, or converts it between the provider
+// form and the static service account form.
+//
+// Usage:
+//
+//	go run ./cmd/kubeconfig -file ../viya-eks-kubeconfig.conf
+//	go run ./cmd/kubeconfig -file ../viya-eks-kubeconfig.conf -to provider -region us-east-1 -out provider.conf
+//	go run ./cmd/kubeconfig -file ../viya-eks-kubeconfig.conf -to static -token-file token -user viya-cluster-admin-sa -namespace kube-system -out static.conf
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"test/cli"
+	"test/kubeconfig"
+	"test/report"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func main() {
+	path := flag.String("file", "", "Path to the kubeconfig file")
+	to := flag.String("to", "", fmt.Sprintf("Convert to %q or %q instead of checking", kubeconfig.Provider, kubeconfig.Static))
+	out := flag.String("out", "", "Path to write the converted kubeconfig to")
+	region := flag.String("region", "", "AWS region of the cluster, for -to provider")
+	tokenFile := flag.String("token-file", "", "Path to the service account token, for -to static")
+	user := flag.String("user", "", "Name of the service account user, for -to static, defaults to the current user")
+	namespace := flag.String("namespace", "", "Namespace of the context, for -to static")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *path == "" || (*to != "" && *out == "") {
+		fmt.Fprintln(os.Stderr, "Error: -file, and -out with -to, are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *to != "" {
+		config, err := kubeconfig.Load(*path)
+		if err != nil {
+			cli.Fail("Error reading kubeconfig:", err)
+		}
+		var converted *api.Config
+		switch kubeconfig.Mode(*to) {
+		case kubeconfig.Provider:
+			converted, err = kubeconfig.ToProvider(config, *region)
+		case kubeconfig.Static:
+			var token []byte
+			if *tokenFile != "" {
+				if token, err = os.ReadFile(*tokenFile); err != nil {
+					cli.Fail("Error reading token:", err)
+				}
+			}
+			converted, err = kubeconfig.ToStatic(config, *user, strings.TrimSpace(string(token)), *namespace)
+		default:
+			err = fmt.Errorf("unknown form %q, expected %q or %q", *to, kubeconfig.Provider, kubeconfig.Static)
+		}
+		if err != nil {
+			cli.Fail("Error:", err)
+		}
+		if err := kubeconfig.Write(*out, converted); err != nil {
+			cli.Fail("Error writing kubeconfig:", err)
+		}
+		*path = *out
+	}
+
+	result, err := kubeconfig.Check(*path)
+	if err != nil {
+		cli.Fail("Error reading kubeconfig:", err)
+	}
+	if err := report.Write(os.Stdout, outputFormat, result, result.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !result.Passed() {
+		os.Exit(1)
+	}
+}
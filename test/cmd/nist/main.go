@@ -0,0 +1,49 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// nist reports the NIST controls a plan passes and fails, and exits 1 when
+// a control fails.
+//
+// Usage:
+//
+//	go run ./cmd/nist -plan plan.json -format markdown
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/nist"
+	"test/planfile"
+	"test/report"
+)
+
+func main() {
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	result := nist.Check(plan)
+	if err := report.Write(os.Stdout, outputFormat, result, result.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !result.Passed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// policy evaluates guardrail rules against a plan and exits 1 when an error
+// violation is not waived.
+//
+// Usage:
+//
+//	go run ./cmd/policy -plan plan.json
+//	go run ./cmd/policy -plan plan.json -config org-policy.yaml -format sarif > policy.sarif
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/planfile"
+	"test/policy"
+	"test/report"
+)
+
+const sarif = "sarif"
+
+func main() {
+	var configs cli.StringList
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	flag.Var(&configs, "config", "Path to a YAML or JSON policy file with rules, waivers and disabled rules, may be repeated")
+	noBuiltin := flag.Bool("no-builtin", false, "Evaluate only the rules of the -config files")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v or %s", report.Formats, sarif))
+	flag.Parse()
+
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	var outputFormat report.Format
+	if *format != sarif {
+		var err error
+		if outputFormat, err = report.ParseFormat(*format); err != nil {
+			cli.Fail("Error:", err)
+		}
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	var loaded []*policy.Config
+	for _, path := range configs {
+		c, err := policy.LoadConfig(path)
+		if err != nil {
+			cli.Fail("Error reading policy:", err)
+		}
+		loaded = append(loaded, c)
+	}
+	var rules []policy.Rule
+	if !*noBuiltin {
+		rules = policy.Builtin()
+	}
+	rules, waivers := policy.Combine(rules, loaded...)
+
+	result := policy.Evaluate(plan, rules, waivers)
+	if *format == sarif {
+		err = result.WriteSARIF(os.Stdout)
+	} else {
+		err = report.Write(os.Stdout, outputFormat, result, result.Tables()...)
+	}
+	if err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if result.Failed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// preflight checks tfvars files against the viya4-iac-aws configuration before
+// running terraform, so mistakes are reported with readable messages.
+//
+// Usage:
+//
+//	go run ./cmd/preflight -dir ../ -var-file ../terraform.tfvars
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/ec2catalog"
+	"test/naming"
+	"test/nodepool"
+	"test/tfvars"
+)
+
+func appendFindings[T fmt.Stringer](findings []fmt.Stringer, items []T) []fmt.Stringer {
+	for _, item := range items {
+		findings = append(findings, item)
+	}
+	return findings
+}
+
+func main() {
+	var varFiles cli.StringList
+	dir := flag.String("dir", ".", "Path to the viya4-iac-aws root module")
+	flag.Var(&varFiles, "var-file", "Path to a .tfvars file, may be repeated")
+	flag.Parse()
+
+	if len(varFiles) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: at least one -var-file is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	variables, err := tfvars.LoadVariables(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading variables:", err)
+		os.Exit(2)
+	}
+
+	var files []*tfvars.VarFile
+	for _, path := range varFiles {
+		vf, err := tfvars.ParseVarFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading var file:", err)
+			os.Exit(2)
+		}
+		files = append(files, vf)
+	}
+
+	var findings []fmt.Stringer
+	var warnings []fmt.Stringer
+	var notes []string
+	findings = appendFindings(findings, tfvars.Check(variables, files...))
+
+	// The remaining checks decode the inputs, which needs well typed values
+	if len(findings) == 0 {
+		in := tfvars.Resolve(variables, files...)
+
+		names, err := naming.Derive(in)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error deriving resource names:", err)
+			os.Exit(2)
+		}
+		violations := naming.Validate(names)
+		findings = appendFindings(findings, violations)
+		if len(violations) > 0 {
+			notes = append(notes, fmt.Sprintf("Longest prefix allowed with these inputs: %d characters", naming.MaxPrefixLength(names)))
+		}
+
+		compat, err := ec2catalog.Default().CheckInputs(in)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error checking instance types:", err)
+			os.Exit(2)
+		}
+		for _, f := range compat {
+			if f.IsWarning() {
+				warnings = append(warnings, f)
+			} else {
+				findings = append(findings, f)
+			}
+		}
+
+		problems, err := nodepool.Check(in)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error checking node pool labels and taints:", err)
+			os.Exit(2)
+		}
+		findings = appendFindings(findings, problems)
+	}
+
+	for _, f := range findings {
+		fmt.Println(f)
+	}
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+	for _, note := range notes {
+		fmt.Println(note)
+	}
+
+	if len(findings) > 0 {
+		fmt.Printf("%d problem(s) found\n", len(findings))
+		os.Exit(1)
+	}
+	fmt.Println("No problems found")
+}
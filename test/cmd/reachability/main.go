@@ -0,0 +1,117 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// reachability prints which planned resources reach each other, and which
+// CIDRs reach them, through their security groups.
+//
+// Usage:
+//
+//	go run ./cmd/reachability -plan plan.json -port 22 -port 2049
+//	go run ./cmd/reachability -plan plan.json -to 'module.postgresql["default"].module.db_instance.aws_db_instance.this[0]' -port 5432
+//	go run ./cmd/reachability -plan plan.json -from module.jump[0].aws_instance.vm -to module.nfs[0].aws_instance.vm -port 2049
+//
+// With -from and -to it exits 1 when the destination is unreachable on a port.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"test/cli"
+	"test/planfile"
+	"test/reachability"
+	"test/report"
+)
+
+var defaultPorts = []string{"22", "443", "2049", "5432"}
+
+func main() {
+	var portFlags cli.StringList
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	from := flag.String("from", "", "Address of the source resource, requires -to")
+	to := flag.String("to", "", "Address of the destination resource")
+	flag.Var(&portFlags, "port", fmt.Sprintf("Port as number or protocol/number, may be repeated (default %s)", strings.Join(defaultPorts, ", ")))
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" || (*from != "" && *to == "") {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required, and -from requires -to")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if len(portFlags) == 0 {
+		portFlags = defaultPorts
+	}
+	var ports []reachability.Port
+	for _, s := range portFlags {
+		p, err := reachability.ParsePort(s)
+		if err != nil {
+			cli.Fail("Error:", err)
+		}
+		ports = append(ports, p)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	g := reachability.Build(plan)
+
+	var tables []*report.Table
+	unreachable := false
+	switch {
+	case *from != "":
+		t := &report.Table{
+			Title:   fmt.Sprintf("%s to %s", *from, *to),
+			Columns: []report.Column{{Header: "Port"}, {Header: "Reachable"}, {Header: "Egress rule"}, {Header: "Ingress rule"}},
+		}
+		for _, p := range ports {
+			path, err := g.Reach(*from, *to, p.Protocol, p.Number)
+			if err != nil {
+				cli.Fail("Error:", err)
+			}
+			if path == nil {
+				unreachable = true
+				t.AddRow(p.String(), "no", "", "")
+			} else {
+				t.AddRow(p.String(), "yes", path.Egress, path.Ingress)
+			}
+		}
+		tables = append(tables, t)
+	case *to != "":
+		t := &report.Table{
+			Title:   "Sources admitted to " + *to,
+			Columns: []report.Column{{Header: "Port"}, {Header: "Source"}, {Header: "Rule"}},
+		}
+		for _, p := range ports {
+			sources, err := g.Sources(*to, p.Protocol, p.Number)
+			if err != nil {
+				cli.Fail("Error:", err)
+			}
+			for _, s := range sources {
+				t.AddRow(p.String(), s.String(), s.Rule)
+			}
+		}
+		tables = append(tables, t)
+	default:
+		for _, p := range ports {
+			tables = append(tables, g.MatrixTable(p), g.SourcesTable(p))
+		}
+	}
+	if len(g.Unresolved) > 0 {
+		tables = append(tables, g.UnresolvedTable())
+	}
+
+	if err := report.Write(os.Stdout, outputFormat, g, tables...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if unreachable {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// sizing checks the node pools of tfvars files, or the node groups of a plan,
+// against a SAS Viya sizing profile.
+//
+// Usage:
+//
+//	go run ./cmd/sizing -dir .. -var-file ../terraform.tfvars -profile medium
+//	go run ./cmd/sizing -plan plan.json -format markdown
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/ec2catalog"
+	"test/planfile"
+	"test/report"
+	"test/sizing"
+	"test/tfvars"
+)
+
+func main() {
+	var varFiles cli.StringList
+	dir := flag.String("dir", ".", "Path to the viya4-iac-aws root module")
+	flag.Var(&varFiles, "var-file", "Path to a .tfvars file, may be repeated")
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct, instead of -var-file")
+	profileName := flag.String("profile", "", "Sizing profile (default: the profiles' default)")
+	profilesPath := flag.String("profiles", "", "Path to sizing profiles to use instead of the embedded ones")
+	maxPods := flag.Int("max-pods", 0, "Pods per node kubelet reserves memory for (default: the profiles' maxPods)")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if (len(varFiles) == 0) == (*planPath == "") {
+		fmt.Fprintln(os.Stderr, "Error: either -var-file or -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	profiles := sizing.DefaultProfiles()
+	if *profilesPath != "" {
+		f, err := os.Open(*profilesPath)
+		if err != nil {
+			cli.Fail("Error reading sizing profiles:", err)
+		}
+		profiles, err = sizing.LoadProfiles(f)
+		f.Close()
+		if err != nil {
+			cli.Fail("Error reading sizing profiles:", err)
+		}
+	}
+	profile, err := profiles.Get(*profileName)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	options := sizing.Options{MaxPods: *maxPods}
+	if options.MaxPods == 0 {
+		options.MaxPods = profiles.MaxPods
+	}
+
+	var pools []sizing.Pool
+	if *planPath != "" {
+		plan, err := planfile.Load(*planPath)
+		if err != nil {
+			cli.Fail("Error reading plan:", err)
+		}
+		pools = sizing.PoolsFromPlan(plan)
+		options.Autoscaling = sizing.AutoscalingPlanned(plan)
+	} else {
+		in, err := tfvars.LoadInputs(*dir, varFiles...)
+		if err != nil {
+			cli.Fail("Error reading inputs:", err)
+		}
+		if pools, err = sizing.PoolsFromInputs(in); err != nil {
+			cli.Fail("Error reading node pools:", err)
+		}
+		options.Autoscaling = in.Bool("autoscaling_enabled")
+	}
+
+	result := sizing.Check(pools, ec2catalog.Default(), profile, options)
+	if err := report.Write(os.Stdout, outputFormat, result, result.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if result.HasErrors() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// summary renders a plan as a Markdown or HTML summary for pull request
+// comments.
+//
+// Usage:
+//
+//	terraform show -json plan.tfplan > plan.json
+//	go run ./cmd/summary -plan plan.json > summary.md
+//	go run ./cmd/summary -plan plan.json -format html > summary.html
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/planfile"
+	"test/summary"
+)
+
+func main() {
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	format := flag.String("format", string(summary.Markdown), fmt.Sprintf("Output format, one of %v", summary.Formats))
+	flag.Parse()
+
+	outputFormat, err := summary.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	if err := summary.Summarize(plan).Write(os.Stdout, outputFormat); err != nil {
+		cli.Fail("Error writing summary:", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// tags checks that the resources of a plan carry project_name and the other
+// required tag keys, and exits 1 when a tag is missing or conflicts.
+//
+// Usage:
+//
+//	go run ./cmd/tags -plan plan.json -require cost_center -require owner
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/planfile"
+	"test/report"
+	"test/tags"
+)
+
+func main() {
+	var required cli.StringList
+	planPath := flag.String("plan", "", "Path to `terraform show -json` output or a saved PlanStruct")
+	flag.Var(&required, "require", "Tag key every taggable resource must carry in addition to project_name, may be repeated")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	if *planPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -plan is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := planfile.Load(*planPath)
+	if err != nil {
+		cli.Fail("Error reading plan:", err)
+	}
+	result := tags.Check(plan, required...)
+	if err := report.Write(os.Stdout, outputFormat, result, result.Table()); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !result.Passed() {
+		os.Exit(1)
+	}
+}
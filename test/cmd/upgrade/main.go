@@ -0,0 +1,79 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// upgrade plans the Kubernetes upgrade from the cluster version in the state
+// to the kubernetes_version of the tfvars one minor version at a time, and
+// exits 1 when a step is blocked.
+//
+// Usage:
+//
+//	go run ./cmd/upgrade -state ../terraform.tfstate -var-file ../terraform.tfvars
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/report"
+	"test/statefile"
+	"test/tfvars"
+	"test/upgrade"
+	"test/versions"
+)
+
+func main() {
+	var varFiles cli.StringList
+	dir := flag.String("dir", "..", "Path to the viya4-iac-aws repository")
+	statePath := flag.String("state", "", "Path to a .tfstate file or `terraform show -json` output, omit for a new cluster")
+	flag.Var(&varFiles, "var-file", "Path to a .tfvars file, may be repeated")
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+
+	inputs, err := tfvars.LoadInputs(*dir, varFiles...)
+	if err != nil {
+		cli.Fail("Error reading inputs:", err)
+	}
+	target, err := upgrade.ParseVersion(inputs.String("kubernetes_version"))
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+	pools, err := inputs.NodePools()
+	if err != nil {
+		cli.Fail("Error reading node pools:", err)
+	}
+
+	in := upgrade.Input{Target: target}
+	var stateGroups []upgrade.NodeGroup
+	if *statePath != "" {
+		state, err := statefile.Load(*statePath)
+		if err != nil {
+			cli.Fail("Error reading state:", err)
+		}
+		if in.Current, err = upgrade.ClusterVersion(state); err != nil {
+			cli.Fail("Error:", err)
+		}
+		stateGroups = upgrade.NodeGroupsFromState(state)
+	}
+	in.NodeGroups = upgrade.MergeNodeGroups(stateGroups, pools)
+
+	if inventory, err := versions.Load(*dir); err == nil {
+		if kubectl := inventory.Component("kubectl"); kubectl != nil && len(kubectl.Versions()) > 0 {
+			in.Kubectl = &kubectl.Versions()[0]
+		}
+	}
+
+	plan := upgrade.Build(in)
+	if err := report.Write(os.Stdout, outputFormat, plan, plan.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !plan.Passed() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// versions reports the Terraform, provider, module and tooling versions pinned
+// across the repository, and exits 1 when the pins contradict each other.
+//
+// Usage:
+//
+//	go run ./cmd/versions -dir ..
+//	go run ./cmd/versions -dir .. -var-file ../terraform.tfvars -index releases.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"test/cli"
+	"test/report"
+	"test/tfvars"
+	"test/versions"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func main() {
+	dir := flag.String("dir", "..", "Path to the viya4-iac-aws repository")
+	varFile := flag.String("var-file", "", "Path to a .tfvars file whose kubernetes_version overrides the default")
+	indexPath := flag.String("index", "", `Path to a JSON file of the newest releases, like {"hashicorp/aws": "6.17.0"}`)
+	format := flag.String("format", string(report.Text), fmt.Sprintf("Output format, one of %v", report.Formats))
+	flag.Parse()
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		cli.Fail("Error:", err)
+	}
+
+	inventory, err := versions.Load(*dir)
+	if err != nil {
+		cli.Fail("Error reading versions:", err)
+	}
+
+	var options versions.Options
+	if *indexPath != "" {
+		if options.Index, err = versions.LoadIndex(*indexPath); err != nil {
+			cli.Fail("Error reading index:", err)
+		}
+	}
+	if *varFile != "" {
+		vf, err := tfvars.ParseVarFile(*varFile)
+		if err != nil {
+			cli.Fail("Error reading var file:", err)
+		}
+		if v, ok := vf.Values["kubernetes_version"]; ok && v.Type().Equals(cty.String) && v.IsKnown() && !v.IsNull() {
+			options.KubernetesVersion = &versions.Pin{Source: *varFile, Version: v.AsString()}
+		}
+	}
+
+	result := versions.Check(inventory, options)
+	if err := report.Write(os.Stdout, outputFormat, result, result.Tables()...); err != nil {
+		cli.Fail("Error writing report:", err)
+	}
+	if !result.Passed() {
+		os.Exit(1)
+	}
+}
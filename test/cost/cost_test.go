@@ -0,0 +1,128 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	"math"
+	"testing"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadEstimate(t *testing.T, path string) *Estimate {
+	plan, err := planfile.Load(path)
+	require.NoError(t, err)
+	return EstimatePlan(plan, DefaultPrices())
+}
+
+func round(c Cost) Cost {
+	r := func(v float64) float64 { return math.Round(v*100) / 100 }
+	return Cost{r(c.Min), r(c.Desired), r(c.Max)}
+}
+
+func TestEstimatePlan(t *testing.T) {
+	t.Parallel()
+
+	e := loadEstimate(t, "testdata/plan-standard.json")
+	assert.Empty(t, e.Unpriced)
+
+	items := make(map[string]Item)
+	for _, item := range e.Items {
+		items[item.key()] = item
+	}
+
+	tests := map[string]struct {
+		key      string
+		expected Cost
+	}{
+		"controlPlane":     {"module.eks.aws_eks_cluster.this[0] control plane", Cost{73, 73, 73}},
+		"nodeGroupCompute": {`module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0] compute`, Cost{509.04, 509.04, 2545.22}},
+		"nodeGroupDisks":   {`module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0] root volumes`, Cost{20, 20, 100}},
+		"gp3NodeDisks":     {`module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0] root volumes`, Cost{16, 16, 80}},
+		"nfsInstance":      {"module.nfs[0].aws_instance.vm compute", Cost{203.28, 203.28, 203.28}},
+		"raidDisk":         {"module.nfs[0].aws_ebs_volume.raid_disk[3] storage", Cost{12.8, 12.8, 12.8}},
+		"natGateway":       {"module.vpc.aws_nat_gateway.nat_gateway[0] gateway", Cost{32.85, 32.85, 32.85}},
+		"eip":              {"module.vpc.aws_eip.nat[0] public IPv4", Cost{3.65, 3.65, 3.65}},
+		"rdsStorage":       {`module.postgresql["default"].module.db_instance.aws_db_instance.this[0] storage`, Cost{14.72, 14.72, 14.72}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			item, ok := items[tc.key]
+			require.True(t, ok, "no item %s", tc.key)
+			assert.Equal(t, tc.expected, round(item.Monthly))
+		})
+	}
+
+	assert.Equal(t, Cost{1881.13, 1881.13, 6339.25}, round(e.Total))
+}
+
+func TestEstimatePlanUnpriced(t *testing.T) {
+	t.Parallel()
+
+	prices := *DefaultPrices()
+	prices.EC2Hourly = map[string]float64{}
+	plan, err := planfile.Load("testdata/plan-standard.json")
+	require.NoError(t, err)
+
+	e := EstimatePlan(plan, &prices)
+	assert.Contains(t, e.Unpriced, `module.nfs[0].aws_instance.vm: no price for instance type "m6in.xlarge"`)
+	assert.Len(t, e.Unpriced, 3)
+}
+
+func TestEstimateRootNodeGroup(t *testing.T) {
+	t.Parallel()
+
+	plan := &terraform.PlanStruct{ResourcePlannedValuesMap: map[string]*tfjson.StateResource{
+		"aws_eks_node_group.x": {
+			Address: "aws_eks_node_group.x",
+			Mode:    tfjson.ManagedResourceMode,
+			Type:    "aws_eks_node_group",
+			AttributeValues: map[string]interface{}{
+				"instance_types": []interface{}{"m6in.xlarge"},
+				"scaling_config": []interface{}{map[string]interface{}{"min_size": 1.0, "desired_size": 1.0, "max_size": 2.0}},
+			},
+		},
+	}}
+	e := EstimatePlan(plan, DefaultPrices())
+	require.Len(t, e.Items, 1)
+	assert.Equal(t, "compute", e.Items[0].Component)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	before := loadEstimate(t, "testdata/plan-standard.json")
+	after := loadEstimate(t, "testdata/plan-ha.json")
+	d := Compare(before, after)
+
+	statuses := make(map[string]string)
+	for _, c := range d.Changes {
+		statuses[c.Address+" "+c.Component] = c.Status
+	}
+	assert.Equal(t, map[string]string{
+		"aws_efs_file_system.efs-fs[0] throughput":                                           "added",
+		`module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0] compute`: "changed",
+		"module.nfs[0].aws_ebs_volume.raid_disk[0] storage":                                  "removed",
+		"module.nfs[0].aws_ebs_volume.raid_disk[1] storage":                                  "removed",
+		"module.nfs[0].aws_ebs_volume.raid_disk[2] storage":                                  "removed",
+		"module.nfs[0].aws_ebs_volume.raid_disk[3] storage":                                  "removed",
+		"module.nfs[0].aws_instance.vm compute":                                              "removed",
+		"module.nfs[0].aws_instance.vm root volume":                                          "removed",
+		`module.postgresql["default"].module.db_instance.aws_db_instance.this[0] compute`:    "changed",
+		`module.postgresql["default"].module.db_instance.aws_db_instance.this[0] storage`:    "changed",
+	}, statuses)
+
+	var sum Cost
+	for _, c := range d.Changes {
+		sum = sum.Add(c.Delta)
+	}
+	assert.Equal(t, round(d.Delta), round(sum))
+	assert.Equal(t, round(after.Total.Sub(before.Total)), round(d.Delta))
+	assert.Empty(t, Compare(after, after).Changes)
+}
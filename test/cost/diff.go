@@ -0,0 +1,64 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import "sort"
+
+// Change is a cost item that differs between two estimates
+type Change struct {
+	Address   string `json:"address"`
+	Component string `json:"component"`
+	// Status is added, removed or changed
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	Before      Cost   `json:"before"`
+	After       Cost   `json:"after"`
+	Delta       Cost   `json:"delta"`
+}
+
+// Diff is the cost difference between two estimates
+type Diff struct {
+	Currency string   `json:"currency"`
+	Changes  []Change `json:"changes"`
+	Before   Cost     `json:"before"`
+	After    Cost     `json:"after"`
+	Delta    Cost     `json:"delta"`
+}
+
+// Compare lists the items that were added, removed or changed in cost from before to after
+func Compare(before, after *Estimate) *Diff {
+	d := &Diff{
+		Currency: after.Currency,
+		Changes:  []Change{},
+		Before:   before.Total,
+		After:    after.Total,
+		Delta:    after.Total.Sub(before.Total),
+	}
+
+	old := make(map[string]Item, len(before.Items))
+	for _, item := range before.Items {
+		old[item.key()] = item
+	}
+	for _, item := range after.Items {
+		prev, ok := old[item.key()]
+		delete(old, item.key())
+		switch {
+		case !ok:
+			d.Changes = append(d.Changes, Change{item.Address, item.Component, "added", item.Description, Cost{}, item.Monthly, item.Monthly})
+		case prev.Monthly != item.Monthly || prev.Description != item.Description:
+			d.Changes = append(d.Changes, Change{item.Address, item.Component, "changed", item.Description, prev.Monthly, item.Monthly, item.Monthly.Sub(prev.Monthly)})
+		}
+	}
+	for _, item := range old {
+		d.Changes = append(d.Changes, Change{item.Address, item.Component, "removed", item.Description, item.Monthly, Cost{}, Cost{}.Sub(item.Monthly)})
+	}
+
+	sort.Slice(d.Changes, func(i, j int) bool {
+		if d.Changes[i].Address != d.Changes[j].Address {
+			return d.Changes[i].Address < d.Changes[j].Address
+		}
+		return d.Changes[i].Component < d.Changes[j].Component
+	})
+	return d
+}
@@ -0,0 +1,243 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cost estimates the monthly on-demand cost of a terraform plan from an
+// embedded price table, without calling AWS.
+//
+// Node groups are priced at their minimum, desired and maximum sizes; every
+// other resource costs the same in all three columns. Usage based charges, such
+// as data transfer, NAT gateway processing and EFS storage, are not included.
+package cost
+
+import (
+	"fmt"
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// Cost is a monthly cost at the minimum, desired and maximum node counts
+type Cost struct {
+	Min     float64 `json:"min"`
+	Desired float64 `json:"desired"`
+	Max     float64 `json:"max"`
+}
+
+func fixed(monthly float64) Cost {
+	return Cost{monthly, monthly, monthly}
+}
+
+// Add returns the sum of two costs
+func (c Cost) Add(o Cost) Cost {
+	return Cost{c.Min + o.Min, c.Desired + o.Desired, c.Max + o.Max}
+}
+
+// Sub returns the difference of two costs
+func (c Cost) Sub(o Cost) Cost {
+	return Cost{c.Min - o.Min, c.Desired - o.Desired, c.Max - o.Max}
+}
+
+// Item is the cost of one component of a planned resource
+type Item struct {
+	Address     string `json:"address"`
+	Component   string `json:"component"`
+	Description string `json:"description"`
+	Monthly     Cost   `json:"monthly"`
+	Note        string `json:"note,omitempty"`
+}
+
+func (i Item) key() string {
+	return i.Address + " " + i.Component
+}
+
+// Estimate is the itemized monthly cost of a plan
+type Estimate struct {
+	PriceVersion string `json:"priceVersion"`
+	Region       string `json:"region"`
+	Currency     string `json:"currency"`
+	Items        []Item `json:"items"`
+	Total        Cost   `json:"total"`
+	// Unpriced lists resources the price table has no price for
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+type estimator struct {
+	prices    *Prices
+	resources map[string]planfile.Resource
+	estimate  *Estimate
+}
+
+// EstimatePlan prices the resources planned by a terraform plan
+func EstimatePlan(plan *terraform.PlanStruct, prices *Prices) *Estimate {
+	e := &estimator{
+		prices:    prices,
+		resources: make(map[string]planfile.Resource),
+		estimate: &Estimate{
+			PriceVersion: prices.Version,
+			Region:       prices.Region,
+			Currency:     prices.Currency,
+			Items:        []Item{},
+		},
+	}
+	resources := planfile.Resources(plan)
+	for _, r := range resources {
+		e.resources[r.Address] = r
+	}
+
+	for _, r := range resources {
+		switch r.Type {
+		case "aws_eks_cluster":
+			e.add(r.Address, "control plane", "EKS cluster", fixed(prices.EKSClusterHourly*prices.HoursPerMonth), "")
+		case "aws_eks_node_group":
+			e.nodeGroup(r)
+		case "aws_instance":
+			e.instance(r)
+		case "aws_ebs_volume":
+			e.volume(r.Address, "storage", r.Values.String("type"), r.Values.Number("size"), r.Values.Number("iops"), r.Values.Number("throughput"))
+		case "aws_nat_gateway":
+			e.add(r.Address, "gateway", "NAT gateway", fixed(prices.NATGatewayHourly*prices.HoursPerMonth), "data processing is billed per GB")
+		case "aws_eip":
+			e.add(r.Address, "public IPv4", "Elastic IP", fixed(prices.PublicIPv4Hourly*prices.HoursPerMonth), "")
+		case "aws_db_instance":
+			e.dbInstance(r)
+		case "aws_efs_file_system":
+			e.efs(r)
+		case "aws_fsx_ontap_file_system":
+			e.fsxONTAP(r)
+		}
+	}
+	return e.estimate
+}
+
+func (e *estimator) add(address, component, description string, monthly Cost, note string) {
+	e.estimate.Items = append(e.estimate.Items, Item{address, component, description, monthly, note})
+	e.estimate.Total = e.estimate.Total.Add(monthly)
+}
+
+func (e *estimator) unpriced(address, format string, args ...interface{}) {
+	e.estimate.Unpriced = append(e.estimate.Unpriced, address+": "+fmt.Sprintf(format, args...))
+}
+
+// nodeGroup prices the nodes of a managed node group and their root volumes
+// from the launch template of the same node group module
+func (e *estimator) nodeGroup(r planfile.Resource) {
+	var scaling planfile.Attributes
+	if blocks := r.Values.Blocks("scaling_config"); len(blocks) > 0 {
+		scaling = blocks[0]
+	}
+	minSize, desired, maxSize := scaling.Number("min_size"), scaling.Number("desired_size"), scaling.Number("max_size")
+	counts := fmt.Sprintf("%g/%g/%g", minSize, desired, maxSize)
+
+	types := r.Values.Strings("instance_types")
+	if len(types) == 0 {
+		e.unpriced(r.Address, "instance type is unknown until apply")
+		return
+	}
+	// With several instance types EKS may pick any of them, the first is priced
+	vmType := types[0]
+	if hourly, ok := e.prices.EC2Hourly[vmType]; ok {
+		monthly := hourly * e.prices.HoursPerMonth
+		e.add(r.Address, "compute", fmt.Sprintf("%s %s nodes (min/desired/max)", counts, vmType),
+			Cost{minSize * monthly, desired * monthly, maxSize * monthly}, "")
+	} else {
+		e.unpriced(r.Address, "no price for instance type %q", vmType)
+	}
+
+	// The eks module keeps the launch template next to the node group, a node
+	// group outside a module has no launch template to price
+	i := strings.LastIndex(r.Address, ".aws_eks_node_group.")
+	if i < 0 {
+		return
+	}
+	lt, ok := e.resources[r.Address[:i]+".aws_launch_template.this[0]"]
+	if !ok {
+		return
+	}
+	for _, bdm := range lt.Values.Blocks("block_device_mappings") {
+		for _, ebs := range bdm.Blocks("ebs") {
+			volumeType := ebs.String("volume_type")
+			price, ok := e.prices.EBS[volumeType]
+			if !ok {
+				e.unpriced(lt.Address, "no price for volume type %q", volumeType)
+				continue
+			}
+			monthly := price.Monthly(ebs.Number("volume_size"), ebs.Number("iops"), ebs.Number("throughput"))
+			e.add(r.Address, "root volumes", fmt.Sprintf("%s %g GiB %s volumes (min/desired/max)", counts, ebs.Number("volume_size"), volumeType),
+				Cost{minSize * monthly, desired * monthly, maxSize * monthly}, "")
+		}
+	}
+}
+
+func (e *estimator) instance(r planfile.Resource) {
+	vmType := r.Values.String("instance_type")
+	if hourly, ok := e.prices.EC2Hourly[vmType]; ok {
+		e.add(r.Address, "compute", vmType+" instance", fixed(hourly*e.prices.HoursPerMonth), "")
+	} else {
+		e.unpriced(r.Address, "no price for instance type %q", vmType)
+	}
+	for _, root := range r.Values.Blocks("root_block_device") {
+		e.volume(r.Address, "root volume", root.String("volume_type"), root.Number("volume_size"), root.Number("iops"), root.Number("throughput"))
+	}
+}
+
+func (e *estimator) volume(address, component, volumeType string, size, iops, throughput float64) {
+	price, ok := e.prices.EBS[volumeType]
+	if !ok {
+		e.unpriced(address, "no price for volume type %q", volumeType)
+		return
+	}
+	e.add(address, component, fmt.Sprintf("%g GiB %s volume", size, volumeType), fixed(price.Monthly(size, iops, throughput)), "")
+}
+
+func (e *estimator) dbInstance(r planfile.Resource) {
+	// Multi-AZ deployments run and store everything on a standby as well
+	copies, deployment := 1.0, "Single-AZ"
+	if r.Values.Bool("multi_az") {
+		copies, deployment = 2, "Multi-AZ"
+	}
+
+	class := r.Values.String("instance_class")
+	if hourly, ok := e.prices.RDSHourly[class]; ok {
+		e.add(r.Address, "compute", fmt.Sprintf("%s %s", class, deployment), fixed(copies*hourly*e.prices.HoursPerMonth), "")
+	} else {
+		e.unpriced(r.Address, "no price for DB instance class %q", class)
+	}
+
+	storageType := r.Values.String("storage_type")
+	if storageType == "" {
+		storageType = "gp2"
+	}
+	price, ok := e.prices.RDSStorage[storageType]
+	if !ok {
+		e.unpriced(r.Address, "no price for DB storage type %q", storageType)
+		return
+	}
+	size := r.Values.Number("allocated_storage")
+	e.add(r.Address, "storage", fmt.Sprintf("%g GiB %s %s", size, storageType, deployment),
+		fixed(copies*price.Monthly(size, r.Values.Number("iops"), r.Values.Number("storage_throughput"))), "")
+}
+
+func (e *estimator) efs(r planfile.Resource) {
+	const note = "storage is billed per GB stored"
+	if r.Values.String("throughput_mode") != "provisioned" {
+		e.add(r.Address, "throughput", "EFS "+r.Values.String("throughput_mode")+" throughput", Cost{}, note)
+		return
+	}
+	rate := r.Values.Number("provisioned_throughput_in_mibps")
+	e.add(r.Address, "throughput", fmt.Sprintf("EFS %g MiB/s provisioned throughput", rate), fixed(rate*e.prices.EFS.ProvisionedMiBpsMonth), note)
+}
+
+func (e *estimator) fsxONTAP(r planfile.Resource) {
+	deployment := strings.ToUpper(r.Values.String("deployment_type"))
+	price, ok := e.prices.FSxONTAP[deployment]
+	if !ok {
+		e.unpriced(r.Address, "no price for FSx ONTAP deployment type %q", deployment)
+		return
+	}
+	capacity := r.Values.Number("storage_capacity")
+	throughput := r.Values.Number("throughput_capacity")
+	e.add(r.Address, "storage", fmt.Sprintf("%g GiB SSD %s", capacity, deployment), fixed(capacity*price.SSDGBMonth), "")
+	e.add(r.Address, "throughput", fmt.Sprintf("%g MBps %s", throughput, deployment), fixed(throughput*price.ThroughputMBpsMonth), "")
+}
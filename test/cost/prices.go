@@ -0,0 +1,86 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+//go:embed prices.json
+var embeddedPrices []byte
+
+// VolumePrice is the monthly price of a block storage volume type. IOPS and
+// throughput up to the included amounts are free.
+type VolumePrice struct {
+	GBMonth            float64 `json:"gbMonth"`
+	IOPSMonth          float64 `json:"iopsMonth,omitempty"`
+	IncludedIOPS       float64 `json:"includedIOPS,omitempty"`
+	ThroughputMonth    float64 `json:"throughputMonth,omitempty"`
+	IncludedThroughput float64 `json:"includedThroughput,omitempty"`
+}
+
+// Monthly returns the monthly price of a volume
+func (p VolumePrice) Monthly(sizeGB, iops, throughput float64) float64 {
+	return sizeGB*p.GBMonth +
+		max(iops-p.IncludedIOPS, 0)*p.IOPSMonth +
+		max(throughput-p.IncludedThroughput, 0)*p.ThroughputMonth
+}
+
+// FSxONTAPPrice is the monthly price of an FSx for NetApp ONTAP deployment type
+type FSxONTAPPrice struct {
+	SSDGBMonth          float64 `json:"ssdGBMonth"`
+	ThroughputMBpsMonth float64 `json:"throughputMBpsMonth"`
+}
+
+// Prices is a versioned on-demand price table for a single region
+type Prices struct {
+	Version          string  `json:"version"`
+	Region           string  `json:"region"`
+	Currency         string  `json:"currency"`
+	Source           string  `json:"source"`
+	HoursPerMonth    float64 `json:"hoursPerMonth"`
+	EKSClusterHourly float64 `json:"eksClusterHourly"`
+	NATGatewayHourly float64 `json:"natGatewayHourly"`
+	PublicIPv4Hourly float64 `json:"publicIPv4Hourly"`
+
+	EC2Hourly  map[string]float64     `json:"ec2Hourly"`
+	EBS        map[string]VolumePrice `json:"ebs"`
+	RDSHourly  map[string]float64     `json:"rdsHourly"`
+	RDSStorage map[string]VolumePrice `json:"rdsStorage"`
+	EFS        struct {
+		StandardGBMonth       float64 `json:"standardGBMonth"`
+		ProvisionedMiBpsMonth float64 `json:"provisionedMiBpsMonth"`
+	} `json:"efs"`
+	FSxONTAP map[string]FSxONTAPPrice `json:"fsxOntap"`
+}
+
+// LoadPrices reads a price table in the prices.json format
+func LoadPrices(r io.Reader) (*Prices, error) {
+	var p Prices
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("decoding price table: %w", err)
+	}
+	return &p, nil
+}
+
+var (
+	defaultPrices *Prices
+	defaultOnce   sync.Once
+)
+
+// DefaultPrices returns the price table embedded in this package
+func DefaultPrices() *Prices {
+	defaultOnce.Do(func() {
+		var p Prices
+		if err := json.Unmarshal(embeddedPrices, &p); err != nil {
+			panic(fmt.Sprintf("embedded price table is invalid: %v", err))
+		}
+		defaultPrices = &p
+	})
+	return defaultPrices
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	"fmt"
+
+	"test/report"
+)
+
+func money(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func signedMoney(v float64) string {
+	if v > -0.005 && v < 0.005 {
+		return "0.00"
+	}
+	return fmt.Sprintf("%+.2f", v)
+}
+
+// Table renders the estimate with one row per item and the totals as footer
+func (e *Estimate) Table() *report.Table {
+	t := &report.Table{
+		Title: fmt.Sprintf("Monthly cost estimate (%s, %s prices of %s)", e.Currency, e.Region, e.PriceVersion),
+		Columns: []report.Column{
+			{Header: "Resource"},
+			{Header: "Component"},
+			{Header: "Description"},
+			{Header: "Min", Right: true},
+			{Header: "Desired", Right: true},
+			{Header: "Max", Right: true},
+		},
+		Footer: []string{"Total", "", "", money(e.Total.Min), money(e.Total.Desired), money(e.Total.Max)},
+	}
+	for _, item := range e.Items {
+		description := item.Description
+		if item.Note != "" {
+			description += " (" + item.Note + ")"
+		}
+		t.AddRow(item.Address, item.Component, description, money(item.Monthly.Min), money(item.Monthly.Desired), money(item.Monthly.Max))
+	}
+	return t
+}
+
+// UnpricedTable lists the resources that are missing from the estimate, or returns nil if there are none
+func (e *Estimate) UnpricedTable() *report.Table {
+	if len(e.Unpriced) == 0 {
+		return nil
+	}
+	t := &report.Table{
+		Title:   "Not included in the estimate",
+		Columns: []report.Column{{Header: "Resource"}},
+	}
+	for _, u := range e.Unpriced {
+		t.AddRow(u)
+	}
+	return t
+}
+
+// Table renders the changed items at the desired node counts, and the change
+// of the totals at every node count as footer
+func (d *Diff) Table() *report.Table {
+	t := &report.Table{
+		Title: fmt.Sprintf("Monthly cost difference (%s)", d.Currency),
+		Columns: []report.Column{
+			{Header: "Resource"},
+			{Header: "Component"},
+			{Header: "Change"},
+			{Header: "Description"},
+			{Header: "Before", Right: true},
+			{Header: "After", Right: true},
+			{Header: "Delta", Right: true},
+		},
+		Footer: []string{"Total (desired)", "", "", "", money(d.Before.Desired), money(d.After.Desired), signedMoney(d.Delta.Desired)},
+	}
+	for _, c := range d.Changes {
+		t.AddRow(c.Address, c.Component, c.Status, c.Description, money(c.Before.Desired), money(c.After.Desired), signedMoney(c.Delta.Desired))
+	}
+	return t
+}
+
+// TotalsTable renders the totals before and after at the minimum, desired and maximum node counts
+func (d *Diff) TotalsTable() *report.Table {
+	t := &report.Table{
+		Title: "Total monthly cost",
+		Columns: []report.Column{
+			{Header: "Node count"},
+			{Header: "Before", Right: true},
+			{Header: "After", Right: true},
+			{Header: "Delta", Right: true},
+		},
+	}
+	t.AddRow("min", money(d.Before.Min), money(d.After.Min), signedMoney(d.Delta.Min))
+	t.AddRow("desired", money(d.Before.Desired), money(d.After.Desired), signedMoney(d.Delta.Desired))
+	t.AddRow("max", money(d.Before.Max), money(d.After.Max), signedMoney(d.Delta.Max))
+	return t
+}
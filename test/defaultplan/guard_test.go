@@ -0,0 +1,20 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The default plan starts from empty state, so it deletes or replaces nothing
+func TestPlanNoDataLoss(t *testing.T) {
+	t.Parallel()
+
+	result := helpers.AssertNoDataLoss(t, helpers.GetDefaultPlan(t))
+	assert.Empty(t, result.Changes)
+}
@@ -0,0 +1,33 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+	"test/iam"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanIAMActions records how files/policies/devops-iac-eks-policy.json
+// drifts from the actions the default plan needs
+func TestPlanIAMActions(t *testing.T) {
+	t.Parallel()
+
+	req := iam.Generate(helpers.GetDefaultPlan(t), iam.DefaultTable())
+	assert.Empty(t, req.Unmapped, "resource types without actions in iam/actions.json")
+
+	shipped, err := iam.LoadPolicy("../../files/policies/devops-iac-eks-policy.json")
+	require.NoError(t, err)
+	diff := iam.Compare(req, shipped)
+	for _, r := range diff.Missing {
+		t.Logf("missing: %s (%v)", r.Action, r.Types)
+	}
+	for _, a := range diff.Unneeded {
+		t.Log("unneeded:", a)
+	}
+}
@@ -4,8 +4,12 @@
 package defaultplan
 
 import (
+	"test/ec2catalog"
 	"test/helpers"
+	"test/sizing"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestPlanNodePool(t *testing.T) {
@@ -61,3 +65,34 @@ func TestPlanNodePool(t *testing.T) {
 
 	helpers.RunTests(t, tests, helpers.GetDefaultPlan(t))
 }
+
+func TestPlanNodePoolCompatibility(t *testing.T) {
+	t.Parallel()
+
+	for _, finding := range ec2catalog.Default().CheckPlan(helpers.GetDefaultPlan(t)) {
+		if !finding.IsWarning() {
+			t.Error(finding)
+		}
+	}
+}
+
+func TestPlanNodePoolSizing(t *testing.T) {
+	t.Parallel()
+
+	plan := helpers.GetDefaultPlan(t)
+	profile, err := sizing.DefaultProfiles().Get("")
+	require.NoError(t, err)
+
+	result := sizing.Check(sizing.PoolsFromPlan(plan), ec2catalog.Default(), profile, sizing.Options{Autoscaling: sizing.AutoscalingPlanned(plan)})
+	for _, finding := range result.Findings {
+		if !finding.IsWarning() {
+			t.Error(finding)
+		}
+	}
+}
+
+func TestPlanNodeTaints(t *testing.T) {
+	t.Parallel()
+
+	helpers.AssertNodeTaints(t, helpers.GetDefaultPlan(t))
+}
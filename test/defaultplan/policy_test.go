@@ -0,0 +1,25 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+	"test/policy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPolicies(t *testing.T) {
+	t.Parallel()
+
+	plan := helpers.GetDefaultPlan(t)
+	result := helpers.AssertPolicies(t, plan, policy.Waiver{
+		Rule:    "IAC-IMDS-001",
+		Address: "module.*.aws_instance.vm",
+		Reason:  "modules/aws_vm does not set metadata_options",
+	})
+	assert.Empty(t, result.UnusedWaivers)
+}
@@ -0,0 +1,36 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+	"test/reachability"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanReachability(t *testing.T) {
+	t.Parallel()
+
+	variables := helpers.GetDefaultPlanVars(t)
+	defaultCidr := variables["default_public_access_cidrs"].([]string)[0]
+	g := reachability.Build(helpers.GetDefaultPlan(t))
+	for _, address := range g.Unresolved {
+		t.Log("unresolved:", address)
+	}
+
+	jump := "module.jump[0].aws_instance.vm"
+	nfs := "module.nfs[0].aws_instance.vm"
+	helpers.AssertReachable(t, g, jump, nfs, 2049)
+	helpers.AssertReachable(t, g, jump, nfs, 22)
+	helpers.AssertNotReachable(t, g, "module.eks.aws_eks_cluster.this[0]", jump, 22)
+
+	cidrs, err := g.CIDRs(jump, "tcp", 22)
+	require.NoError(t, err)
+	assert.Contains(t, cidrs, defaultCidr)
+	helpers.AssertCIDRs(t, g, nfs, 2049)
+}
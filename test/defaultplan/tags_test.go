@@ -0,0 +1,23 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+	"test/tags"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanTags(t *testing.T) {
+	t.Parallel()
+
+	result := tags.Check(helpers.GetDefaultPlan(t))
+	for _, f := range result.Findings {
+		t.Error(f)
+	}
+	assert.NotZero(t, result.Checked)
+}
@@ -0,0 +1,16 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package defaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+)
+
+func TestPlanVersionGates(t *testing.T) {
+	t.Parallel()
+
+	helpers.AssertVersionGates(t, helpers.GetDefaultPlan(t))
+}
@@ -0,0 +1,464 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drift compares a state with the values a set of tfvars files asks
+// for, without refreshing, for the attributes people change outside terraform:
+// node group sizes, instance types and disks, VM sizes and disks, PostgreSQL
+// instance classes, the storage backend and the network CIDRs.
+//
+// Every difference is either expected, like a desired_size the cluster
+// autoscaler changed, or drift the next apply reverts. Drift comes with the
+// tfvars change that makes the next apply keep what the state has instead.
+package drift
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"test/planfile"
+	"test/statefile"
+	"test/tfvars"
+)
+
+// Class of a Difference
+type Class string
+
+const (
+	// Expected differences are made by design outside terraform
+	Expected Class = "expected"
+	// Drift is reverted or replaced by the next apply
+	Drift Class = "drift"
+)
+
+// Difference is an attribute whose state value is not the intended one
+type Difference struct {
+	Class Class `json:"class"`
+	// Resource names the resource, like "node group cas"
+	Resource  string `json:"resource"`
+	Address   string `json:"address"`
+	Attribute string `json:"attribute"`
+	State     string `json:"state"`
+	Intended  string `json:"intended"`
+	// Suggestion is the tfvars assignment that reconciles the difference,
+	// or a hint when no variable controls the attribute
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Result are the differences, sorted by address
+type Result struct {
+	Differences []Difference `json:"differences"`
+}
+
+// Passed reports whether there is no drift
+func (r *Result) Passed() bool {
+	for _, d := range r.Differences {
+		if d.Class == Drift {
+			return false
+		}
+	}
+	return true
+}
+
+// Detect compares a state with the inputs
+func Detect(state *statefile.State, in tfvars.Inputs) (*Result, error) {
+	d := &detector{in: in, result: &Result{Differences: []Difference{}}}
+	for _, check := range []func(*statefile.State) error{d.nodeGroups, d.vms, d.postgres, d.storage, d.network} {
+		if err := check(state); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(d.result.Differences, func(i, j int) bool {
+		return d.result.Differences[i].Address < d.result.Differences[j].Address
+	})
+	return d.result, nil
+}
+
+type detector struct {
+	in     tfvars.Inputs
+	result *Result
+}
+
+// compare records a difference of an attribute, as drift reconciled by
+// setting variable to the state value
+func (d *detector) compare(resource, address, attribute, state, intended, variable string) {
+	if state == intended {
+		return
+	}
+	suggestion := ""
+	if variable != "" {
+		suggestion = variable + " = " + state
+	}
+	d.result.Differences = append(d.result.Differences, Difference{
+		Class:      Drift,
+		Resource:   resource,
+		Address:    address,
+		Attribute:  attribute,
+		State:      state,
+		Intended:   intended,
+		Suggestion: suggestion,
+	})
+}
+
+func (d *detector) add(diff Difference) {
+	d.result.Differences = append(d.result.Differences, diff)
+}
+
+var nodeGroupKey = regexp.MustCompile(`eks_managed_node_group\["([^"]+)"\]`)
+
+// nodeGroups compares the node groups and their launch templates with the
+// node pools
+func (d *detector) nodeGroups(state *statefile.State) error {
+	pools, err := d.in.NodePools()
+	if err != nil {
+		return err
+	}
+	var userPools map[string]interface{}
+	if err := d.in.Decode("node_pools", &userPools); err != nil {
+		return err
+	}
+
+	byName := map[string]tfvars.NodePool{}
+	for _, np := range pools {
+		byName[np.Name] = np
+	}
+	seen := map[string]bool{}
+
+	for _, r := range state.Resources {
+		m := nodeGroupKey.FindStringSubmatch(r.Module)
+		if m == nil || (r.Type != "aws_eks_node_group" && r.Type != "aws_launch_template") {
+			continue
+		}
+		name := m[1]
+		resource := "node group " + name
+		np, ok := byName[name]
+		if !ok {
+			if r.Type == "aws_eks_node_group" {
+				d.add(Difference{
+					Class:      Drift,
+					Resource:   resource,
+					Address:    r.Address,
+					State:      "exists",
+					Intended:   "absent",
+					Suggestion: fmt.Sprintf("add node_pools[%q] to keep it, the next apply destroys it", name),
+				})
+			}
+			continue
+		}
+		seen[name] = true
+		_, fromNodePools := userPools[name]
+		variable := func(nodePoolsKey, defaultVariable string) string {
+			if fromNodePools {
+				return fmt.Sprintf("node_pools[%q].%s", name, nodePoolsKey)
+			}
+			return defaultVariable
+		}
+
+		v := r.Values
+		if r.Type == "aws_launch_template" {
+			for _, bdm := range v.Blocks("block_device_mappings") {
+				for _, ebs := range bdm.Blocks("ebs") {
+					d.compare(resource, r.Address, "volume_type", quote(ebs.String("volume_type")), quote(np.OSDiskType), variable("os_disk_type", "default_nodepool_os_disk_type"))
+					d.compare(resource, r.Address, "volume_size", number(ebs.Number("volume_size")), strconv.Itoa(np.OSDiskSize), variable("os_disk_size", "default_nodepool_os_disk_size"))
+				}
+			}
+			continue
+		}
+
+		// vm_type is a single instance type, only that is a reconciling value
+		if types := v.Strings("instance_types"); len(types) == 1 {
+			d.compare(resource, r.Address, "instance_types", quote(types[0]), quote(np.VMType), variable("vm_type", "default_nodepool_vm_type"))
+		} else {
+			d.compare(resource, r.Address, "instance_types", list(types), list([]string{np.VMType}), "")
+		}
+		scaling := v.Blocks("scaling_config")
+		if len(scaling) == 0 {
+			continue
+		}
+		minSize, maxSize, desired := int(scaling[0].Number("min_size")), int(scaling[0].Number("max_size")), int(scaling[0].Number("desired_size"))
+		d.compare(resource, r.Address, "scaling_config.min_size", strconv.Itoa(minSize), strconv.Itoa(np.MinNodes), variable("min_nodes", "default_nodepool_min_nodes"))
+		d.compare(resource, r.Address, "scaling_config.max_size", strconv.Itoa(maxSize), strconv.Itoa(np.MaxNodes), variable("max_nodes", "default_nodepool_max_nodes"))
+
+		intended := d.desiredSize(np, fromNodePools)
+		if desired == intended {
+			continue
+		}
+		diff := Difference{
+			Class:     Drift,
+			Resource:  resource,
+			Address:   r.Address,
+			Attribute: "scaling_config.desired_size",
+			State:     strconv.Itoa(desired),
+			Intended:  strconv.Itoa(intended),
+		}
+		switch {
+		case d.in.Bool("autoscaling_enabled") && desired >= np.MinNodes && desired <= np.MaxNodes:
+			diff.Class = Expected
+			diff.Suggestion = "the cluster autoscaler manages the node count within min_nodes and max_nodes"
+		case fromNodePools:
+			diff.Suggestion = fmt.Sprintf("node_pools[%q] has no node count, it follows min_nodes", name)
+		default:
+			diff.Suggestion = "default_nodepool_node_count = " + diff.State
+		}
+		d.add(diff)
+	}
+
+	for _, np := range pools {
+		if !seen[np.Name] {
+			d.add(Difference{
+				Class:      Drift,
+				Resource:   "node group " + np.Name,
+				State:      "absent",
+				Intended:   "exists",
+				Suggestion: "the next apply creates it",
+			})
+		}
+	}
+	return nil
+}
+
+// desiredSize is the desired_size locals.tf gives a node pool
+func (d *detector) desiredSize(np tfvars.NodePool, fromNodePools bool) int {
+	if !fromNodePools {
+		return int(d.in.Number("default_nodepool_node_count"))
+	}
+	if d.in.Bool("autoscaling_enabled") && np.MinNodes == 0 {
+		return 1
+	}
+	return np.MinNodes
+}
+
+// vms compares the jump and NFS server instances and the NFS RAID disks
+func (d *detector) vms(state *statefile.State) error {
+	for _, r := range state.Resources {
+		var vm string
+		switch {
+		case strings.HasPrefix(r.Module, "module.jump"):
+			vm = "jump"
+		case strings.HasPrefix(r.Module, "module.nfs"):
+			vm = "nfs"
+		default:
+			continue
+		}
+		resource := vm + " VM"
+		v := r.Values
+		switch r.Type {
+		case "aws_instance":
+			d.compare(resource, r.Address, "instance_type", quote(v.String("instance_type")), quote(d.in.String(vm+"_vm_type")), vm+"_vm_type")
+			for _, disk := range v.Blocks("root_block_device") {
+				d.compare(resource, r.Address, "root_block_device.volume_type", quote(disk.String("volume_type")), quote(d.in.String("os_disk_type")), "os_disk_type")
+				d.compare(resource, r.Address, "root_block_device.volume_size", number(disk.Number("volume_size")), number(d.in.Number("os_disk_size")), "os_disk_size")
+			}
+		case "aws_ebs_volume":
+			resource = fmt.Sprintf("NFS RAID disk %v", r.Index)
+			d.compare(resource, r.Address, "type", quote(v.String("type")), quote(d.in.String("nfs_raid_disk_type")), "nfs_raid_disk_type")
+			d.compare(resource, r.Address, "size", number(v.Number("size")), number(d.in.Number("nfs_raid_disk_size")), "nfs_raid_disk_size")
+		}
+	}
+	return nil
+}
+
+var postgresKey = regexp.MustCompile(`^module\.postgresql\["([^"]+)"\]`)
+
+// postgres compares the RDS instances with postgres_servers merged over
+// postgres_server_defaults, like locals.tf does
+func (d *detector) postgres(state *statefile.State) error {
+	var defaults map[string]interface{}
+	if err := d.in.Decode("postgres_server_defaults", &defaults); err != nil {
+		return err
+	}
+	var servers map[string]map[string]interface{}
+	if err := d.in.Decode("postgres_servers", &servers); err != nil {
+		return err
+	}
+
+	for _, r := range state.Managed("aws_db_instance") {
+		m := postgresKey.FindStringSubmatch(r.Module)
+		if m == nil {
+			continue
+		}
+		name := m[1]
+		resource := "PostgreSQL server " + name
+		server, ok := servers[name]
+		if !ok {
+			d.add(Difference{
+				Class:      Drift,
+				Resource:   resource,
+				Address:    r.Address,
+				State:      "exists",
+				Intended:   "absent",
+				Suggestion: fmt.Sprintf("add postgres_servers[%q] to keep it, the next apply destroys it", name),
+			})
+			continue
+		}
+		merged := planfile.Attributes{}
+		for k, v := range defaults {
+			merged[k] = v
+		}
+		for k, v := range server {
+			merged[k] = v
+		}
+		variable := func(key string) string {
+			return fmt.Sprintf("postgres_servers[%q].%s", name, key)
+		}
+		d.compare(resource, r.Address, "instance_class", quote(r.Values.String("instance_class")), quote(merged.String("instance_type")), variable("instance_type"))
+		d.compare(resource, r.Address, "allocated_storage", number(r.Values.Number("allocated_storage")), anyNumber(merged["storage_size"]), variable("storage_size"))
+	}
+	return nil
+}
+
+// storage compares the RWX storage in the state with the backend locals.tf
+// derives from storage_type and storage_type_backend
+func (d *detector) storage(state *statefile.State) error {
+	backend, address := "none", ""
+	for _, r := range state.Resources {
+		switch {
+		case r.Type == "aws_efs_file_system":
+			backend, address = "efs", r.Address
+		case r.Type == "aws_fsx_ontap_file_system":
+			backend, address = "ontap", r.Address
+		case r.Type == "aws_instance" && strings.HasPrefix(r.Module, "module.nfs"):
+			backend, address = "nfs", r.Address
+		}
+	}
+
+	storageType := d.in.String("storage_type")
+	intended := "none"
+	switch {
+	case storageType == "standard":
+		intended = "nfs"
+	case storageType == "ha" && d.in.String("storage_type_backend") == "ontap":
+		intended = "ontap"
+	case storageType == "ha":
+		intended = "efs"
+	}
+	if backend == intended {
+		return nil
+	}
+	suggestions := map[string]string{
+		"nfs":   `storage_type = "standard"`,
+		"efs":   `storage_type = "ha", storage_type_backend = "efs"`,
+		"ontap": `storage_type = "ha", storage_type_backend = "ontap"`,
+		"none":  `storage_type = "none", storage_type_backend = "none"`,
+	}
+	d.add(Difference{
+		Class:      Drift,
+		Resource:   "storage backend",
+		Address:    address,
+		Attribute:  "storage_type_backend",
+		State:      quote(backend),
+		Intended:   quote(intended),
+		Suggestion: suggestions[backend] + ", the next apply replaces the storage and its data",
+	})
+	return nil
+}
+
+// network compares the VPC and subnet CIDRs the stack created, and the CIDRs
+// allowed to reach the public cluster endpoint
+func (d *detector) network(state *statefile.State) error {
+	if d.in.Get("vpc_id").IsNull() {
+		for _, r := range state.Managed("aws_vpc") {
+			d.compare("VPC", r.Address, "cidr_block", quote(r.Values.String("cidr_block")), quote(d.in.String("vpc_cidr")), "vpc_cidr")
+		}
+	}
+
+	var subnets, existing map[string][]string
+	if err := d.in.Decode("subnets", &subnets); err != nil {
+		return err
+	}
+	if err := d.in.Decode("subnet_ids", &existing); err != nil {
+		return err
+	}
+	stateSubnets := map[string][]string{}
+	addresses := map[string]string{}
+	for _, r := range state.Managed("aws_subnet") {
+		if r.Module != "module.vpc" {
+			continue
+		}
+		index, _ := r.Index.(float64)
+		cidrs := stateSubnets[r.Name]
+		for len(cidrs) <= int(index) {
+			cidrs = append(cidrs, "")
+		}
+		cidrs[int(index)] = r.Values.String("cidr_block")
+		stateSubnets[r.Name] = cidrs
+		addresses[r.Name] = fmt.Sprintf("module.vpc.aws_subnet.%s", r.Name)
+	}
+	for _, role := range sortedKeys(stateSubnets) {
+		if len(existing[role]) > 0 {
+			continue
+		}
+		d.compare("subnets "+role, addresses[role], "cidr_block", list(stateSubnets[role]), list(subnets[role]), fmt.Sprintf("subnets[%q]", role))
+	}
+
+	if d.in.String("cluster_api_mode") == "private" {
+		return nil
+	}
+	var intended []string
+	for _, name := range []string{"cluster_endpoint_public_access_cidrs", "default_public_access_cidrs"} {
+		if !d.in.Get(name).IsNull() {
+			if err := d.in.Decode(name, &intended); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	// EKS opens an endpoint without CIDRs to everyone
+	if len(intended) == 0 {
+		intended = []string{"0.0.0.0/0"}
+	}
+	for _, r := range state.Managed("aws_eks_cluster") {
+		for _, vpc := range r.Values.Blocks("vpc_config") {
+			actual := vpc.Strings("public_access_cidrs")
+			sort.Strings(actual)
+			sort.Strings(intended)
+			d.compare("cluster endpoint", r.Address, "vpc_config.public_access_cidrs", list(actual), list(intended), "cluster_endpoint_public_access_cidrs")
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quote formats a string as an HCL literal
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// number formats a number as an HCL literal
+func number(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// anyNumber formats a number or a numeric string, as postgres_servers is
+// typed any
+func anyNumber(v interface{}) string {
+	switch n := v.(type) {
+	case float64:
+		return number(n)
+	case string:
+		if f, err := strconv.ParseFloat(n, 64); err == nil {
+			return number(f)
+		}
+		return quote(n)
+	}
+	return "null"
+}
+
+// list formats strings as an HCL list
+func list(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = quote(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
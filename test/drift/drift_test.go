@@ -0,0 +1,207 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"testing"
+
+	"test/statefile"
+	"test/tfvars"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func inputs(t *testing.T) tfvars.Inputs {
+	in, err := tfvars.LoadInputs("../..", "testdata/intent.tfvars")
+	require.NoError(t, err)
+	return in
+}
+
+func detect(t *testing.T, in tfvars.Inputs) *Result {
+	state, err := statefile.Load("testdata/terraform.tfstate")
+	require.NoError(t, err)
+	result, err := Detect(state, in)
+	require.NoError(t, err)
+	return result
+}
+
+// find returns the difference of a resource attribute, nil when there is none
+func find(r *Result, resource, attribute string) *Difference {
+	for i, d := range r.Differences {
+		if d.Resource == resource && d.Attribute == attribute {
+			return &r.Differences[i]
+		}
+	}
+	return nil
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	result := detect(t, inputs(t))
+	assert.False(t, result.Passed())
+
+	tests := map[string]struct {
+		resource  string
+		attribute string
+		expected  Difference
+	}{
+		"node group instance type": {
+			resource:  "node group cas",
+			attribute: "instance_types",
+			expected: Difference{
+				Class:      Drift,
+				State:      `"r6idn.4xlarge"`,
+				Intended:   `"r6idn.2xlarge"`,
+				Suggestion: `node_pools["cas"].vm_type = "r6idn.4xlarge"`,
+			},
+		},
+		"node group max size": {
+			resource:  "node group cas",
+			attribute: "scaling_config.max_size",
+			expected:  Difference{Class: Drift, State: "8", Intended: "5", Suggestion: `node_pools["cas"].max_nodes = 8`},
+		},
+		"autoscaled desired size": {
+			resource:  "node group cas",
+			attribute: "scaling_config.desired_size",
+			expected: Difference{
+				Class:      Expected,
+				State:      "4",
+				Intended:   "1",
+				Suggestion: "the cluster autoscaler manages the node count within min_nodes and max_nodes",
+			},
+		},
+		"node group disk": {
+			resource:  "node group cas",
+			attribute: "volume_size",
+			expected:  Difference{Class: Drift, State: "300", Intended: "200", Suggestion: `node_pools["cas"].os_disk_size = 300`},
+		},
+		"removed node pool": {
+			resource: "node group connect",
+			expected: Difference{
+				Class:      Drift,
+				State:      "exists",
+				Intended:   "absent",
+				Suggestion: `add node_pools["connect"] to keep it, the next apply destroys it`,
+			},
+		},
+		"new node pool": {
+			resource: "node group stateless",
+			expected: Difference{Class: Drift, State: "absent", Intended: "exists", Suggestion: "the next apply creates it"},
+		},
+		"postgres instance class": {
+			resource:  "PostgreSQL server default",
+			attribute: "instance_class",
+			expected: Difference{
+				Class:      Drift,
+				State:      `"db.m6idn.2xlarge"`,
+				Intended:   `"db.m6idn.xlarge"`,
+				Suggestion: `postgres_servers["default"].instance_type = "db.m6idn.2xlarge"`,
+			},
+		},
+		"raid disk": {
+			resource:  "NFS RAID disk 2",
+			attribute: "size",
+			expected:  Difference{Class: Drift, State: "256", Intended: "128", Suggestion: "nfs_raid_disk_size = 256"},
+		},
+		"subnets": {
+			resource:  "subnets public",
+			attribute: "cidr_block",
+			expected: Difference{
+				Class:      Drift,
+				State:      `["192.168.129.0/25", "192.168.129.128/26"]`,
+				Intended:   `["192.168.129.0/25", "192.168.129.128/25"]`,
+				Suggestion: `subnets["public"] = ["192.168.129.0/25", "192.168.129.128/26"]`,
+			},
+		},
+		"endpoint cidrs": {
+			resource:  "cluster endpoint",
+			attribute: "vpc_config.public_access_cidrs",
+			expected: Difference{
+				Class:      Drift,
+				State:      `["10.10.10.10/32", "123.45.6.89/32"]`,
+				Intended:   `["123.45.6.89/32"]`,
+				Suggestion: `cluster_endpoint_public_access_cidrs = ["10.10.10.10/32", "123.45.6.89/32"]`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := find(result, tc.resource, tc.attribute)
+			require.NotNil(t, d)
+			actual := *d
+			actual.Resource, actual.Address, actual.Attribute = "", "", ""
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	for _, unchanged := range []struct{ resource, attribute string }{
+		{"node group default", "scaling_config.desired_size"},
+		{"node group default", "volume_type"},
+		{"PostgreSQL server default", "allocated_storage"},
+		{"nfs VM", "instance_type"},
+		{"nfs VM", "root_block_device.volume_size"},
+		{"VPC", "cidr_block"},
+		{"subnets private", "cidr_block"},
+		{"storage backend", "storage_type_backend"},
+	} {
+		assert.Nil(t, find(result, unchanged.resource, unchanged.attribute), "%s %s", unchanged.resource, unchanged.attribute)
+	}
+}
+
+func TestDetectWithoutAutoscaler(t *testing.T) {
+	t.Parallel()
+
+	in := inputs(t)
+	in["autoscaling_enabled"] = cty.False
+	in["default_nodepool_node_count"] = cty.NumberIntVal(3)
+	result := detect(t, in)
+
+	d := find(result, "node group cas", "scaling_config.desired_size")
+	require.NotNil(t, d)
+	assert.Equal(t, Drift, d.Class)
+	assert.Equal(t, `node_pools["cas"] has no node count, it follows min_nodes`, d.Suggestion)
+
+	d = find(result, "node group default", "scaling_config.desired_size")
+	require.NotNil(t, d)
+	assert.Equal(t, Difference{
+		Class:      Drift,
+		Resource:   "node group default",
+		Address:    `module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0]`,
+		Attribute:  "scaling_config.desired_size",
+		State:      "2",
+		Intended:   "3",
+		Suggestion: "default_nodepool_node_count = 2",
+	}, *d)
+}
+
+func TestDetectStorageAndPrivateEndpoint(t *testing.T) {
+	t.Parallel()
+
+	in := inputs(t)
+	in["storage_type"] = cty.StringVal("ha")
+	in["cluster_api_mode"] = cty.StringVal("private")
+	result := detect(t, in)
+
+	d := find(result, "storage backend", "storage_type_backend")
+	require.NotNil(t, d)
+	assert.Equal(t, `"nfs"`, d.State)
+	assert.Equal(t, `"efs"`, d.Intended)
+	assert.Equal(t, `storage_type = "standard", the next apply replaces the storage and its data`, d.Suggestion)
+	assert.Nil(t, find(result, "cluster endpoint", "vpc_config.public_access_cidrs"), "private endpoints have no public CIDRs")
+}
+
+func TestDetectEmptyState(t *testing.T) {
+	t.Parallel()
+
+	result, err := Detect(&statefile.State{}, tfvars.Inputs{"storage_type": cty.StringVal("none")})
+	require.NoError(t, err)
+	// Only the default node pool, which the state does not have yet
+	require.Len(t, result.Differences, 1)
+	assert.Equal(t, "node group default", result.Differences[0].Resource)
+}
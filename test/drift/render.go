@@ -0,0 +1,25 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import "test/report"
+
+// Tables renders the differences
+func (r *Result) Tables() []*report.Table {
+	t := &report.Table{
+		Title: "Differences between the state and the tfvars",
+		Columns: []report.Column{
+			{Header: "Class"},
+			{Header: "Resource"},
+			{Header: "Attribute"},
+			{Header: "State"},
+			{Header: "Intended"},
+			{Header: "Suggestion"},
+		},
+	}
+	for _, d := range r.Differences {
+		t.AddRow(string(d.Class), d.Resource, d.Attribute, d.State, d.Intended, d.Suggestion)
+	}
+	return []*report.Table{t}
+}
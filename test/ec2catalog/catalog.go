@@ -0,0 +1,112 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ec2catalog ships an offline catalog of EC2 instance types and checks
+// node pools, VMs and disks against it.
+//
+// The embedded catalog.json is refreshed with cmd/ec2catalog from the output of
+// `aws ec2 describe-instance-types --output json`.
+package ec2catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed catalog.json
+var embeddedCatalog []byte
+
+// InstanceType describes the hardware of a single EC2 instance type
+type InstanceType struct {
+	Name                string `json:"instanceType"`
+	Architecture        string `json:"architecture"`
+	Hypervisor          string `json:"hypervisor"`
+	VCPUs               int    `json:"vcpus"`
+	MemoryMiB           int    `json:"memoryMiB"`
+	GPUs                int    `json:"gpus"`
+	GPUManufacturer     string `json:"gpuManufacturer"`
+	GPUModel            string `json:"gpuModel"`
+	Accelerator         string `json:"accelerator"`
+	InstanceStorageGB   int    `json:"instanceStorageGB"`
+	NVMeInstanceStorage bool   `json:"nvmeInstanceStorage"`
+	EBSOptimized        string `json:"ebsOptimized"`
+	NetworkPerformance  string `json:"networkPerformance"`
+}
+
+// Family returns the instance family, for example "m6in" for "m6in.xlarge"
+func (it InstanceType) Family() string {
+	family, _, _ := strings.Cut(it.Name, ".")
+	return family
+}
+
+// Catalog is a set of instance types indexed by name
+type Catalog struct {
+	Generated     string         `json:"generated"`
+	Source        string         `json:"source"`
+	InstanceTypes []InstanceType `json:"instanceTypes"`
+
+	index map[string]InstanceType
+}
+
+// Load reads a catalog in the catalog.json format
+func Load(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("decoding instance type catalog: %w", err)
+	}
+	c.reindex()
+	return &c, nil
+}
+
+var (
+	defaultCatalog *Catalog
+	defaultOnce    sync.Once
+)
+
+// Default returns the catalog embedded in this package
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		var c Catalog
+		if err := json.Unmarshal(embeddedCatalog, &c); err != nil {
+			panic(fmt.Sprintf("embedded instance type catalog is invalid: %v", err))
+		}
+		c.reindex()
+		defaultCatalog = &c
+	})
+	return defaultCatalog
+}
+
+// Lookup returns the instance type with the given name
+func (c *Catalog) Lookup(name string) (InstanceType, bool) {
+	it, ok := c.index[name]
+	return it, ok
+}
+
+// Merge adds or replaces instance types, keeping the list sorted by name
+func (c *Catalog) Merge(types ...InstanceType) {
+	if c.index == nil {
+		c.reindex()
+	}
+	for _, it := range types {
+		c.index[it.Name] = it
+	}
+	c.InstanceTypes = c.InstanceTypes[:0]
+	for _, it := range c.index {
+		c.InstanceTypes = append(c.InstanceTypes, it)
+	}
+	sort.Slice(c.InstanceTypes, func(i, j int) bool {
+		return c.InstanceTypes[i].Name < c.InstanceTypes[j].Name
+	})
+}
+
+func (c *Catalog) reindex() {
+	c.index = make(map[string]InstanceType, len(c.InstanceTypes))
+	for _, it := range c.InstanceTypes {
+		c.index[it.Name] = it
+	}
+}
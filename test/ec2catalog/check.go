@@ -0,0 +1,221 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ec2catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"test/tfvars"
+)
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Finding is a compatibility problem found for a node pool, VM or disk
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Subject  string   `json:"subject"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Subject, f.Message)
+}
+
+// IsWarning reports whether the finding should not fail a check
+func (f Finding) IsWarning() bool {
+	return f.Severity == Warning
+}
+
+// DefaultAMIType is the AMI type EKS uses for a node group without ami_type, as the default node pool is built
+const DefaultAMIType = "AL2023_x86_64_STANDARD"
+
+// amiType describes the hardware an EKS node group AMI type supports
+type amiType struct {
+	arch        string
+	nvidia      bool
+	accelerator string
+}
+
+var amiTypes = map[string]amiType{
+	"AL2_x86_64":                 {arch: "x86_64"},
+	"AL2_x86_64_GPU":             {arch: "x86_64", nvidia: true},
+	"AL2_ARM_64":                 {arch: "arm64"},
+	"AL2023_x86_64_STANDARD":     {arch: "x86_64"},
+	"AL2023_ARM_64_STANDARD":     {arch: "arm64"},
+	"AL2023_x86_64_NVIDIA":       {arch: "x86_64", nvidia: true},
+	"AL2023_ARM_64_NVIDIA":       {arch: "arm64", nvidia: true},
+	"AL2023_x86_64_NEURON":       {arch: "x86_64", accelerator: "neuron"},
+	"BOTTLEROCKET_x86_64":        {arch: "x86_64"},
+	"BOTTLEROCKET_ARM_64":        {arch: "arm64"},
+	"BOTTLEROCKET_x86_64_NVIDIA": {arch: "x86_64", nvidia: true},
+	"BOTTLEROCKET_ARM_64_NVIDIA": {arch: "arm64", nvidia: true},
+}
+
+// CheckInstance checks that an instance type exists in the catalog and can run the given EKS AMI type
+func (c *Catalog) CheckInstance(subject, vmType, ami string) []Finding {
+	if ami == "" {
+		ami = DefaultAMIType
+	}
+	at, knownAMI := amiTypes[ami]
+	it, knownType := c.Lookup(vmType)
+
+	var findings []Finding
+	if !knownAMI {
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("cpu_type %q is not a supported EKS AMI type", ami)})
+	}
+	if !knownType {
+		return append(findings, Finding{Warning, subject, fmt.Sprintf("instance type %q is not in the catalog generated %s; refresh the catalog if it is new", vmType, c.Generated)})
+	}
+	if !knownAMI {
+		return findings
+	}
+
+	if it.Architecture != at.arch {
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s is %s but cpu_type %s is built for %s", vmType, it.Architecture, ami, at.arch)})
+	}
+	switch {
+	case at.nvidia && it.GPUs == 0:
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("cpu_type %s needs an NVIDIA GPU but %s has none", ami, vmType)})
+	case at.nvidia && it.GPUManufacturer != "NVIDIA":
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("cpu_type %s needs an NVIDIA GPU but %s has %s %s GPUs", ami, vmType, it.GPUManufacturer, it.GPUModel)})
+	case !at.nvidia && it.GPUs > 0 && it.GPUManufacturer == "NVIDIA":
+		findings = append(findings, Finding{Warning, subject, fmt.Sprintf("%s has %d %s GPU(s) that cpu_type %s has no drivers for; use an NVIDIA AMI type", vmType, it.GPUs, it.GPUModel, ami)})
+	}
+	if at.accelerator != it.Accelerator {
+		if at.accelerator != "" {
+			findings = append(findings, Finding{Error, subject, fmt.Sprintf("cpu_type %s needs %s accelerators but %s has none", ami, at.accelerator, vmType)})
+		} else {
+			findings = append(findings, Finding{Warning, subject, fmt.Sprintf("%s has %s accelerators that cpu_type %s has no drivers for", vmType, it.Accelerator, ami)})
+		}
+	}
+	return findings
+}
+
+// CheckVM checks an instance type used for a VM from modules/aws_vm, which
+// always boots the x86_64 Ubuntu AMI. For the NFS server the RAID disks must
+// show up as the only extra NVMe devices, which the cloud-init bootcmd counts.
+func (c *Catalog) CheckVM(subject, vmType string, nfs bool) []Finding {
+	it, ok := c.Lookup(vmType)
+	if !ok {
+		return []Finding{{Warning, subject, fmt.Sprintf("instance type %q is not in the catalog generated %s; refresh the catalog if it is new", vmType, c.Generated)}}
+	}
+
+	var findings []Finding
+	if it.Architecture != "x86_64" {
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s is %s but modules/aws_vm only selects x86_64 Ubuntu AMIs", vmType, it.Architecture)})
+	}
+	if nfs {
+		if it.Hypervisor != "nitro" {
+			findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s is a %s instance that attaches EBS volumes as xvd devices; the NFS cloud-init waits for NVMe devices", vmType, it.Hypervisor)})
+		}
+		if it.NVMeInstanceStorage {
+			findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s has %d GB of NVMe instance storage that the NFS cloud-init would add to the RAID array", vmType, it.InstanceStorageGB)})
+		}
+	}
+	return findings
+}
+
+// volumeLimits holds the documented EBS size (GiB) and IOPS bounds of a volume type
+type volumeLimits struct {
+	minSize, maxSize int
+	minIOPS, maxIOPS int
+	iopsPerGiB       int
+}
+
+var volumeTypes = map[string]volumeLimits{
+	"standard": {minSize: 1, maxSize: 1024},
+	"gp2":      {minSize: 1, maxSize: 16384},
+	"gp3":      {minSize: 1, maxSize: 16384, minIOPS: 3000, maxIOPS: 16000, iopsPerGiB: 500},
+	"io1":      {minSize: 4, maxSize: 16384, minIOPS: 100, maxIOPS: 64000, iopsPerGiB: 50},
+	"io2":      {minSize: 4, maxSize: 65536, minIOPS: 100, maxIOPS: 256000, iopsPerGiB: 1000},
+	"st1":      {minSize: 125, maxSize: 16384},
+	"sc1":      {minSize: 125, maxSize: 16384},
+}
+
+// CheckVolume checks an EBS volume's size and IOPS against the limits of its type.
+// An IOPS value of 0 means unset, as the *_iops variables default to it.
+func CheckVolume(subject, volumeType string, size, iops int) []Finding {
+	limits, ok := volumeTypes[volumeType]
+	if !ok {
+		return []Finding{{Error, subject, fmt.Sprintf("%q is not an EBS volume type", volumeType)}}
+	}
+
+	var findings []Finding
+	if size < limits.minSize || size > limits.maxSize {
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s volumes must be %d-%d GiB, got %d", volumeType, limits.minSize, limits.maxSize, size)})
+	}
+	switch {
+	case limits.maxIOPS == 0 && iops > 0:
+		findings = append(findings, Finding{Warning, subject, fmt.Sprintf("iops is ignored for %s volumes", volumeType)})
+	case limits.maxIOPS == 0:
+	case iops == 0 && (volumeType == "io1" || volumeType == "io2"):
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s volumes require iops", volumeType)})
+	case iops == 0:
+	case iops < limits.minIOPS || iops > limits.maxIOPS:
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s iops must be %d-%d, got %d", volumeType, limits.minIOPS, limits.maxIOPS, iops)})
+	case iops > size*limits.iopsPerGiB:
+		findings = append(findings, Finding{Error, subject, fmt.Sprintf("%s allows at most %d iops per GiB; %d iops needs at least %d GiB, got %d",
+			volumeType, limits.iopsPerGiB, iops, (iops+limits.iopsPerGiB-1)/limits.iopsPerGiB, size)})
+	}
+	return findings
+}
+
+// checkEBSOptimized warns when provisioned IOPS volumes are attached to an instance without EBS optimization
+func (c *Catalog) checkEBSOptimized(subject, vmType, volumeType string) []Finding {
+	it, ok := c.Lookup(vmType)
+	if !ok || !strings.HasPrefix(volumeType, "io") || it.EBSOptimized != "unsupported" {
+		return nil
+	}
+	return []Finding{{Warning, subject, fmt.Sprintf("%s is not EBS-optimized; %s volumes will not reach their provisioned iops", vmType, volumeType)}}
+}
+
+// CheckNodePool checks a node pool's instance type, AMI type and OS disk
+func (c *Catalog) CheckNodePool(np tfvars.NodePool) []Finding {
+	subject := fmt.Sprintf("node_pools[%q]", np.Name)
+	if np.Name == tfvars.DefaultNodePoolName {
+		subject = "default node pool"
+	}
+	findings := c.CheckInstance(subject, np.VMType, np.CPUType)
+	findings = append(findings, CheckVolume(subject+" os disk", np.OSDiskType, np.OSDiskSize, np.OSDiskIOPS)...)
+	return append(findings, c.checkEBSOptimized(subject, np.VMType, np.OSDiskType)...)
+}
+
+// CheckInputs runs every check for the node pools, VMs and disks configured by the inputs
+func (c *Catalog) CheckInputs(in tfvars.Inputs) ([]Finding, error) {
+	pools, err := in.NodePools()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, np := range pools {
+		findings = append(findings, c.CheckNodePool(np)...)
+	}
+
+	osDiskType := in.String("os_disk_type")
+	osDiskSize := int(in.Number("os_disk_size"))
+	osDiskIOPS := int(in.Number("os_disk_iops"))
+
+	if in.Bool("create_jump_vm") {
+		vmType := in.String("jump_vm_type")
+		findings = append(findings, c.CheckVM("jump_vm_type", vmType, false)...)
+		findings = append(findings, CheckVolume("jump VM os disk", osDiskType, osDiskSize, osDiskIOPS)...)
+		findings = append(findings, c.checkEBSOptimized("jump_vm_type", vmType, osDiskType)...)
+	}
+	if in.String("storage_type") == "standard" {
+		vmType := in.String("nfs_vm_type")
+		findings = append(findings, c.CheckVM("nfs_vm_type", vmType, true)...)
+		findings = append(findings, CheckVolume("NFS VM os disk", osDiskType, osDiskSize, osDiskIOPS)...)
+		findings = append(findings, CheckVolume("NFS RAID disks", in.String("nfs_raid_disk_type"), int(in.Number("nfs_raid_disk_size")), int(in.Number("nfs_raid_disk_iops")))...)
+		findings = append(findings, c.checkEBSOptimized("nfs_vm_type", vmType, in.String("nfs_raid_disk_type"))...)
+	}
+	return findings, nil
+}
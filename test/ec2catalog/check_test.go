@@ -0,0 +1,223 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ec2catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"test/tfvars"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInputsExamples(t *testing.T) {
+	t.Parallel()
+
+	examples, err := filepath.Glob("../../examples/*.tfvars")
+	require.NoError(t, err)
+	require.NotEmpty(t, examples)
+
+	for _, example := range examples {
+		t.Run(filepath.Base(example), func(t *testing.T) {
+			in, err := tfvars.LoadInputs("../..", example)
+			require.NoError(t, err)
+			findings, err := Default().CheckInputs(in)
+			require.NoError(t, err)
+			assert.Empty(t, findings)
+		})
+	}
+}
+
+func TestCheckInstance(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		vmType   string
+		ami      string
+		expected []Finding
+	}{
+		"defaultAMI": {
+			vmType: "r6in.2xlarge",
+		},
+		"gpuWithStandardAMI": {
+			vmType: "g4dn.xlarge",
+			ami:    "AL2023_x86_64_STANDARD",
+			expected: []Finding{
+				{Warning, "pool", "g4dn.xlarge has 1 T4 GPU(s) that cpu_type AL2023_x86_64_STANDARD has no drivers for; use an NVIDIA AMI type"},
+			},
+		},
+		"armWithX86AMI": {
+			vmType: "m7g.xlarge",
+			ami:    "AL2023_x86_64_STANDARD",
+			expected: []Finding{
+				{Error, "pool", "m7g.xlarge is arm64 but cpu_type AL2023_x86_64_STANDARD is built for x86_64"},
+			},
+		},
+		"nvidiaAMIWithoutGPU": {
+			vmType: "m6in.xlarge",
+			ami:    "AL2023_x86_64_NVIDIA",
+			expected: []Finding{
+				{Error, "pool", "cpu_type AL2023_x86_64_NVIDIA needs an NVIDIA GPU but m6in.xlarge has none"},
+			},
+		},
+		"nvidiaAMIWithAMDGPU": {
+			vmType: "g4ad.xlarge",
+			ami:    "AL2023_x86_64_NVIDIA",
+			expected: []Finding{
+				{Error, "pool", "cpu_type AL2023_x86_64_NVIDIA needs an NVIDIA GPU but g4ad.xlarge has AMD Radeon Pro V520 GPUs"},
+			},
+		},
+		"unknownAMI": {
+			vmType: "m6in.xlarge",
+			ami:    "WINDOWS_CORE_2022_x86_64",
+			expected: []Finding{
+				{Error, "pool", `cpu_type "WINDOWS_CORE_2022_x86_64" is not a supported EKS AMI type`},
+			},
+		},
+		"unknownInstanceType": {
+			vmType: "m99.xlarge",
+			expected: []Finding{
+				{Warning, "pool", `instance type "m99.xlarge" is not in the catalog generated 2026-10-01; refresh the catalog if it is new`},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Default().CheckInstance("pool", tc.vmType, tc.ami))
+		})
+	}
+}
+
+func TestCheckVolume(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		volumeType string
+		size, iops int
+		expected   string
+	}{
+		"gp2Unset":       {"gp2", 200, 0, ""},
+		"gp3Baseline":    {"gp3", 128, 3000, ""},
+		"io1Valid":       {"io1", 100, 5000, ""},
+		"io1TooFew":      {"io1", 100, 50, "error: disk: io1 iops must be 100-64000, got 50"},
+		"io1TooMany":     {"io1", 100, 70000, "error: disk: io1 iops must be 100-64000, got 70000"},
+		"io1Ratio":       {"io1", 64, 5000, "error: disk: io1 allows at most 50 iops per GiB; 5000 iops needs at least 100 GiB, got 64"},
+		"io1Unset":       {"io1", 100, 0, "error: disk: io1 volumes require iops"},
+		"st1TooSmall":    {"st1", 64, 0, "error: disk: st1 volumes must be 125-16384 GiB, got 64"},
+		"gp2IopsIgnored": {"gp2", 64, 3000, "warning: disk: iops is ignored for gp2 volumes"},
+		"unknownType":    {"gp4", 64, 0, `error: disk: "gp4" is not an EBS volume type`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var messages []string
+			for _, f := range CheckVolume("disk", tc.volumeType, tc.size, tc.iops) {
+				messages = append(messages, f.String())
+			}
+			assert.Equal(t, tc.expected, strings.Join(messages, "\n"))
+		})
+	}
+}
+
+func TestCheckInputsNFSServer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "input.tfvars")
+	require.NoError(t, os.WriteFile(path, []byte(`
+prefix       = "viya"
+storage_type = "standard"
+nfs_vm_type  = "m4.xlarge"
+jump_vm_type = "m6gd.large"
+`), 0600))
+	in, err := tfvars.LoadInputs("../..", path)
+	require.NoError(t, err)
+
+	findings, err := Default().CheckInputs(in)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, f := range findings {
+		messages = append(messages, f.String())
+	}
+	assert.Equal(t, []string{
+		"error: jump_vm_type: m6gd.large is arm64 but modules/aws_vm only selects x86_64 Ubuntu AMIs",
+		"error: nfs_vm_type: m4.xlarge is a xen instance that attaches EBS volumes as xvd devices; the NFS cloud-init waits for NVMe devices",
+	}, messages)
+
+	findings = Default().CheckVM("nfs_vm_type", "m6id.xlarge", true)
+	require.Len(t, findings, 1)
+	assert.Contains(t, findings[0].Message, "NVMe instance storage")
+}
+
+func TestParseDescribeInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	types, err := ParseDescribeInstanceTypes(strings.NewReader(`{"InstanceTypes": [{
+  "InstanceType": "g5.2xlarge",
+  "Hypervisor": "nitro",
+  "ProcessorInfo": {"SupportedArchitectures": ["x86_64"]},
+  "VCpuInfo": {"DefaultVCpus": 8},
+  "MemoryInfo": {"SizeInMiB": 32768},
+  "GpuInfo": {"Gpus": [{"Name": "A10G", "Manufacturer": "NVIDIA", "Count": 1}]},
+  "InstanceStorageInfo": {"TotalSizeInGB": 450, "NvmeSupport": "required"},
+  "EbsInfo": {"EbsOptimizedSupport": "default"},
+  "NetworkInfo": {"NetworkPerformance": "Up to 10 Gigabit"}
+}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []InstanceType{{
+		Name:                "g5.2xlarge",
+		Architecture:        "x86_64",
+		Hypervisor:          "nitro",
+		VCPUs:               8,
+		MemoryMiB:           32768,
+		GPUs:                1,
+		GPUManufacturer:     "NVIDIA",
+		GPUModel:            "A10G",
+		InstanceStorageGB:   450,
+		NVMeInstanceStorage: true,
+		EBSOptimized:        "default",
+		NetworkPerformance:  "Up to 10 Gigabit",
+	}}, types)
+
+	c := &Catalog{}
+	c.Merge(types...)
+	it, ok := c.Lookup("g5.2xlarge")
+	assert.True(t, ok)
+	assert.Equal(t, "g5", it.Family())
+}
+
+func TestCheckPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := &terraform.PlanStruct{ResourcePlannedValuesMap: map[string]*tfjson.StateResource{
+		`module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]`: {AttributeValues: map[string]interface{}{
+			"ami_type":       "AL2023_ARM_64_STANDARD",
+			"instance_types": []interface{}{"r6in.2xlarge"},
+		}},
+		`module.eks.module.eks_managed_node_group["cas"].aws_launch_template.this[0]`: {AttributeValues: map[string]interface{}{
+			"block_device_mappings": []interface{}{map[string]interface{}{
+				"ebs": []interface{}{map[string]interface{}{"volume_type": "io1", "volume_size": float64(200), "iops": float64(0)}},
+			}},
+		}},
+		"module.nfs[0].aws_instance.vm": {AttributeValues: map[string]interface{}{
+			"instance_type": "r6in.xlarge",
+		}},
+	}}
+
+	var messages []string
+	for _, f := range Default().CheckPlan(plan) {
+		messages = append(messages, f.String())
+	}
+	assert.Equal(t, []string{
+		`error: module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]: r6in.2xlarge is x86_64 but cpu_type AL2023_ARM_64_STANDARD is built for arm64`,
+		`error: module.eks.module.eks_managed_node_group["cas"].aws_launch_template.this[0]: io1 volumes require iops`,
+	}, messages)
+}
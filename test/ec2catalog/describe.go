@@ -0,0 +1,97 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ec2catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// describeOutput is the subset of `aws ec2 describe-instance-types --output json` the catalog keeps
+type describeOutput struct {
+	InstanceTypes []struct {
+		InstanceType  string
+		Hypervisor    string
+		ProcessorInfo struct {
+			SupportedArchitectures []string
+		}
+		VCpuInfo struct {
+			DefaultVCpus int
+		}
+		MemoryInfo struct {
+			SizeInMiB int
+		}
+		GpuInfo *struct {
+			Gpus []struct {
+				Name         string
+				Manufacturer string
+				Count        int
+			}
+		}
+		InferenceAcceleratorInfo *struct {
+			Accelerators []struct {
+				Manufacturer string
+			}
+		}
+		NeuronInfo          *struct{}
+		InstanceStorageInfo *struct {
+			TotalSizeInGB int
+			NvmeSupport   string
+		}
+		EbsInfo struct {
+			EbsOptimizedSupport string
+		}
+		NetworkInfo struct {
+			NetworkPerformance string
+		}
+	}
+}
+
+// ParseDescribeInstanceTypes converts the JSON output of
+// `aws ec2 describe-instance-types` into catalog entries.
+func ParseDescribeInstanceTypes(r io.Reader) ([]InstanceType, error) {
+	var out describeOutput
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding describe-instance-types output: %w", err)
+	}
+
+	types := make([]InstanceType, 0, len(out.InstanceTypes))
+	for _, d := range out.InstanceTypes {
+		it := InstanceType{
+			Name:               d.InstanceType,
+			Hypervisor:         d.Hypervisor,
+			VCPUs:              d.VCpuInfo.DefaultVCpus,
+			MemoryMiB:          d.MemoryInfo.SizeInMiB,
+			EBSOptimized:       d.EbsInfo.EbsOptimizedSupport,
+			NetworkPerformance: d.NetworkInfo.NetworkPerformance,
+		}
+		if it.Hypervisor == "" {
+			// bare metal instances report no hypervisor and expose EBS over NVMe
+			it.Hypervisor = "nitro"
+		}
+		for _, arch := range d.ProcessorInfo.SupportedArchitectures {
+			// i386 is listed next to x86_64 on older families
+			if arch == "x86_64" || arch == "arm64" {
+				it.Architecture = arch
+			}
+		}
+		if d.GpuInfo != nil {
+			for _, gpu := range d.GpuInfo.Gpus {
+				it.GPUs += gpu.Count
+				it.GPUManufacturer = gpu.Manufacturer
+				it.GPUModel = gpu.Name
+			}
+		}
+		if d.NeuronInfo != nil || (d.InferenceAcceleratorInfo != nil && len(d.InferenceAcceleratorInfo.Accelerators) > 0) {
+			it.Accelerator = "neuron"
+		}
+		if d.InstanceStorageInfo != nil {
+			it.InstanceStorageGB = d.InstanceStorageInfo.TotalSizeInGB
+			it.NVMeInstanceStorage = d.InstanceStorageInfo.NvmeSupport == "required" || d.InstanceStorageInfo.NvmeSupport == "supported"
+		}
+		types = append(types, it)
+	}
+	return types, nil
+}
@@ -0,0 +1,49 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ec2catalog
+
+import (
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// CheckPlan runs the compatibility checks against the planned node groups,
+// launch templates, VMs and RAID disks, so plan tests can use the same rules
+// as preflight.
+func (c *Catalog) CheckPlan(plan *terraform.PlanStruct) []Finding {
+	var findings []Finding
+	for _, r := range planfile.Resources(plan) {
+		switch {
+		case strings.HasSuffix(r.Address, ".aws_eks_node_group.this[0]"):
+			for _, vmType := range r.Values.Strings("instance_types") {
+				findings = append(findings, c.CheckInstance(r.Address, vmType, r.Values.String("ami_type"))...)
+			}
+		case strings.HasSuffix(r.Address, ".aws_launch_template.this[0]"):
+			for _, bdm := range r.Values.Blocks("block_device_mappings") {
+				for _, ebs := range bdm.Blocks("ebs") {
+					findings = append(findings, checkPlannedVolume(r.Address, ebs.String("volume_type"), ebs.Number("volume_size"), ebs.Number("iops"))...)
+				}
+			}
+		case r.Address == "module.jump[0].aws_instance.vm" || r.Address == "module.nfs[0].aws_instance.vm":
+			findings = append(findings, c.CheckVM(r.Address, r.Values.String("instance_type"), strings.HasPrefix(r.Address, "module.nfs"))...)
+			for _, root := range r.Values.Blocks("root_block_device") {
+				findings = append(findings, checkPlannedVolume(r.Address, root.String("volume_type"), root.Number("volume_size"), root.Number("iops"))...)
+			}
+		case strings.HasPrefix(r.Address, "module.nfs[0].aws_ebs_volume.raid_disk["):
+			findings = append(findings, checkPlannedVolume(r.Address, r.Values.String("type"), r.Values.Number("size"), r.Values.Number("iops"))...)
+		}
+	}
+	return findings
+}
+
+// checkPlannedVolume checks a volume unless its type is unknown until apply
+func checkPlannedVolume(subject, volumeType string, size, iops float64) []Finding {
+	if volumeType == "" {
+		return nil
+	}
+	return CheckVolume(subject, volumeType, int(size), int(iops))
+}
@@ -0,0 +1,360 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package graph builds the resource dependency graph of the configuration in
+// a plan and renders it as DOT or Mermaid.
+//
+// The graph is built from the configuration section of the plan JSON, from
+// expression references and depends_on. References through module inputs and
+// outputs are followed to the resources behind them. The plan JSON does not
+// carry the expressions of locals, so dependencies that only go through a
+// local value are missing from the graph.
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Kind of a node
+type Kind string
+
+const (
+	Resource Kind = "resource"
+	Data     Kind = "data"
+	// Module is a collapsed module call
+	Module Kind = "module"
+)
+
+// Node is a resource, a data source or a collapsed module
+type Node struct {
+	// ID is the configuration address, without instance keys
+	ID   string `json:"id"`
+	Kind Kind   `json:"kind"`
+	// Module is the module path of the node, empty in the root module
+	Module string `json:"module,omitempty"`
+}
+
+// Graph is a dependency graph, an edge points from a node to a node it
+// depends on
+type Graph struct {
+	Nodes map[string]*Node
+	Edges map[string]map[string]bool
+	// Sources maps the module paths to the source of the module call
+	Sources map[string]string
+}
+
+func newGraph() *Graph {
+	return &Graph{Nodes: map[string]*Node{}, Edges: map[string]map[string]bool{}, Sources: map[string]string{}}
+}
+
+func (g *Graph) addNode(n *Node) {
+	g.Nodes[n.ID] = n
+	if g.Edges[n.ID] == nil {
+		g.Edges[n.ID] = map[string]bool{}
+	}
+}
+
+func (g *Graph) addEdge(from, to string) {
+	if from != to {
+		g.Edges[from][to] = true
+	}
+}
+
+// IDs returns the sorted node IDs
+func (g *Graph) IDs() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DependsOn returns the sorted dependencies of a node
+func (g *Graph) DependsOn(id string) []string {
+	deps := make([]string, 0, len(g.Edges[id]))
+	for to := range g.Edges[id] {
+		deps = append(deps, to)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// FromPlan builds the graph of the configuration of a plan
+func FromPlan(plan *terraform.PlanStruct) *Graph {
+	return Build(plan.RawPlan.Config)
+}
+
+// Build builds the graph of a configuration
+func Build(config *tfjson.Config) *Graph {
+	g := newGraph()
+	if config == nil || config.RootModule == nil {
+		return g
+	}
+	b := &builder{graph: g, resolving: map[string]bool{}}
+	root := &scope{module: config.RootModule}
+	b.collect(root)
+	b.link(root)
+	return g
+}
+
+// scope is a module instance in the module tree
+type scope struct {
+	// path is the module path, like module.eks.module.kms
+	path   string
+	module *tfjson.ConfigModule
+	// call is the module call of the scope in the parent, nil in the root
+	call   *tfjson.ModuleCall
+	parent *scope
+}
+
+func (s *scope) address(local string) string {
+	if s.path == "" {
+		return local
+	}
+	return s.path + "." + local
+}
+
+func (s *scope) child(name string) *scope {
+	call, ok := s.module.ModuleCalls[name]
+	if !ok || call.Module == nil {
+		return nil
+	}
+	return &scope{path: s.address("module." + name), module: call.Module, call: call, parent: s}
+}
+
+func (s *scope) children() []*scope {
+	names := make([]string, 0, len(s.module.ModuleCalls))
+	for name := range s.module.ModuleCalls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var children []*scope
+	for _, name := range names {
+		if c := s.child(name); c != nil {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+type builder struct {
+	graph *Graph
+	// resolving guards against following a module input or output in a loop
+	resolving map[string]bool
+}
+
+// collect adds the resources of a scope and its children as nodes
+func (b *builder) collect(s *scope) {
+	if s.call != nil {
+		b.graph.Sources[s.path] = s.call.Source
+	}
+	for _, r := range s.module.Resources {
+		kind := Resource
+		if r.Mode == tfjson.DataResourceMode {
+			kind = Data
+		}
+		b.graph.addNode(&Node{ID: s.address(r.Address), Kind: kind, Module: s.path})
+	}
+	for _, c := range s.children() {
+		b.collect(c)
+	}
+}
+
+// link adds the edges of the resources of a scope and its children. The
+// count, for_each and depends_on of a module call apply to every resource in
+// the module.
+func (b *builder) link(s *scope) {
+	inherited := b.callDependencies(s)
+	for _, r := range s.module.Resources {
+		id := s.address(r.Address)
+		var refs []string
+		for _, e := range r.Expressions {
+			refs = append(refs, references(e)...)
+		}
+		for _, p := range r.Provisioners {
+			for _, e := range p.Expressions {
+				refs = append(refs, references(e)...)
+			}
+		}
+		refs = append(refs, references(r.CountExpression)...)
+		refs = append(refs, references(r.ForEachExpression)...)
+		refs = append(refs, r.DependsOn...)
+		for _, to := range b.resolve(s, refs) {
+			b.graph.addEdge(id, to)
+		}
+		for _, to := range inherited {
+			b.graph.addEdge(id, to)
+		}
+	}
+	for _, c := range s.children() {
+		b.link(c)
+	}
+}
+
+// callDependencies returns the dependencies the module calls of a scope and
+// its parents impose on all of its resources
+func (b *builder) callDependencies(s *scope) []string {
+	var deps []string
+	for ; s.call != nil; s = s.parent {
+		refs := append(references(s.call.CountExpression), references(s.call.ForEachExpression)...)
+		refs = append(refs, s.call.DependsOn...)
+		deps = append(deps, b.resolve(s.parent, refs)...)
+	}
+	return deps
+}
+
+// resolve returns the node IDs behind the references made in a scope
+func (b *builder) resolve(s *scope, refs []string) []string {
+	seen := map[string]bool{}
+	var ids []string
+	add := func(found ...string) {
+		for _, id := range found {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	for _, ref := range refs {
+		parts := split(ref)
+		if len(parts) < 2 {
+			continue
+		}
+		switch parts[0] {
+		case "local", "each", "count", "path", "terraform", "self":
+		case "var":
+			add(b.input(s, parts[1])...)
+		case "module":
+			if len(parts) > 2 {
+				add(b.output(s, parts[1], parts[2])...)
+			} else {
+				add(b.within(s.address("module." + parts[1]))...)
+			}
+		case "data":
+			if len(parts) > 2 {
+				add(b.node(s.address("data." + parts[1] + "." + parts[2]))...)
+			}
+		default:
+			add(b.node(s.address(parts[0] + "." + parts[1]))...)
+		}
+	}
+	return ids
+}
+
+func (b *builder) node(id string) []string {
+	if _, ok := b.graph.Nodes[id]; ok {
+		return []string{id}
+	}
+	return nil
+}
+
+// within returns the nodes in a module and its children
+func (b *builder) within(path string) []string {
+	var ids []string
+	for _, id := range b.graph.IDs() {
+		if m := b.graph.Nodes[id].Module; m == path || strings.HasPrefix(m, path+".") {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// input follows a variable of a module to the expression of the module call
+func (b *builder) input(s *scope, name string) []string {
+	if s.call == nil {
+		return nil
+	}
+	key := s.path + " var." + name
+	if b.resolving[key] {
+		return nil
+	}
+	b.resolving[key] = true
+	defer delete(b.resolving, key)
+	return b.resolve(s.parent, references(s.call.Expressions[name]))
+}
+
+// output follows a module output to the resources behind its expression
+func (b *builder) output(s *scope, module, name string) []string {
+	c := s.child(module)
+	if c == nil {
+		return nil
+	}
+	o, ok := c.module.Outputs[name]
+	if !ok {
+		return b.within(c.path)
+	}
+	key := c.path + " output." + name
+	if b.resolving[key] {
+		return nil
+	}
+	b.resolving[key] = true
+	defer delete(b.resolving, key)
+	return b.resolve(c, append(references(o.Expression), o.DependsOn...))
+}
+
+// references returns the references of an expression and its nested blocks.
+// Terraform lists a reference to a module output together with the module
+// call itself, which would depend on every resource in the module, so the
+// module call is dropped when one of its outputs is referenced.
+func references(e *tfjson.Expression) []string {
+	if e == nil || e.ExpressionData == nil {
+		return nil
+	}
+	outputs := map[string]bool{}
+	for _, ref := range e.References {
+		if parts := split(ref); len(parts) > 2 && parts[0] == "module" {
+			outputs[parts[1]] = true
+		}
+	}
+	var refs []string
+	for _, ref := range e.References {
+		if parts := split(ref); len(parts) == 2 && parts[0] == "module" && outputs[parts[1]] {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	for _, block := range e.NestedBlocks {
+		for _, nested := range block {
+			refs = append(refs, references(nested)...)
+		}
+	}
+	return refs
+}
+
+// split splits a reference into its names, dropping index keys, so
+// module.nfs[0].private_ip becomes module, nfs and private_ip
+func split(ref string) []string {
+	var parts []string
+	var name strings.Builder
+	depth := 0
+	inString := false
+	for i := 0; i < len(ref); i++ {
+		ch := ref[i]
+		switch {
+		case inString:
+			if ch == '\\' {
+				i++
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case depth > 0:
+		case ch == '.':
+			parts = append(parts, name.String())
+			name.Reset()
+		default:
+			name.WriteByte(ch)
+		}
+	}
+	return append(parts, name.String())
+}
@@ -0,0 +1,174 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"test/planfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph(t *testing.T) *Graph {
+	plan, err := planfile.Load("testdata/plan.json")
+	require.NoError(t, err)
+	return FromPlan(plan)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	tests := map[string]struct {
+		id       string
+		expected []string
+	}{
+		"dependsOnModuleOutput": {"kubernetes_storage_class_v1.ebs_csi_tagged_default", []string{
+			"module.kubeconfig.local_file.kubeconfig",
+			"terraform_data.run_command",
+		}},
+		"dependsOnModule": {"data.cloudinit_config.jump", []string{
+			"aws_efs_file_system.efs-fs",
+			"module.nfs.aws_ebs_volume.raid_disk",
+			"module.nfs.aws_instance.vm",
+			"module.nfs.aws_volume_attachment.attachment",
+		}},
+		"nestedBlockReference": {"data.cloudinit_config.nfs", []string{"module.vpc.aws_subnet.public"}},
+		"moduleInput":          {"module.nfs.aws_instance.vm", []string{"data.cloudinit_config.nfs"}},
+		"inputThroughOutput": {"module.eks.aws_eks_cluster.this", []string{
+			"module.eks.aws_iam_role.this",
+			"module.eks.module.kms.aws_kms_key.this",
+			"module.vpc.aws_subnet.private",
+		}},
+		"moduleCallDependsOn": {"module.kubeconfig.local_file.kubeconfig", []string{
+			"module.eks.aws_eks_cluster.this",
+			"module.eks.aws_iam_role.this",
+			"module.eks.module.kms.aws_kms_key.this",
+		}},
+		"localsAreNotFollowed": {"terraform_data.run_command", []string{"module.kubeconfig.local_file.kubeconfig"}},
+		"noDependencies":       {"module.vpc.aws_vpc.vpc", []string{}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, g.DependsOn(tc.id))
+		})
+	}
+
+	assert.Equal(t, Data, g.Nodes["data.cloudinit_config.nfs"].Kind)
+	assert.Equal(t, "module.eks.module.kms", g.Nodes["module.eks.module.kms.aws_kms_key.this"].Module)
+}
+
+func TestCollapse(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	assert.Equal(t, []string{"module.eks"}, g.ExternalModules(), "module.eks.module.kms is inside module.eks")
+
+	c := g.Collapse(g.ExternalModules()...)
+	assert.Equal(t, &Node{ID: "module.eks", Kind: Module}, c.Nodes["module.eks"])
+	assert.NotContains(t, c.Nodes, "module.eks.aws_eks_cluster.this")
+	assert.Equal(t, []string{"module.vpc.aws_subnet.private"}, c.DependsOn("module.eks"))
+	assert.Equal(t, []string{"module.eks"}, c.DependsOn("module.kubeconfig.local_file.kubeconfig"))
+	assert.Len(t, g.Nodes, 18, "the graph is not modified")
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	f := testGraph(t).Collapse("module.eks").Filter("module.kubeconfig")
+	assert.Equal(t, []string{
+		"kubernetes_storage_class_v1.ebs_csi_tagged_default",
+		"module.eks",
+		"module.kubeconfig.local_file.kubeconfig",
+		"terraform_data.run_command",
+	}, f.IDs())
+	assert.Equal(t, []string{"module.kubeconfig.local_file.kubeconfig"}, f.DependsOn("kubernetes_storage_class_v1.ebs_csi_tagged_default"),
+		"edges between neighbors are left out")
+	assert.Empty(t, f.DependsOn("module.eks"))
+
+	root := testGraph(t).Filter("")
+	assert.Contains(t, root.Nodes, "terraform_data.run_command")
+	assert.NotContains(t, root.Nodes, "module.eks.module.kms.aws_kms_key.this")
+}
+
+func TestCriticalPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{
+		"module.jump.aws_volume_attachment.attachment",
+		"module.jump.aws_instance.vm",
+		"data.cloudinit_config.jump",
+		"module.nfs.aws_volume_attachment.attachment",
+		"module.nfs.aws_instance.vm",
+		"data.cloudinit_config.nfs",
+		"module.vpc.aws_subnet.public",
+		"module.vpc.aws_vpc.vpc",
+	}, testGraph(t).CriticalPath())
+	assert.Nil(t, newGraph().CriticalPath())
+}
+
+func TestCriticalPathIgnoresCycles(t *testing.T) {
+	t.Parallel()
+
+	g := newGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.addNode(&Node{ID: id, Kind: Resource})
+	}
+	g.addEdge("a", "b")
+	g.addEdge("b", "c")
+	g.addEdge("c", "a")
+	assert.Equal(t, []string{"a", "b", "c"}, g.CriticalPath())
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t).Collapse("module.eks").Filter("module.kubeconfig")
+	var b strings.Builder
+	require.NoError(t, g.WriteDOT(&b, g.CriticalPath()))
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "digraph terraform {\n"))
+	assert.Contains(t, out, "  subgraph \"cluster_module.kubeconfig\" {\n    label = \"module.kubeconfig\";\n"+
+		"    \"module.kubeconfig.local_file.kubeconfig\" [label = \"local_file.kubeconfig\", color = red, penwidth = 2];\n  }\n")
+	assert.Contains(t, out, `  "module.eks" [label = "module.eks", shape = box3d, color = red, penwidth = 2];`)
+	assert.Contains(t, out, `  "module.kubeconfig.local_file.kubeconfig" -> "module.eks" [color = red, penwidth = 2];`)
+	assert.Contains(t, out, `  "terraform_data.run_command" -> "module.kubeconfig.local_file.kubeconfig";`, "ties are broken by node ID")
+}
+
+func TestWriteMermaid(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t).Filter("module.vpc")
+	var b strings.Builder
+	require.NoError(t, g.WriteMermaid(&b, []string{"data.cloudinit_config.nfs", "module.vpc.aws_subnet.public"}))
+	assert.Equal(t, `flowchart RL
+  n0(["data.cloudinit_config.nfs"])
+  subgraph m1 ["module.eks"]
+    n1["aws_eks_cluster.this"]
+  end
+  subgraph m2 ["module.vpc"]
+    n2["aws_subnet.private"]
+    n3["aws_subnet.public"]
+    n4["aws_vpc.vpc"]
+  end
+  n0 --> n3
+  n1 --> n2
+  n2 --> n4
+  n3 --> n4
+  classDef critical stroke:#d00,stroke-width:3px
+  class n0,n3 critical
+  linkStyle 0 stroke:#d00,stroke-width:3px
+`, b.String())
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"module", "nfs", "private_ip"}, split("module.nfs[0].private_ip"))
+	assert.Equal(t, []string{"module", "postgresql", "address"}, split(`module.postgresql["a.b]"].address`))
+	assert.Equal(t, []string{"var", "tags"}, split("var.tags"))
+}
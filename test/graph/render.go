@@ -0,0 +1,192 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format of the graph
+type Format string
+
+const (
+	DOT     Format = "dot"
+	Mermaid Format = "mermaid"
+)
+
+// Formats lists the supported formats, for flag help
+var Formats = []Format{DOT, Mermaid}
+
+// ParseFormat returns the Format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported format %q, expected one of %v", name, Formats)
+}
+
+// Write renders the graph in the given format, highlighting the nodes and
+// edges of path
+func (g *Graph) Write(w io.Writer, format Format, path []string) error {
+	if format == Mermaid {
+		return g.WriteMermaid(w, path)
+	}
+	return g.WriteDOT(w, path)
+}
+
+// highlight returns the nodes and edges of a path, edges keyed by "from to"
+func highlight(path []string) (nodes, edges map[string]bool) {
+	nodes, edges = map[string]bool{}, map[string]bool{}
+	for i, id := range path {
+		nodes[id] = true
+		if i > 0 {
+			edges[path[i-1]+" "+id] = true
+		}
+	}
+	return nodes, edges
+}
+
+// byModule groups the sorted node IDs by module path, root module first
+func (g *Graph) byModule() ([]string, map[string][]string) {
+	groups := map[string][]string{}
+	for _, id := range g.IDs() {
+		m := g.Nodes[id].Module
+		groups[m] = append(groups[m], id)
+	}
+	modules := make([]string, 0, len(groups))
+	for m := range groups {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	return modules, groups
+}
+
+// label is the node ID relative to its module
+func (g *Graph) label(id string) string {
+	n := g.Nodes[id]
+	if n.Kind == Module || n.Module == "" {
+		return id
+	}
+	return strings.TrimPrefix(id, n.Module+".")
+}
+
+// WriteDOT writes the graph in Graphviz DOT, with a cluster per module
+func (g *Graph) WriteDOT(w io.Writer, path []string) error {
+	critical, criticalEdges := highlight(path)
+	var b strings.Builder
+	b.WriteString("digraph terraform {\n  rankdir = \"RL\";\n  node [shape = box, fontname = \"Helvetica\"];\n")
+
+	modules, groups := g.byModule()
+	for _, m := range modules {
+		indent := "  "
+		if m != "" {
+			fmt.Fprintf(&b, "  subgraph %s {\n    label = %s;\n", strconv.Quote("cluster_"+m), strconv.Quote(m))
+			indent = "    "
+		}
+		for _, id := range groups[m] {
+			var attrs []string
+			attrs = append(attrs, "label = "+strconv.Quote(g.label(id)))
+			switch g.Nodes[id].Kind {
+			case Data:
+				attrs = append(attrs, "shape = ellipse")
+			case Module:
+				attrs = append(attrs, "shape = box3d")
+			}
+			if critical[id] {
+				attrs = append(attrs, "color = red", "penwidth = 2")
+			}
+			fmt.Fprintf(&b, "%s%s [%s];\n", indent, strconv.Quote(id), strings.Join(attrs, ", "))
+		}
+		if m != "" {
+			b.WriteString("  }\n")
+		}
+	}
+
+	for _, from := range g.IDs() {
+		for _, to := range g.DependsOn(from) {
+			attrs := ""
+			if criticalEdges[from+" "+to] {
+				attrs = " [color = red, penwidth = 2]"
+			}
+			fmt.Fprintf(&b, "  %s -> %s%s;\n", strconv.Quote(from), strconv.Quote(to), attrs)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart, with a subgraph per
+// module
+func (g *Graph) WriteMermaid(w io.Writer, path []string) error {
+	critical, criticalEdges := highlight(path)
+	ids := map[string]string{}
+	for i, id := range g.IDs() {
+		ids[id] = "n" + strconv.Itoa(i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart RL\n")
+	modules, groups := g.byModule()
+	for i, m := range modules {
+		indent := "  "
+		if m != "" {
+			fmt.Fprintf(&b, "  subgraph m%d [\"%s\"]\n", i, mermaidText(m))
+			indent = "    "
+		}
+		for _, id := range groups[m] {
+			text := mermaidText(g.label(id))
+			switch g.Nodes[id].Kind {
+			case Data:
+				fmt.Fprintf(&b, "%s%s([\"%s\"])\n", indent, ids[id], text)
+			case Module:
+				fmt.Fprintf(&b, "%s%s[[\"%s\"]]\n", indent, ids[id], text)
+			default:
+				fmt.Fprintf(&b, "%s%s[\"%s\"]\n", indent, ids[id], text)
+			}
+		}
+		if m != "" {
+			b.WriteString("  end\n")
+		}
+	}
+
+	var links []string
+	edge := 0
+	for _, from := range g.IDs() {
+		for _, to := range g.DependsOn(from) {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[from], ids[to])
+			if criticalEdges[from+" "+to] {
+				links = append(links, strconv.Itoa(edge))
+			}
+			edge++
+		}
+	}
+
+	if len(path) > 0 {
+		var nodes []string
+		for _, id := range g.IDs() {
+			if critical[id] {
+				nodes = append(nodes, ids[id])
+			}
+		}
+		b.WriteString("  classDef critical stroke:#d00,stroke-width:3px\n")
+		fmt.Fprintf(&b, "  class %s critical\n", strings.Join(nodes, ","))
+		if len(links) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:#d00,stroke-width:3px\n", strings.Join(links, ","))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidText escapes the quotes in a quoted Mermaid label
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,167 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"sort"
+	"strings"
+)
+
+// inModule reports whether a module path is the given module or one of its
+// children
+func inModule(path, module string) bool {
+	return path == module || strings.HasPrefix(path, module+".")
+}
+
+// ExternalModules returns the outermost module calls whose source is not a
+// local path, like module.eks
+func (g *Graph) ExternalModules() []string {
+	var external []string
+	for path, source := range g.Sources {
+		if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+			continue
+		}
+		external = append(external, path)
+	}
+	sort.Strings(external)
+
+	var outermost []string
+	for _, path := range external {
+		if len(outermost) == 0 || !inModule(path, outermost[len(outermost)-1]) {
+			outermost = append(outermost, path)
+		}
+	}
+	return outermost
+}
+
+// Collapse returns a graph where the nodes of each of the modules, including
+// their child modules, are merged into a single Module node
+func (g *Graph) Collapse(modules ...string) *Graph {
+	rename := map[string]string{}
+	for _, id := range g.IDs() {
+		rename[id] = id
+		for _, m := range modules {
+			if inModule(g.Nodes[id].Module, m) {
+				rename[id] = m
+				break
+			}
+		}
+	}
+
+	c := newGraph()
+	for path, source := range g.Sources {
+		c.Sources[path] = source
+	}
+	for _, id := range g.IDs() {
+		n := *g.Nodes[id]
+		if to := rename[id]; to != id {
+			n = Node{ID: to, Kind: Module, Module: parentModule(to)}
+		}
+		c.addNode(&n)
+	}
+	for from, deps := range g.Edges {
+		for to := range deps {
+			c.addEdge(rename[from], rename[to])
+		}
+	}
+	return c
+}
+
+// parentModule returns the module path a module call is made in
+func parentModule(path string) string {
+	if i := strings.LastIndex(path, ".module."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// Filter returns a graph with the nodes in the modules and the nodes they
+// directly depend on or are depended on by. The root module is selected with
+// an empty module path.
+func (g *Graph) Filter(modules ...string) *Graph {
+	selected := map[string]bool{}
+	for _, id := range g.IDs() {
+		for _, m := range modules {
+			n := g.Nodes[id]
+			if (m == "" && n.Module == "") || (m != "" && (inModule(n.Module, m) || (n.Kind == Module && inModule(n.ID, m)))) {
+				selected[id] = true
+			}
+		}
+	}
+
+	keep := map[string]bool{}
+	for from, deps := range g.Edges {
+		for to := range deps {
+			if selected[from] || selected[to] {
+				keep[from], keep[to] = true, true
+			}
+		}
+	}
+	for id := range selected {
+		keep[id] = true
+	}
+
+	f := newGraph()
+	for path, source := range g.Sources {
+		f.Sources[path] = source
+	}
+	for id := range keep {
+		f.addNode(g.Nodes[id])
+	}
+	for from, deps := range g.Edges {
+		for to := range deps {
+			// Only edges of the selected nodes, so the neighbors do not pull
+			// in their own dependencies among each other
+			if keep[from] && keep[to] && (selected[from] || selected[to]) {
+				f.addEdge(from, to)
+			}
+		}
+	}
+	return f
+}
+
+// CriticalPath returns the longest chain of dependencies, starting at the
+// node that is created last. Ties are broken by node ID. Edges that close a
+// cycle, which collapsing modules can introduce, are ignored.
+func (g *Graph) CriticalPath() []string {
+	length := map[string]int{}
+	next := map[string]string{}
+	const visiting = -1
+
+	var visit func(id string) int
+	visit = func(id string) int {
+		if l, ok := length[id]; ok {
+			if l == visiting {
+				return 0
+			}
+			return l
+		}
+		length[id] = visiting
+		best := 1
+		for _, to := range g.DependsOn(id) {
+			if l := visit(to) + 1; l > best {
+				best = l
+				next[id] = to
+			}
+		}
+		length[id] = best
+		return best
+	}
+
+	start, longest := "", 0
+	for _, id := range g.IDs() {
+		if l := visit(id); l > longest {
+			start, longest = id, l
+		}
+	}
+	if start == "" {
+		return nil
+	}
+
+	path := []string{start}
+	for id, ok := next[start]; ok; id, ok = next[id] {
+		path = append(path, id)
+	}
+	return path
+}
@@ -0,0 +1,180 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package guard classifies the deletes and replacements in the resource
+// changes of a plan by what they destroy, so a change to an input such as
+// postgres_servers.*.server_version, nfs_raid_disk_size, storage_type_backend
+// or aws_fsx_ontap_deployment_type can not silently drop data.
+//
+// Data-destroying changes fail the check unless an allow-list entry covers
+// their address.
+package guard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Class of a destructive change
+type Class string
+
+const (
+	Stateless           Class = "stateless"
+	StatefulRecoverable Class = "stateful-recoverable"
+	DataDestroying      Class = "data-destroying"
+)
+
+// Action is the destructive part of a resource change
+type Action string
+
+const (
+	Delete  Action = "delete"
+	Replace Action = "replace"
+)
+
+// Attribute is an attribute that forced a replacement
+type Attribute struct {
+	Path   string `json:"path"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func (a Attribute) String() string {
+	if a.Before == "" && a.After == "" {
+		return a.Path
+	}
+	return fmt.Sprintf("%s: %s -> %s", a.Path, a.Before, a.After)
+}
+
+// Change is a delete or replace of a resource
+type Change struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Action  Action `json:"action"`
+	Class   Class  `json:"class"`
+	// Note explains what is lost, and what is kept of it
+	Note string `json:"note,omitempty"`
+	// Attributes forced the replacement
+	Attributes []Attribute `json:"attributes,omitempty"`
+	// Allowed is the allow-list entry that covers a data-destroying change
+	Allowed string `json:"allowed,omitempty"`
+}
+
+// Blocking reports whether the change destroys data without an allow-list
+// entry
+func (c Change) Blocking() bool {
+	return c.Class == DataDestroying && c.Allowed == ""
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s: %s (%s)", c.Address, c.Action, c.Class)
+	if c.Note != "" {
+		s += ", " + c.Note
+	}
+	if len(c.Attributes) > 0 {
+		attrs := make([]string, len(c.Attributes))
+		for i, a := range c.Attributes {
+			attrs[i] = a.String()
+		}
+		s += ", forced by " + strings.Join(attrs, "; ")
+	}
+	return s
+}
+
+// Result lists the destructive changes of a plan
+type Result struct {
+	Changes []Change `json:"changes"`
+	// UnusedAllows are the allow-list entries that covered no data-destroying
+	// change
+	UnusedAllows []string `json:"unusedAllows,omitempty"`
+}
+
+// Failed reports whether a data-destroying change is not allowed
+func (r *Result) Failed() bool {
+	for _, c := range r.Changes {
+		if c.Blocking() {
+			return true
+		}
+	}
+	return false
+}
+
+// Check classifies every delete and replace in the resource changes of the
+// plan. Allow entries are resource addresses where "*" matches any characters.
+func Check(plan *terraform.PlanStruct, allow ...string) *Result {
+	result := &Result{Changes: []Change{}}
+	used := make([]bool, len(allow))
+	for _, rc := range plan.RawPlan.ResourceChanges {
+		if rc.Change == nil || rc.Mode == tfjson.DataResourceMode {
+			continue
+		}
+		c, ok := classify(rc)
+		if !ok {
+			continue
+		}
+		if c.Class == DataDestroying {
+			for i, a := range allow {
+				if planfile.MatchAddress(a, c.Address) {
+					c.Allowed = a
+					used[i] = true
+					break
+				}
+			}
+		}
+		result.Changes = append(result.Changes, c)
+	}
+	for i, a := range allow {
+		if !used[i] {
+			result.UnusedAllows = append(result.UnusedAllows, a)
+		}
+	}
+	sort.SliceStable(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Address < result.Changes[j].Address
+	})
+	return result
+}
+
+func classify(rc *tfjson.ResourceChange) (Change, bool) {
+	actions := rc.Change.Actions
+	c := Change{Address: rc.Address, Type: rc.Type, Class: Stateless}
+	switch {
+	case actions.Replace():
+		c.Action = Replace
+	case actions.Delete():
+		c.Action = Delete
+	default:
+		return c, false
+	}
+	if s, ok := StatefulTypes[rc.Type]; ok {
+		c.Class, c.Note = s.Class, "loses "+s.Loses
+		if s.Fallback != nil {
+			before, _ := rc.Change.Before.(map[string]interface{})
+			if fallback := s.Fallback(before); fallback != "" {
+				c.Note += ", " + fallback
+			}
+		}
+	}
+	if c.Action == Delete && c.Note == "" {
+		c.Note = "no longer in the configuration"
+	}
+	if rc.DeposedKey != "" {
+		c.Note = strings.TrimPrefix(c.Note+", deposed object "+rc.DeposedKey, ", ")
+	}
+	for _, p := range rc.Change.ReplacePaths {
+		path, _ := p.([]interface{})
+		c.Attributes = append(c.Attributes, attribute(rc.Change, path))
+	}
+	return c, true
+}
+
+// attribute formats a replace path and the values before and after the change
+func attribute(change *tfjson.Change, path []interface{}) Attribute {
+	before, after := planfile.ChangeValues(change, path)
+	return Attribute{Path: planfile.FormatPath(path), Before: before, After: after}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package guard
+
+import (
+	"testing"
+
+	"test/planfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	postgres = `module.postgresql["default"].module.db_instance.aws_db_instance.this[0]`
+	other    = `module.postgresql["other"].module.db_instance.aws_db_instance.this[0]`
+	raidDisk = "module.nfs[0].aws_ebs_volume.raid_disk[0]"
+	efs      = "aws_efs_file_system.efs-fs[0]"
+)
+
+func testResult(t *testing.T, allow ...string) *Result {
+	plan, err := planfile.Load("testdata/plan.json")
+	require.NoError(t, err)
+	return Check(plan, allow...)
+}
+
+func changes(r *Result) map[string]Change {
+	m := map[string]Change{}
+	for _, c := range r.Changes {
+		m[c.Address] = c
+	}
+	return m
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	result := testResult(t)
+	assert.True(t, result.Failed())
+
+	classes := map[string]Class{}
+	for _, c := range result.Changes {
+		classes[c.Address] = c.Class
+	}
+	assert.Equal(t, map[string]Class{
+		postgres:                         DataDestroying,
+		other:                            DataDestroying,
+		raidDisk:                         DataDestroying,
+		efs:                              DataDestroying,
+		"module.jump[0].aws_instance.vm": StatefulRecoverable,
+		"aws_vpc_security_group_ingress_rule.vms[0]":                                  Stateless,
+		`module.eks.module.eks_managed_node_group["cas"].aws_launch_template.this[0]`: Stateless,
+	}, classes, "creates, updates and data source reads are not listed")
+
+	byAddress := changes(result)
+	assert.Equal(t, Delete, byAddress[efs].Action)
+	assert.Equal(t, Replace, byAddress[other].Action, "create before destroy is a replace")
+	assert.Contains(t, byAddress[postgres].Note, "a final snapshot is taken", "a snapshot is noted, the replace still blocks")
+	assert.Empty(t, byAddress[postgres].Allowed)
+}
+
+func TestCheckAttributes(t *testing.T) {
+	t.Parallel()
+
+	byAddress := changes(testResult(t))
+	assert.Equal(t, []Attribute{
+		{Path: "engine_version", Before: `"15"`, After: `"16"`},
+		{Path: "password", Before: "(sensitive)", After: "(sensitive)"},
+	}, byAddress[postgres].Attributes)
+	assert.Equal(t, []Attribute{
+		{Path: "availability_zone", Before: `"us-east-1a"`, After: "(known after apply)"},
+	}, byAddress[raidDisk].Attributes)
+	assert.Equal(t, "ebs_block_device[0].volume_size: 64 -> 128", byAddress["module.jump[0].aws_instance.vm"].Attributes[0].String())
+	assert.Empty(t, byAddress[efs].Attributes)
+}
+
+func TestCheckAllow(t *testing.T) {
+	t.Parallel()
+
+	result := testResult(t, "module.postgresql[*", "module.nfs[0].aws_ebs_volume.raid_disk[*]", "aws_fsx_*")
+	assert.True(t, result.Failed(), "the EFS delete is not allowed")
+	assert.Equal(t, []string{"aws_fsx_*"}, result.UnusedAllows)
+
+	byAddress := changes(result)
+	assert.Equal(t, "module.postgresql[*", byAddress[other].Allowed)
+	assert.Equal(t, "module.postgresql[*", byAddress[postgres].Allowed, "the engine_version replace needs the allow-list despite its final snapshot")
+	assert.Empty(t, byAddress["module.jump[0].aws_instance.vm"].Allowed, "only data-destroying changes use the allow-list")
+
+	result = testResult(t, "module.postgresql[*", "module.nfs[0].aws_ebs_volume.raid_disk[*]", efs)
+	assert.False(t, result.Failed())
+}
@@ -0,0 +1,36 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package guard
+
+import (
+	"strings"
+
+	"test/report"
+)
+
+// Table renders the destructive changes with the attributes that forced them
+func (r *Result) Table() *report.Table {
+	t := &report.Table{
+		Title: "Deletes and replacements",
+		Columns: []report.Column{
+			{Header: "Address"},
+			{Header: "Action"},
+			{Header: "Class"},
+			{Header: "Forced by"},
+			{Header: "Note"},
+		},
+	}
+	for _, c := range r.Changes {
+		attrs := make([]string, len(c.Attributes))
+		for i, a := range c.Attributes {
+			attrs[i] = a.String()
+		}
+		class := string(c.Class)
+		if c.Allowed != "" {
+			class += " (allowed)"
+		}
+		t.AddRow(c.Address, string(c.Action), class, strings.Join(attrs, "; "), c.Note)
+	}
+	return t
+}
@@ -0,0 +1,54 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package guard
+
+import "test/planfile"
+
+// Stateful describes what is lost when a resource of a type is deleted
+type Stateful struct {
+	Class Class
+	// Loses names the state that goes away with the resource
+	Loses string
+	// Fallback returns what is kept of the state, like a final snapshot,
+	// given the values before the change, or "" when nothing is. It is only
+	// noted: restoring from it is manual and loses later writes, so the change
+	// stays in its class.
+	Fallback func(before planfile.Attributes) string
+}
+
+// StatefulTypes lists the resource types of this configuration that hold
+// state. Every other type is stateless and is recreated without loss.
+var StatefulTypes = map[string]Stateful{
+	"aws_db_instance": {
+		Class: DataDestroying,
+		Loses: "the PostgreSQL databases",
+		Fallback: func(before planfile.Attributes) string {
+			if before.Bool("skip_final_snapshot") {
+				return ""
+			}
+			return "a final snapshot is taken, restoring it is manual"
+		},
+	},
+	"aws_ebs_volume":                        {Class: DataDestroying, Loses: "the volume data, for the NFS server its RAID disks"},
+	"aws_efs_file_system":                   {Class: DataDestroying, Loses: "the shared file system data"},
+	"aws_fsx_ontap_file_system":             {Class: DataDestroying, Loses: "the ONTAP file system and all of its volumes"},
+	"aws_fsx_ontap_storage_virtual_machine": {Class: DataDestroying, Loses: "the volumes of the storage virtual machine"},
+	"aws_fsx_ontap_volume": {
+		Class: DataDestroying,
+		Loses: "the volume data",
+		Fallback: func(before planfile.Attributes) string {
+			if before.Bool("skip_final_backup") {
+				return ""
+			}
+			return "a final backup is taken, restoring it is manual"
+		},
+	},
+	"aws_cloudwatch_log_group": {Class: DataDestroying, Loses: "the EKS control plane log events"},
+
+	"aws_eks_cluster":   {Class: StatefulRecoverable, Loses: "the Kubernetes objects, which must be redeployed, persistent volumes survive"},
+	"aws_instance":      {Class: StatefulRecoverable, Loses: "the root volume, attached volumes survive"},
+	"aws_kms_key":       {Class: StatefulRecoverable, Loses: "the key, whose deletion can be cancelled during the waiting period"},
+	"aws_eip":           {Class: StatefulRecoverable, Loses: "the public IP address, allow lists that name it must be updated"},
+	"kubernetes_secret": {Class: StatefulRecoverable, Loses: "the service account token, the kubeconfig must be redistributed"},
+}
@@ -0,0 +1,293 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package handoff assembles what the viya4-deployment stage needs from an
+// applied stack into a bundle: a versioned YAML document, the kubeconfig, an
+// SSH config for the jump and NFS servers, and the secrets.
+//
+// The document carries the deployment variables under vars, named like the
+// ansible variables of viya4-deployment. Secrets never appear in it: vars
+// leaves out the variables that would hold them, like the password of a
+// V4_CFG_POSTGRES_SERVERS entry, and secrets maps each file of the secrets
+// directory to the variable it fills. The kubeconfig and secret files are only
+// readable by the owner.
+package handoff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"test/outputs"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Version is the version of the handoff document, bumped on incompatible
+// changes
+const Version = 1
+
+const (
+	// DocumentFile is the name of the handoff document in the bundle
+	DocumentFile = "handoff.yaml"
+	// SSHConfigFile is the name of the SSH config snippet in the bundle
+	SSHConfigFile = "ssh_config"
+	// SecretsDir is the directory of the secret files in the bundle
+	SecretsDir = "secrets"
+)
+
+// Document is the handoff document
+type Document struct {
+	Version int      `json:"version"`
+	Vars    Vars     `json:"vars"`
+	Secrets []Secret `json:"secrets,omitempty"`
+}
+
+// Secret is a secret file of the bundle
+type Secret struct {
+	// File is the path of the secret in the bundle
+	File string `json:"file"`
+	// Variable is the deployment variable the secret fills, as a dotted path
+	// into vars, like V4_CFG_POSTGRES_SERVERS.default.password. It is empty
+	// when no viya4-deployment variable takes the secret.
+	Variable string `json:"variable,omitempty"`
+	// Description says what the secret is for
+	Description string `json:"description"`
+}
+
+// Vars are the deployment variables
+type Vars struct {
+	Provider    string `json:"PROVIDER"`
+	ClusterName string `json:"CLUSTER_NAME"`
+	// Kubeconfig is the path of the kubeconfig in the bundle
+	Kubeconfig          string `json:"KUBECONFIG"`
+	ClusterNodePoolMode string `json:"V4_CFG_CLUSTER_NODE_POOL_MODE"`
+	CRURL               string `json:"V4_CFG_CR_URL,omitempty"`
+
+	StorageType          string `json:"STORAGE_TYPE"`
+	StorageTypeBackend   string `json:"STORAGE_TYPE_BACKEND"`
+	RWXFilestoreEndpoint string `json:"V4_CFG_RWX_FILESTORE_ENDPOINT,omitempty"`
+	RWXFilestorePath     string `json:"V4_CFG_RWX_FILESTORE_PATH,omitempty"`
+
+	JumpHost             string `json:"JUMP_SVR_HOST,omitempty"`
+	JumpUser             string `json:"JUMP_SVR_USER,omitempty"`
+	JumpPrivateKey       string `json:"JUMP_SVR_PRIVATE_KEY,omitempty"`
+	JumpRWXFilestorePath string `json:"JUMP_SVR_RWX_FILESTORE_PATH,omitempty"`
+
+	PostgresServers map[string]PostgresServer `json:"V4_CFG_POSTGRES_SERVERS,omitempty"`
+}
+
+// PostgresServer is an external PostgreSQL server of V4_CFG_POSTGRES_SERVERS,
+// without its password
+type PostgresServer struct {
+	Internal              bool   `json:"internal"`
+	FQDN                  string `json:"fqdn"`
+	Port                  int    `json:"server_port"`
+	Admin                 string `json:"admin"`
+	SSLEnforcementEnabled bool   `json:"ssl_enforcement_enabled"`
+}
+
+// File is a file of the bundle
+type File struct {
+	Data []byte
+	Mode os.FileMode
+}
+
+// Bundle is the handoff document and the files it references, keyed by their
+// slash separated path in the bundle
+type Bundle struct {
+	Document Document
+	Files    map[string]File
+}
+
+// Options are the settings that do not come from the outputs
+type Options struct {
+	// SSHPrivateKey is the private key of the ssh_public_key input, used to
+	// log in to the jump and NFS servers
+	SSHPrivateKey string
+}
+
+// Build assembles the bundle of validated outputs
+func Build(o *outputs.Outputs, opts Options) (*Bundle, error) {
+	if problems := o.Validate(); len(problems) > 0 {
+		messages := make([]string, len(problems))
+		for i, p := range problems {
+			messages[i] = p.String()
+		}
+		return nil, fmt.Errorf("invalid outputs:\n  %s", strings.Join(messages, "\n  "))
+	}
+	if o.KubeConfig == "" {
+		return nil, fmt.Errorf("kube_config is not set, the bundle needs the outputs of `terraform output -json` or the state, which include sensitive values")
+	}
+
+	b := &Bundle{Files: map[string]File{}}
+	kubeconfig := o.ClusterName + "-kubeconfig.conf"
+	b.Files[kubeconfig] = File{Data: []byte(o.KubeConfig), Mode: 0o600}
+
+	vars := Vars{
+		Provider:             o.Provider,
+		ClusterName:          o.ClusterName,
+		Kubeconfig:           kubeconfig,
+		ClusterNodePoolMode:  o.ClusterNodePoolMode,
+		CRURL:                o.CREndpoint,
+		StorageType:          o.StorageType(),
+		StorageTypeBackend:   o.StorageTypeBackend,
+		RWXFilestoreEndpoint: o.RWXFilestoreEndpoint,
+		RWXFilestorePath:     o.RWXFilestorePath,
+	}
+	var secrets []Secret
+	if o.AWSFSxONTAPFSxadminPassword != "" {
+		secrets = append(secrets, Secret{
+			File:        b.secret("fsxadmin-password", o.AWSFSxONTAPFSxadminPassword),
+			Description: "password of the fsxadmin user of the FSx for NetApp ONTAP file system",
+		})
+	}
+
+	if host := jumpHost(o); host != "" {
+		vars.JumpHost = host
+		vars.JumpUser = o.JumpAdminUsername
+		vars.JumpPrivateKey = opts.SSHPrivateKey
+		vars.JumpRWXFilestorePath = o.JumpRWXFilestorePath
+	}
+	if config := sshConfig(o, opts); config != "" {
+		b.Files[SSHConfigFile] = File{Data: []byte(config), Mode: 0o644}
+	}
+
+	if len(o.PostgresServers) > 0 {
+		vars.PostgresServers = map[string]PostgresServer{}
+		names := make([]string, 0, len(o.PostgresServers))
+		for name := range o.PostgresServers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			s := o.PostgresServers[name]
+			vars.PostgresServers[name] = PostgresServer{
+				Internal:              s.Internal,
+				FQDN:                  s.FQDN,
+				Port:                  s.ServerPort,
+				Admin:                 s.Admin,
+				SSLEnforcementEnabled: s.SSLEnforcementEnabled,
+			}
+			secrets = append(secrets, Secret{
+				File:        b.secret("postgres-"+name+"-password", s.Password),
+				Variable:    "V4_CFG_POSTGRES_SERVERS." + name + ".password",
+				Description: "password of the " + s.Admin + " user of the " + name + " PostgreSQL server",
+			})
+		}
+	}
+
+	b.Document = Document{Version: Version, Vars: vars, Secrets: secrets}
+	data, err := yaml.Marshal(b.Document)
+	if err != nil {
+		return nil, err
+	}
+	b.Files[DocumentFile] = File{Data: data, Mode: 0o644}
+	return b, nil
+}
+
+// secret adds a secret file and returns its path in the bundle
+func (b *Bundle) secret(name, value string) string {
+	path := SecretsDir + "/" + name
+	b.Files[path] = File{Data: []byte(value), Mode: 0o600}
+	return path
+}
+
+// jumpHost returns the address to reach the jump server at, its public IP
+// when it has one, empty without a jump server
+func jumpHost(o *outputs.Outputs) string {
+	if o.JumpPublicIP != "" {
+		return o.JumpPublicIP
+	}
+	return o.JumpPrivateIP
+}
+
+// sshConfig returns the SSH config for the jump server and the NFS server,
+// which is reached through the jump server when it has no public IP
+func sshConfig(o *outputs.Outputs, opts Options) string {
+	var sb strings.Builder
+	host := func(name, address, user, proxy string) {
+		fmt.Fprintf(&sb, "Host %s\n", name)
+		fmt.Fprintf(&sb, "  HostName %s\n", address)
+		fmt.Fprintf(&sb, "  User %s\n", user)
+		if opts.SSHPrivateKey != "" {
+			fmt.Fprintf(&sb, "  IdentityFile %s\n", opts.SSHPrivateKey)
+			sb.WriteString("  IdentitiesOnly yes\n")
+		}
+		if proxy != "" {
+			fmt.Fprintf(&sb, "  ProxyJump %s\n", proxy)
+		}
+	}
+
+	jump := ""
+	if address := jumpHost(o); address != "" {
+		jump = o.Prefix + "-jump"
+		host(jump, address, o.JumpAdminUsername, "")
+	}
+	if o.NFSPrivateIP != "" {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		if o.NFSPublicIP != "" {
+			host(o.Prefix+"-nfs", o.NFSPublicIP, o.NFSAdminUsername, "")
+		} else {
+			host(o.Prefix+"-nfs", o.NFSPrivateIP, o.NFSAdminUsername, jump)
+		}
+	}
+	return sb.String()
+}
+
+// Paths returns the paths of the files in the bundle, sorted
+func (b *Bundle) Paths() []string {
+	paths := make([]string, 0, len(b.Files))
+	for path := range b.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write writes the bundle to a directory, creating it and its subdirectories
+// for the owner only when needed. Files replace existing ones, so they have
+// their mode even when an older file was readable by others.
+func (b *Bundle) Write(dir string) error {
+	for _, path := range b.Paths() {
+		f := b.Files[path]
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+		if err := writeFile(target, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes a file through a temporary file that only the owner can
+// read, renamed over the target, so the data is never in a file with wider
+// permissions
+func writeFile(target string, f File) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return err
+	}
+	// CreateTemp creates the file with mode 0600
+	_, err = tmp.Write(f.Data)
+	if err == nil {
+		err = tmp.Chmod(f.Mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), target)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handoff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"test/outputs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func load(t *testing.T, fixture string) *outputs.Outputs {
+	o, err := outputs.Load("../outputs/testdata/" + fixture + ".json")
+	require.NoError(t, err)
+	return o
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		paths   []string
+		secrets []string
+	}{
+		"standard-nfs": {
+			paths: []string{"handoff.yaml", "ssh_config", "standard-nfs-eks-kubeconfig.conf"},
+		},
+		"ha-efs": {
+			paths:   []string{"ha-efs-eks-kubeconfig.conf", "handoff.yaml", "secrets/postgres-default-password", "ssh_config"},
+			secrets: []string{"my$up3rS3cretPassw0rd"},
+		},
+		"ha-ontap": {
+			paths:   []string{"ha-ontap-eks-kubeconfig.conf", "handoff.yaml", "secrets/fsxadmin-password"},
+			secrets: []string{"v3RyS3cretPa$$w0rd"},
+		},
+		"none": {
+			paths: []string{"handoff.yaml", "none-eks-kubeconfig.conf"},
+		},
+	}
+	for fixture, tc := range tests {
+		t.Run(fixture, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := Build(load(t, fixture), Options{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.paths, b.Paths())
+			assert.Len(t, b.Document.Secrets, len(tc.secrets))
+
+			document := string(b.Files[DocumentFile].Data)
+			for _, secret := range append(tc.secrets, "apiVersion: v1") {
+				assert.NotContains(t, document, secret, "secrets stay out of the document")
+			}
+
+			var decoded Document
+			require.NoError(t, yaml.Unmarshal(b.Files[DocumentFile].Data, &decoded))
+			assert.Equal(t, b.Document, decoded)
+			assert.Equal(t, Version, decoded.Version)
+		})
+	}
+}
+
+func TestBuildVars(t *testing.T) {
+	t.Parallel()
+
+	b, err := Build(load(t, "ha-efs"), Options{SSHPrivateKey: "~/.ssh/viya"})
+	require.NoError(t, err)
+	assert.Equal(t, Vars{
+		Provider:             "aws",
+		ClusterName:          "ha-efs-eks",
+		Kubeconfig:           "ha-efs-eks-kubeconfig.conf",
+		ClusterNodePoolMode:  "standard",
+		CRURL:                "https://123456789012.dkr.ecr.us-east-1.amazonaws.com",
+		StorageType:          "ha",
+		StorageTypeBackend:   "efs",
+		RWXFilestoreEndpoint: "fs-0123456789abcdef0.efs.us-east-1.amazonaws.com",
+		RWXFilestorePath:     "/",
+		JumpHost:             "3.210.1.3",
+		JumpUser:             "jumpuser",
+		JumpPrivateKey:       "~/.ssh/viya",
+		JumpRWXFilestorePath: "/viya-share",
+		PostgresServers: map[string]PostgresServer{
+			"default": {
+				FQDN:                  "ha-efs-default-pgsql.abcdefghij.us-east-1.rds.amazonaws.com",
+				Port:                  5432,
+				Admin:                 "pgadmin",
+				SSLEnforcementEnabled: true,
+			},
+		},
+	}, b.Document.Vars)
+	assert.Equal(t, []Secret{{
+		File:        "secrets/postgres-default-password",
+		Variable:    "V4_CFG_POSTGRES_SERVERS.default.password",
+		Description: "password of the pgadmin user of the default PostgreSQL server",
+	}}, b.Document.Secrets)
+	assert.Equal(t, "my$up3rS3cretPassw0rd", string(b.Files["secrets/postgres-default-password"].Data))
+}
+
+func TestSSHConfig(t *testing.T) {
+	t.Parallel()
+
+	o := load(t, "standard-nfs")
+	o.JumpPublicIP = ""
+	b, err := Build(o, Options{SSHPrivateKey: "~/.ssh/viya"})
+	require.NoError(t, err)
+	assert.Equal(t, `Host standard-nfs-jump
+  HostName 192.168.129.10
+  User jumpuser
+  IdentityFile ~/.ssh/viya
+  IdentitiesOnly yes
+
+Host standard-nfs-nfs
+  HostName 192.168.129.20
+  User nfsuser
+  IdentityFile ~/.ssh/viya
+  IdentitiesOnly yes
+  ProxyJump standard-nfs-jump
+`, string(b.Files[SSHConfigFile].Data))
+	assert.Equal(t, "192.168.129.10", b.Document.Vars.JumpHost, "private clusters use the private IP")
+}
+
+func TestBuildErrors(t *testing.T) {
+	t.Parallel()
+
+	o := load(t, "ha-efs")
+	o.EFSARN = ""
+	_, err := Build(o, Options{})
+	assert.EqualError(t, err, "invalid outputs:\n  efs_arn: required, but not set")
+
+	o = load(t, "none")
+	o.KubeConfig = ""
+	_, err = Build(o, Options{})
+	assert.ErrorContains(t, err, "kube_config is not set")
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	b, err := Build(load(t, "ha-ontap"), Options{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	// An existing secret keeps no wider permissions
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, SecretsDir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, SecretsDir, "fsxadmin-password"), []byte("old"), 0o644))
+	require.NoError(t, b.Write(dir))
+
+	modes := map[string]os.FileMode{
+		"ha-ontap-eks-kubeconfig.conf": 0o600,
+		"handoff.yaml":                 0o644,
+		"secrets/fsxadmin-password":    0o600,
+	}
+	for path, mode := range modes {
+		info, err := os.Stat(filepath.Join(dir, path))
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode().Perm(), path)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "secrets/fsxadmin-password"))
+	require.NoError(t, err)
+	assert.Equal(t, "v3RyS3cretPa$$w0rd", string(data))
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(dir, SecretsDir))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "fsxadmin-password", entries[0].Name())
+}
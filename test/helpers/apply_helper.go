@@ -37,10 +37,11 @@ func InitPlanAndApply(t *testing.T, overrides map[string]interface{}) (*terrafor
 	options := &terraform.Options{
 		TerraformDir: test_structure.CopyTerraformFolderToTemp(t, "../../", ""),
 		Vars:         variables,
-		PlanFilePath: filepath.Join(os.TempDir(), "testplan-"+variables["prefix"].(string)+".tfplan"),
+		PlanFilePath: filepath.Join(os.TempDir(), "testplan-"+planKey(t, variables)+".tfplan"),
 		NoColor:      true,
 	}
 
+	BuildBuildInfo(t, options.TerraformDir)
 	plan := terraform.InitAndPlanAndShowWithStruct(t, options)
 
 	terraform.Apply(t, options)
@@ -0,0 +1,23 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// BuildInfoProgram is the program of the buildinfo external data source of
+// main.tf, relative to the Terraform directory
+const BuildInfoProgram = "files/tools/iac-buildinfo"
+
+// BuildBuildInfo builds cmd/buildinfo into a copy of the Terraform directory,
+// the buildinfo data source fails the plan without it
+func BuildBuildInfo(t *testing.T, terraformDir string) {
+	out, err := exec.Command("go", "build", "-o", filepath.Join(terraformDir, BuildInfoProgram), "test/cmd/buildinfo").CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -0,0 +1,83 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"test/cloudinit"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// planString returns a string variable of the plan
+func planString(plan *terraform.PlanStruct, name string) string {
+	variable, ok := plan.RawPlan.Variables[name]
+	if !ok {
+		return ""
+	}
+	value, _ := variable.Value.(string)
+	return value
+}
+
+// planStrings returns a list of a map(list(string)) variable of the plan
+func planStrings(plan *terraform.PlanStruct, name, key string) []string {
+	variable, ok := plan.RawPlan.Variables[name]
+	if !ok {
+		return nil
+	}
+	values, _ := variable.Value.(map[string]interface{})
+	items, _ := values[key].([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprint(item))
+	}
+	return list
+}
+
+// AssertCloudInit renders the cloud-config templates of the VMs the plan
+// creates with the values of the plan and fails the test when the lint finds
+// errors, such as the NFS template waiting for another number of disks than
+// the plan attaches
+func AssertCloudInit(t *testing.T, plan *terraform.PlanStruct) {
+	storageType := planString(plan, "storage_type")
+	backend := cloudinit.Backend(storageType, planString(plan, "storage_type_backend"))
+
+	if _, ok := plan.ResourcePlannedValuesMap["module.jump[0].aws_instance.vm"]; ok {
+		vars := cloudinit.JumpVars{
+			VMAdmin:              planString(plan, "jump_vm_admin"),
+			StorageType:          storageType,
+			RWXFilestoreEndpoint: cloudinit.UnknownEndpoint,
+			RWXFilestorePath:     cloudinit.FilestorePath(backend),
+			JumpRWXFilestorePath: planString(plan, "jump_rwx_filestore_path"),
+		}
+		data, err := cloudinit.Render(filepath.Join("..", "..", cloudinit.JumpTemplate), vars.Map())
+		require.NoError(t, err)
+		result := cloudinit.LintJump(data, vars)
+		assert.True(t, result.Passed(), "%s: %v", result.Template, result.Findings)
+	}
+
+	if _, ok := plan.ResourcePlannedValuesMap["module.nfs[0].aws_instance.vm"]; ok {
+		disks := 0
+		for {
+			if _, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf("module.nfs[0].aws_ebs_volume.raid_disk[%d]", disks)]; !ok {
+				break
+			}
+			disks++
+		}
+		vars := cloudinit.NFSVars{
+			VMAdmin:            planString(plan, "nfs_vm_admin"),
+			PublicSubnetCIDRs:  planStrings(plan, "subnets", "public"),
+			PrivateSubnetCIDRs: planStrings(plan, "subnets", "private"),
+		}
+		data, err := cloudinit.Render(filepath.Join("..", "..", cloudinit.NFSTemplate), vars.Map())
+		require.NoError(t, err)
+		result := cloudinit.LintNFS(data, vars, disks)
+		assert.True(t, result.Passed(), "%s: %v", result.Template, result.Findings)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"testing"
+
+	"test/guard"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// AssertNoDataLoss fails the test for every data-destroying delete or replace
+// in the plan that the allowed addresses do not cover
+func AssertNoDataLoss(t *testing.T, plan *terraform.PlanStruct, allow ...string) *guard.Result {
+	result := guard.Check(plan, allow...)
+	for _, c := range result.Changes {
+		if c.Blocking() {
+			t.Error(c)
+		} else {
+			t.Log(c)
+		}
+	}
+	return result
+}
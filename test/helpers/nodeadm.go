@@ -0,0 +1,83 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"fmt"
+	"testing"
+
+	"test/nodeadm"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nodePoolFlags are the labels and taints a node pool passes to the kubelet
+type nodePoolFlags struct {
+	labels map[string]string
+	taints []string
+}
+
+// planNodePoolFlags returns the labels and taints of every node pool of the
+// plan variables, keyed by node group name
+func planNodePoolFlags(plan *terraform.PlanStruct) map[string]nodePoolFlags {
+	labels := func(v interface{}) map[string]string {
+		m, _ := v.(map[string]interface{})
+		labels := make(map[string]string, len(m))
+		for k, v := range m {
+			labels[k] = fmt.Sprint(v)
+		}
+		return labels
+	}
+	taints := func(v interface{}) []string {
+		items, _ := v.([]interface{})
+		taints := make([]string, len(items))
+		for i, item := range items {
+			taints[i] = fmt.Sprint(item)
+		}
+		return taints
+	}
+	value := func(name string) interface{} {
+		if variable, ok := plan.RawPlan.Variables[name]; ok {
+			return variable.Value
+		}
+		return nil
+	}
+
+	pools := map[string]nodePoolFlags{
+		"default": {labels: labels(value("default_nodepool_labels")), taints: taints(value("default_nodepool_taints"))},
+	}
+	nodePools, _ := value("node_pools").(map[string]interface{})
+	for name, v := range nodePools {
+		np, _ := v.(map[string]interface{})
+		pools[name] = nodePoolFlags{labels: labels(np["node_labels"]), taints: taints(np["node_taints"])}
+	}
+	return pools
+}
+
+// AssertNodeUserData decodes the user data of the launch template of every
+// node pool of the plan and fails the test unless its NodeConfig is valid and
+// passes the labels and taints of the pool to the kubelet
+func AssertNodeUserData(t *testing.T, plan *terraform.PlanStruct) {
+	for name, expected := range planNodePoolFlags(plan) {
+		address := fmt.Sprintf("module.eks.module.eks_managed_node_group[%q].aws_launch_template.this[0]", name)
+		launchTemplate, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "the plan has no %s", address)
+		userData, ok := launchTemplate.AttributeValues["user_data"].(string)
+		require.True(t, ok, "%s has no known user_data", address)
+
+		decoded, err := nodeadm.Decode(userData)
+		require.NoError(t, err, address)
+		configs, err := decoded.NodeConfigs()
+		require.NoError(t, err, address)
+		require.Len(t, configs, 1, "%s user_data NodeConfigs", address)
+		assert.Empty(t, configs[0].Validate(), "%s NodeConfig", address)
+
+		labels, err := configs[0].Labels()
+		require.NoError(t, err, address)
+		assert.Equal(t, expected.labels, labels, "%s node labels", address)
+		assert.ElementsMatch(t, expected.taints, configs[0].Taints(), "%s node taints", address)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"fmt"
+	"testing"
+
+	"test/nodepool"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertNodeTaints fails the test unless the taints EKS puts on the node group
+// of every node pool of the plan are the node_taints of the pool, parsed the
+// way the taints map of locals.tf splits them
+func AssertNodeTaints(t *testing.T, plan *terraform.PlanStruct) {
+	for name, pool := range planNodePoolFlags(plan) {
+		address := fmt.Sprintf("module.eks.module.eks_managed_node_group[%q].aws_eks_node_group.this[0]", name)
+		nodeGroup, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "the plan has no %s", address)
+
+		expected := make([]map[string]string, 0, len(pool.taints))
+		for _, s := range pool.taints {
+			taint, err := nodepool.ParseTaint(s)
+			require.NoError(t, err, address)
+			expected = append(expected, map[string]string{"key": taint.Key, "value": taint.Value, "effect": taint.EKSEffect()})
+		}
+
+		blocks, _ := nodeGroup.AttributeValues["taint"].([]interface{})
+		planned := make([]map[string]string, 0, len(blocks))
+		for _, block := range blocks {
+			m, _ := block.(map[string]interface{})
+			taint := map[string]string{}
+			for _, k := range []string{"key", "value", "effect"} {
+				if v, ok := m[k].(string); ok {
+					taint[k] = v
+				} else {
+					taint[k] = ""
+				}
+			}
+			planned = append(planned, taint)
+		}
+		assert.ElementsMatch(t, expected, planned, "%s taints", address)
+	}
+}
@@ -4,12 +4,17 @@
 package helpers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
+	"test/planfile"
+
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
 	"github.com/stretchr/testify/assert"
@@ -56,8 +61,21 @@ func GetDefaultPlan(t *testing.T) *terraform.PlanStruct {
 	return GetPlanFromCache(t, GetDefaultPlanVars(t))
 }
 
+// planKey identifies the plan of the variables, by prefix and a hash of the
+// variables, since plans with the same prefix may have other inputs
+func planKey(t *testing.T, variables map[string]interface{}) string {
+	prefix, ok := variables["prefix"].(string)
+	if !ok {
+		t.Fatalf("prefix is %v, expected a string", variables["prefix"])
+	}
+	data, err := json.Marshal(variables)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	return prefix + "-" + hex.EncodeToString(sum[:])[:12]
+}
+
 func GetPlanFromCache(t *testing.T, variables map[string]interface{}) *terraform.PlanStruct {
-	return getCache().get(variables["prefix"].(string), func() *terraform.PlanStruct {
+	return getCache().get(planKey(t, variables), func() *terraform.PlanStruct {
 		return GetPlan(t, variables)
 	})
 }
@@ -66,18 +84,25 @@ func GetPlan(t *testing.T, variables map[string]interface{}) *terraform.PlanStru
 	plan, err := InitPlanWithVariables(t, variables)
 	require.NotNil(t, plan)
 	require.NoError(t, err)
+
+	// Keep the plan for the offline tools, e.g. cmd/cost, when asked to
+	if dir := os.Getenv("TEST_PLAN_OUTPUT_DIR"); dir != "" {
+		path := filepath.Join(dir, "plan-"+planKey(t, variables)+".json")
+		require.NoError(t, planfile.Save(path, plan))
+	}
 	return plan
 }
 
 // InitPlanWithVariables returns a *terraform.PlanStruct
 func InitPlanWithVariables(t *testing.T, variables map[string]interface{}) (*terraform.PlanStruct, error) {
 	// Create a temporary plan file
-	planFileName := "testplan-" + variables["prefix"].(string) + ".tfplan"
+	planFileName := "testplan-" + planKey(t, variables) + ".tfplan"
 	planFilePath := filepath.Join(os.TempDir(), planFileName)
 	defer os.Remove(planFilePath)
 
 	// Copy the terraform folder to a temp folder
 	tempTestFolder := test_structure.CopyTerraformFolderToTemp(t, "../../", "")
+	BuildBuildInfo(t, tempTestFolder)
 	// Get the path to the parent folder for clean up
 	tempTestFolderSlice := strings.Split(tempTestFolder, string(os.PathSeparator))
 	tempTestFolderPath := strings.Join(tempTestFolderSlice[:len(tempTestFolderSlice)-1], string(os.PathSeparator))
@@ -0,0 +1,26 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"testing"
+
+	"test/policy"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// AssertPolicies evaluates the built-in policy rules against the plan and
+// fails the test for every error violation the waivers do not cover
+func AssertPolicies(t *testing.T, plan *terraform.PlanStruct, waivers ...policy.Waiver) *policy.Result {
+	result := policy.Evaluate(plan, policy.Builtin(), waivers)
+	for _, v := range result.Violations {
+		if v.Severity == policy.Error && !v.Waived() {
+			t.Error(v)
+		} else {
+			t.Log(v)
+		}
+	}
+	return result
+}
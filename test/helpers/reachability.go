@@ -0,0 +1,37 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"testing"
+
+	"test/reachability"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertReachable fails the test unless the source resource can reach the
+// destination resource on the TCP port
+func AssertReachable(t *testing.T, g *reachability.Graph, source, destination string, port int) {
+	path, err := g.Reach(source, destination, "tcp", port)
+	require.NoError(t, err)
+	assert.NotNil(t, path, "%s can not reach %s on tcp/%d", source, destination, port)
+}
+
+// AssertNotReachable fails the test if the source resource can reach the
+// destination resource on the TCP port
+func AssertNotReachable(t *testing.T, g *reachability.Graph, source, destination string, port int) {
+	path, err := g.Reach(source, destination, "tcp", port)
+	require.NoError(t, err)
+	assert.Nil(t, path, "%s can reach %s on tcp/%d", source, destination, port)
+}
+
+// AssertCIDRs fails the test unless exactly the expected CIDRs can reach the
+// destination resource on the TCP port
+func AssertCIDRs(t *testing.T, g *reachability.Graph, destination string, port int, expected ...string) {
+	cidrs, err := g.CIDRs(destination, "tcp", port)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expected, cidrs, "CIDRs reaching %s on tcp/%d", destination, port)
+}
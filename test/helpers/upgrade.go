@@ -0,0 +1,34 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package helpers
+
+import (
+	"testing"
+
+	"test/upgrade"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertVersionGates fails the test unless the plan has exactly the version
+// gated resources of its kubernetes_version, compared as versions rather than
+// strings
+func AssertVersionGates(t *testing.T, plan *terraform.PlanStruct) {
+	variable, ok := plan.RawPlan.Variables["kubernetes_version"]
+	require.True(t, ok, "the plan has no kubernetes_version variable")
+	value, _ := variable.Value.(string)
+	version, err := upgrade.ParseVersion(value)
+	require.NoError(t, err)
+
+	for _, g := range upgrade.Gates {
+		_, planned := plan.ResourcePlannedValuesMap[g.Address]
+		if version.AtLeast(g.Since) {
+			assert.True(t, planned, "%s %s from Kubernetes %s on, the plan is for %s", g.Address, g.Description, g.Since, version)
+		} else {
+			assert.False(t, planned, "%s %s from Kubernetes %s on, the plan is for %s", g.Address, g.Description, g.Since, version)
+		}
+	}
+}
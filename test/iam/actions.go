@@ -0,0 +1,88 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iam
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+//go:embed actions.json
+var embeddedActions []byte
+
+// Operation is a step of the resource lifecycle terraform calls AWS for
+type Operation string
+
+const (
+	Create Operation = "create"
+	Read   Operation = "read"
+	Update Operation = "update"
+	Delete Operation = "delete"
+)
+
+// Operations lists the lifecycle operations in the order they are reported
+var Operations = []Operation{Create, Read, Update, Delete}
+
+// Lifecycle maps the operations of a resource type to the IAM actions the
+// provider calls for them
+type Lifecycle struct {
+	Create []string `json:"create"`
+	Read   []string `json:"read"`
+	Update []string `json:"update"`
+	Delete []string `json:"delete"`
+}
+
+// Actions returns the IAM actions of an operation
+func (l Lifecycle) Actions(op Operation) []string {
+	switch op {
+	case Create:
+		return l.Create
+	case Read:
+		return l.Read
+	case Update:
+		return l.Update
+	case Delete:
+		return l.Delete
+	}
+	return nil
+}
+
+// Table is a versioned mapping of AWS provider resource types and data sources
+// to IAM actions. A data source mapped to no actions, such as
+// aws_caller_identity, needs no permission.
+type Table struct {
+	Version     string               `json:"version"`
+	Provider    string               `json:"provider"`
+	Resources   map[string]Lifecycle `json:"resources"`
+	DataSources map[string][]string  `json:"dataSources"`
+}
+
+// LoadTable reads a mapping table in the actions.json format
+func LoadTable(r io.Reader) (*Table, error) {
+	var t Table
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("decoding action table: %w", err)
+	}
+	return &t, nil
+}
+
+var (
+	defaultTable *Table
+	defaultOnce  sync.Once
+)
+
+// DefaultTable returns the mapping table embedded in this package
+func DefaultTable() *Table {
+	defaultOnce.Do(func() {
+		var t Table
+		if err := json.Unmarshal(embeddedActions, &t); err != nil {
+			panic(fmt.Sprintf("embedded action table is invalid: %v", err))
+		}
+		defaultTable = &t
+	})
+	return defaultTable
+}
@@ -0,0 +1,42 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iam
+
+// Diff compares the required actions with a policy
+type Diff struct {
+	// Missing lists the required actions the policy does not allow
+	Missing []Required `json:"missing"`
+	// Unneeded lists the action patterns of the policy that match no
+	// required action
+	Unneeded []string `json:"unneeded"`
+	// Unmapped is copied from the requirements, the diff does not cover
+	// these types
+	Unmapped []string `json:"unmapped,omitempty"`
+}
+
+// Compare reports the required actions a policy is missing and the actions it
+// grants without need
+func Compare(req *Requirements, policy *Document) *Diff {
+	d := &Diff{Missing: []Required{}, Unneeded: []string{}, Unmapped: req.Unmapped}
+	grants := policy.Grants()
+	used := map[string]bool{}
+	for _, r := range req.Actions {
+		allowed := false
+		for _, grant := range grants {
+			if Matches(grant, r.Action) {
+				allowed = true
+				used[grant] = true
+			}
+		}
+		if !allowed {
+			d.Missing = append(d.Missing, r)
+		}
+	}
+	for _, grant := range grants {
+		if !used[grant] {
+			d.Unneeded = append(d.Unneeded, grant)
+		}
+	}
+	return d
+}
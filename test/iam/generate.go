@@ -0,0 +1,162 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package iam derives the IAM actions the identity running terraform needs for
+// a plan from an embedded table of resource types and data sources, and
+// compares them to files/policies/devops-iac-eks-policy.json.
+//
+// Managed resources need the actions of their whole lifecycle, since the same
+// identity creates, refreshes, updates and destroys them. Data sources need
+// their read actions. Resource types of providers other than aws call no AWS
+// API and need nothing.
+package iam
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Usage counts the resources of one type in a plan
+type Usage struct {
+	Type      string `json:"type"`
+	Mode      string `json:"mode"`
+	Resources int    `json:"resources"`
+}
+
+// Key returns the type as it is written in configuration, with a data.
+// prefix for data sources
+func (u Usage) Key() string {
+	if u.Mode == string(tfjson.DataResourceMode) {
+		return "data." + u.Type
+	}
+	return u.Type
+}
+
+// Required is an IAM action and the resource types that need it
+type Required struct {
+	Action string   `json:"action"`
+	Types  []string `json:"types"`
+}
+
+// Requirements are the IAM actions a plan needs
+type Requirements struct {
+	TableVersion string     `json:"tableVersion"`
+	Types        []Usage    `json:"types"`
+	Actions      []Required `json:"actions"`
+	// Unmapped lists aws resource types and data sources the table has no
+	// actions for, which makes the requirements incomplete
+	Unmapped []string `json:"unmapped,omitempty"`
+}
+
+// ActionNames returns the required actions
+func (r *Requirements) ActionNames() []string {
+	names := make([]string, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		names = append(names, a.Action)
+	}
+	return names
+}
+
+// Policy returns the minimal policy that allows the required actions
+func (r *Requirements) Policy() *Document {
+	return NewPolicy(r.ActionNames())
+}
+
+// Generate collects the managed resources and data sources of a plan, from
+// the planned values, the resource changes and the data sources read into the
+// prior state, and maps their types to IAM actions
+func Generate(plan *terraform.PlanStruct, table *Table) *Requirements {
+	counts := map[Usage]map[string]bool{}
+	add := func(mode tfjson.ResourceMode, typ, address string) {
+		if !strings.HasPrefix(typ, "aws_") {
+			return
+		}
+		if mode == "" {
+			mode = tfjson.ManagedResourceMode
+		}
+		key := Usage{Type: typ, Mode: string(mode)}
+		if counts[key] == nil {
+			counts[key] = map[string]bool{}
+		}
+		counts[key][address] = true
+	}
+
+	for address, r := range plan.ResourcePlannedValuesMap {
+		add(r.Mode, r.Type, address)
+	}
+	for _, rc := range plan.RawPlan.ResourceChanges {
+		add(rc.Mode, rc.Type, rc.Address)
+	}
+	if plan.RawPlan.PriorState != nil && plan.RawPlan.PriorState.Values != nil {
+		var walk func(m *tfjson.StateModule)
+		walk = func(m *tfjson.StateModule) {
+			if m == nil {
+				return
+			}
+			for _, r := range m.Resources {
+				if r.Mode == tfjson.DataResourceMode {
+					add(r.Mode, r.Type, r.Address)
+				}
+			}
+			for _, child := range m.ChildModules {
+				walk(child)
+			}
+		}
+		walk(plan.RawPlan.PriorState.Values.RootModule)
+	}
+
+	req := &Requirements{TableVersion: table.Version, Types: []Usage{}, Actions: []Required{}}
+	needed := map[string]map[string]bool{}
+	need := func(actions []string, key string) {
+		for _, a := range actions {
+			if needed[a] == nil {
+				needed[a] = map[string]bool{}
+			}
+			needed[a][key] = true
+		}
+	}
+	for u, addresses := range counts {
+		u.Resources = len(addresses)
+		req.Types = append(req.Types, u)
+		if u.Mode == string(tfjson.DataResourceMode) {
+			actions, ok := table.DataSources[u.Type]
+			if !ok {
+				req.Unmapped = append(req.Unmapped, u.Key())
+			}
+			need(actions, u.Key())
+			continue
+		}
+		lifecycle, ok := table.Resources[u.Type]
+		if !ok {
+			req.Unmapped = append(req.Unmapped, u.Key())
+			continue
+		}
+		for _, op := range Operations {
+			need(lifecycle.Actions(op), u.Key())
+		}
+	}
+
+	sort.Slice(req.Types, func(i, j int) bool {
+		return req.Types[i].Key() < req.Types[j].Key()
+	})
+	sort.Strings(req.Unmapped)
+	for action, types := range needed {
+		req.Actions = append(req.Actions, Required{Action: action, Types: sortedKeys(types)})
+	}
+	sort.Slice(req.Actions, func(i, j int) bool {
+		return req.Actions[i].Action < req.Actions[j].Action
+	})
+	return req
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,183 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iam
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"test/planfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const shippedPolicy = "../../files/policies/devops-iac-eks-policy.json"
+
+func testRequirements(t *testing.T, table *Table) *Requirements {
+	plan, err := planfile.Load("../reachability/testdata/plan.json")
+	require.NoError(t, err)
+	return Generate(plan, table)
+}
+
+func requiredBy(req *Requirements) map[string][]string {
+	by := map[string][]string{}
+	for _, r := range req.Actions {
+		by[r.Action] = r.Types
+	}
+	return by
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	req := testRequirements(t, DefaultTable())
+	assert.Empty(t, req.Unmapped)
+
+	types := map[string]int{}
+	for _, u := range req.Types {
+		types[u.Key()] = u.Resources
+	}
+	assert.Equal(t, 3, types["aws_security_group"])
+	assert.Equal(t, 1, types["aws_vpc"])
+
+	by := requiredBy(req)
+	assert.Equal(t, []string{"aws_vpc_security_group_egress_rule", "aws_vpc_security_group_ingress_rule"}, by["ec2:ModifySecurityGroupRules"])
+	assert.Contains(t, by, "ec2:RunInstances")
+	assert.Contains(t, by, "rds:DeleteDBInstance", "managed resources need their whole lifecycle")
+	assert.NotContains(t, by, "ec2:DescribeImages", "the plan reads no aws_ami")
+
+	plan, err := planfile.Load("../cost/testdata/plan-standard.json")
+	require.NoError(t, err)
+	by = requiredBy(Generate(plan, DefaultTable()))
+	assert.Equal(t, []string{"data.aws_ami"}, by["ec2:DescribeImages"])
+}
+
+func TestGenerateUnmapped(t *testing.T) {
+	t.Parallel()
+
+	table := *DefaultTable()
+	table.Resources = map[string]Lifecycle{}
+	req := testRequirements(t, &table)
+	assert.Contains(t, req.Unmapped, "aws_vpc")
+	assert.Empty(t, req.Actions)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	req := testRequirements(t, DefaultTable())
+	shipped, err := LoadPolicy(shippedPolicy)
+	require.NoError(t, err)
+
+	d := Compare(req, shipped)
+	var missing []string
+	for _, r := range d.Missing {
+		missing = append(missing, r.Action)
+		assert.False(t, shipped.Allows(r.Action), r.Action)
+	}
+	assert.Contains(t, missing, "ec2:ModifySecurityGroupRules")
+	assert.NotContains(t, missing, "ec2:DescribeSecurityGroupRules", "granted by ec2:Describe*")
+	assert.Contains(t, d.Unneeded, "ecr:PutImage")
+	assert.NotContains(t, d.Unneeded, "ec2:Describe*")
+
+	assert.Empty(t, Compare(req, req.Policy()).Missing)
+	assert.Empty(t, Compare(req, req.Policy()).Unneeded)
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern, action string
+		expected        bool
+	}{
+		"exact":        {"ec2:CreateVpc", "ec2:CreateVpc", true},
+		"caseFolded":   {"tag:getTagKeys", "tag:GetTagKeys", true},
+		"suffix":       {"ec2:Describe*", "ec2:DescribeVpcs", true},
+		"infix":        {"ec2:*VpcEndpoint*", "ec2:DeleteVpcEndpoints", true},
+		"service":      {"eks:*", "eks:CreateCluster", true},
+		"otherService": {"eks:*", "ec2:CreateVpc", false},
+		"singleChar":   {"ec2:CreateVp?", "ec2:CreateVpc", true},
+		"prefixOnly":   {"ec2:Create", "ec2:CreateVpc", false},
+		"everything":   {"*", "rds:CreateDBInstance", true},
+		"notAPrefixOf": {"iam:List*", "iam:GetRole", false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Matches(tc.pattern, tc.action))
+		})
+	}
+}
+
+func TestPolicyRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var d Document
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Action": "ec2:CreateVpc", "Resource": "*"},
+			{"Effect": "Deny", "Action": ["ec2:DeleteVpc"], "Resource": "*"}
+		]
+	}`), &d))
+	assert.Equal(t, []string{"ec2:CreateVpc"}, d.Grants())
+	assert.False(t, d.Allows("ec2:DeleteVpc"), "Deny statements grant nothing")
+
+	data, err := NewPolicy([]string{"ec2:CreateVpc", "ec2:DeleteVpc"}).MarshalIndent()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Resource": "*"`)
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	loaded, err := LoadPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ec2:CreateVpc", "ec2:DeleteVpc"}, loaded.Grants())
+}
+
+// TestDefaultTableCoversConfiguration fails when a resource or data source of
+// the aws provider is added to the root module or modules/ without actions
+func TestDefaultTableCoversConfiguration(t *testing.T) {
+	t.Parallel()
+
+	table := DefaultTable()
+	files, err := filepath.Glob("../../*.tf")
+	require.NoError(t, err)
+	modules, err := filepath.Glob("../../modules/*/*.tf")
+	require.NoError(t, err)
+	files = append(files, modules...)
+	require.NotEmpty(t, files)
+
+	block := regexp.MustCompile(`(?m)^(resource|data) "(aws_[a-z0-9_]+)"`)
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		for _, m := range block.FindAllStringSubmatch(string(data), -1) {
+			if m[1] == "data" {
+				assert.Contains(t, table.DataSources, m[2], "%s: data source", path)
+			} else {
+				assert.Contains(t, table.Resources, m[2], "%s: resource", path)
+			}
+		}
+	}
+
+	action := regexp.MustCompile(`^[a-z0-9-]+:[A-Z][A-Za-z0-9]+$`)
+	for typ, lifecycle := range table.Resources {
+		assert.NotEmpty(t, lifecycle.Create, typ)
+		assert.NotEmpty(t, lifecycle.Delete, typ)
+		for _, op := range Operations {
+			for _, a := range lifecycle.Actions(op) {
+				assert.Regexp(t, action, a, "%s %s", typ, op)
+			}
+		}
+	}
+	for typ, actions := range table.DataSources {
+		for _, a := range actions {
+			assert.Regexp(t, action, a, "data.%s", typ)
+		}
+	}
+}
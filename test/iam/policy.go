@@ -0,0 +1,135 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// PolicyVersion is the IAM policy language version
+const PolicyVersion = "2012-10-17"
+
+// StatementID is the Sid of the statement in files/policies/devops-iac-eks-policy.json
+const StatementID = "DevOpsIACEKSPolicy"
+
+// Values is a policy element that is either a single string or a list
+type Values []string
+
+// UnmarshalJSON accepts a string or a list of strings
+func (v *Values) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = Values{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or a list of strings: %w", err)
+	}
+	*v = list
+	return nil
+}
+
+// MarshalJSON writes a single value as a string, like the policies in
+// files/policies do for Resource
+func (v Values) MarshalJSON() ([]byte, error) {
+	if len(v) == 1 {
+		return json.Marshal(v[0])
+	}
+	return json.Marshal([]string(v))
+}
+
+// Statement is a policy statement. Conditions are not modeled.
+type Statement struct {
+	Sid       string `json:"Sid,omitempty"`
+	Effect    string `json:"Effect"`
+	Action    Values `json:"Action,omitempty"`
+	NotAction Values `json:"NotAction,omitempty"`
+	Resource  Values `json:"Resource"`
+}
+
+// Document is an IAM policy document
+type Document struct {
+	Version   string      `json:"Version"`
+	Statement []Statement `json:"Statement"`
+}
+
+// LoadPolicy reads a policy document from a file
+func LoadPolicy(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Document
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("%s: decoding policy: %w", path, err)
+	}
+	return &d, nil
+}
+
+// Grants returns the action patterns of the Allow statements, sorted and
+// without duplicates. Deny and NotAction statements are ignored.
+func (d *Document) Grants() []string {
+	seen := map[string]bool{}
+	var grants []string
+	for _, s := range d.Statement {
+		if s.Effect != "Allow" {
+			continue
+		}
+		for _, a := range s.Action {
+			if !seen[a] {
+				seen[a] = true
+				grants = append(grants, a)
+			}
+		}
+	}
+	sort.Strings(grants)
+	return grants
+}
+
+// Allows reports whether an Allow statement grants the action
+func (d *Document) Allows(action string) bool {
+	for _, grant := range d.Grants() {
+		if Matches(grant, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether an action pattern such as "ec2:Describe*" grants an
+// action. Actions are case insensitive and patterns may use * and ?.
+func Matches(pattern, action string) bool {
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(action))
+	return err == nil && matched
+}
+
+// NewPolicy returns a policy with one statement that allows the actions on
+// all resources
+func NewPolicy(actions []string) *Document {
+	return &Document{
+		Version: PolicyVersion,
+		Statement: []Statement{{
+			Sid:      StatementID,
+			Effect:   "Allow",
+			Action:   actions,
+			Resource: Values{"*"},
+		}},
+	}
+}
+
+// MarshalIndent encodes the policy with the indentation of the files in
+// files/policies
+func (d *Document) MarshalIndent() ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
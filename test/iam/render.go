@@ -0,0 +1,75 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package iam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"test/report"
+)
+
+// Table renders the resource types of the plan and the number of actions
+// each needs
+func (r *Requirements) Table() *report.Table {
+	t := &report.Table{
+		Title: fmt.Sprintf("Resource types (action table %s)", r.TableVersion),
+		Columns: []report.Column{
+			{Header: "Type"},
+			{Header: "Resources", Right: true},
+			{Header: "Actions", Right: true},
+		},
+		Footer: []string{"Total", "", strconv.Itoa(len(r.Actions))},
+	}
+	actions := map[string]int{}
+	for _, a := range r.Actions {
+		for _, typ := range a.Types {
+			actions[typ]++
+		}
+	}
+	unmapped := map[string]bool{}
+	for _, u := range r.Unmapped {
+		unmapped[u] = true
+	}
+	for _, u := range r.Types {
+		count := strconv.Itoa(actions[u.Key()])
+		if unmapped[u.Key()] {
+			count = "unmapped"
+		}
+		t.AddRow(u.Key(), strconv.Itoa(u.Resources), count)
+	}
+	return t
+}
+
+// Tables renders the missing and the unneeded actions, and the unmapped types
+// when there are any
+func (d *Diff) Tables() []*report.Table {
+	missing := &report.Table{
+		Title:   "Required actions the policy does not allow",
+		Columns: []report.Column{{Header: "Action"}, {Header: "Needed by"}},
+	}
+	for _, r := range d.Missing {
+		missing.AddRow(r.Action, strings.Join(r.Types, ", "))
+	}
+	unneeded := &report.Table{
+		Title:   "Policy actions no planned resource needs",
+		Columns: []report.Column{{Header: "Action"}},
+	}
+	for _, a := range d.Unneeded {
+		unneeded.AddRow(a)
+	}
+	tables := []*report.Table{missing, unneeded}
+	if len(d.Unmapped) > 0 {
+		unmapped := &report.Table{
+			Title:   "Types missing from the action table",
+			Columns: []report.Column{{Header: "Type"}},
+		}
+		for _, u := range d.Unmapped {
+			unmapped.AddRow(u)
+		}
+		tables = append(tables, unmapped)
+	}
+	return tables
+}
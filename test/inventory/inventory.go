@@ -0,0 +1,335 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package inventory lists what a deployed environment contains from its
+// terraform state, grouped the way the stack builds it, and flags the state
+// problems that break the next apply or the handoff to the deployment stage.
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"test/planfile"
+	"test/statefile"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// KubeconfigAddress is the kubeconfig file the stack writes for kubectl and
+// the deployment stage
+const KubeconfigAddress = "module.kubeconfig.local_file.kubeconfig"
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Finding is a problem of the state
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Address  string   `json:"address,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Address == "" {
+		return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Address, f.Message)
+}
+
+// Network is the VPC, Existing when it was not created by the stack
+type Network struct {
+	ID       string `json:"id"`
+	CIDR     string `json:"cidr"`
+	Existing bool   `json:"existing"`
+}
+
+// Subnet is a subnet of the VPC
+type Subnet struct {
+	// Role is public, private, database or control_plane
+	Role     string `json:"role"`
+	ID       string `json:"id"`
+	CIDR     string `json:"cidr"`
+	AZ       string `json:"az"`
+	Existing bool   `json:"existing"`
+}
+
+// Cluster is the EKS cluster
+type Cluster struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Endpoint string `json:"endpoint"`
+	Status   string `json:"status"`
+}
+
+// NodeGroup is an EKS managed node group
+type NodeGroup struct {
+	Name          string   `json:"name"`
+	InstanceTypes []string `json:"instanceTypes"`
+	AMIType       string   `json:"amiType"`
+	Version       string   `json:"version"`
+	MinSize       int      `json:"minSize"`
+	MaxSize       int      `json:"maxSize"`
+	DesiredSize   int      `json:"desiredSize"`
+	Status        string   `json:"status"`
+}
+
+// Storage is the RWX storage backend
+type Storage struct {
+	// Backend is efs, ontap or nfs
+	Backend  string `json:"backend"`
+	ID       string `json:"id"`
+	Endpoint string `json:"endpoint"`
+}
+
+// Database is an RDS PostgreSQL server
+type Database struct {
+	// Name is the postgres_servers key
+	Name          string `json:"name"`
+	Identifier    string `json:"identifier"`
+	EngineVersion string `json:"engineVersion"`
+	InstanceClass string `json:"instanceClass"`
+	Address       string `json:"address"`
+	Port          int    `json:"port"`
+}
+
+// VM is an EC2 instance of the jump or NFS server
+type VM struct {
+	// Name is the module of the VM, jump or nfs
+	Name         string `json:"name"`
+	ID           string `json:"id"`
+	InstanceType string `json:"instanceType"`
+	PrivateIP    string `json:"privateIp"`
+	PublicIP     string `json:"publicIp,omitempty"`
+	State        string `json:"state"`
+}
+
+// Named is a security group or IAM role
+type Named struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+}
+
+// Inventory is the contents of a state
+type Inventory struct {
+	TerraformVersion string      `json:"terraformVersion"`
+	Resources        int         `json:"resources"`
+	Network          *Network    `json:"network,omitempty"`
+	Subnets          []Subnet    `json:"subnets"`
+	Cluster          *Cluster    `json:"cluster,omitempty"`
+	NodeGroups       []NodeGroup `json:"nodeGroups"`
+	Storage          *Storage    `json:"storage,omitempty"`
+	Databases        []Database  `json:"databases"`
+	VMs              []VM        `json:"vms"`
+	SecurityGroups   []Named     `json:"securityGroups"`
+	IAMRoles         []Named     `json:"iamRoles"`
+	Findings         []Finding   `json:"findings"`
+}
+
+// Passed reports whether no finding is an error
+func (inv *Inventory) Passed() bool {
+	for _, f := range inv.Findings {
+		if f.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+func (inv *Inventory) add(severity Severity, address, format string, args ...interface{}) {
+	inv.Findings = append(inv.Findings, Finding{Severity: severity, Address: address, Message: fmt.Sprintf(format, args...)})
+}
+
+// subnetRoles are the subnet resource names of module.vpc
+var subnetRoles = []string{"public", "private", "database", "control_plane"}
+
+// Build lists the contents of a state and checks it
+func Build(state *statefile.State) *Inventory {
+	inv := &Inventory{
+		TerraformVersion: state.TerraformVersion,
+		Subnets:          []Subnet{},
+		NodeGroups:       []NodeGroup{},
+		Databases:        []Database{},
+		VMs:              []VM{},
+		SecurityGroups:   []Named{},
+		IAMRoles:         []Named{},
+		Findings:         []Finding{},
+	}
+
+	kubeconfig := false
+	for _, r := range state.Resources {
+		if r.Mode == tfjson.ManagedResourceMode {
+			inv.Resources++
+		}
+		if r.Tainted {
+			inv.add(Error, r.Address, "tainted, the next apply replaces it")
+		}
+		if r.Address == KubeconfigAddress {
+			kubeconfig = true
+		}
+		inv.collect(r)
+	}
+
+	sort.SliceStable(inv.Subnets, func(i, j int) bool {
+		a, b := inv.Subnets[i], inv.Subnets[j]
+		if a.Role != b.Role {
+			return roleOrder(a.Role) < roleOrder(b.Role)
+		}
+		return a.AZ < b.AZ
+	})
+	sort.Slice(inv.NodeGroups, func(i, j int) bool { return inv.NodeGroups[i].Name < inv.NodeGroups[j].Name })
+	sort.Slice(inv.Databases, func(i, j int) bool { return inv.Databases[i].Name < inv.Databases[j].Name })
+	sort.Slice(inv.VMs, func(i, j int) bool { return inv.VMs[i].Name < inv.VMs[j].Name })
+
+	inv.check(kubeconfig)
+	return inv
+}
+
+func roleOrder(role string) int {
+	for i, r := range subnetRoles {
+		if r == role {
+			return i
+		}
+	}
+	return len(subnetRoles)
+}
+
+// collect adds a resource to its section of the inventory
+func (inv *Inventory) collect(r statefile.Resource) {
+	v := r.Values
+	existing := r.Mode == tfjson.DataResourceMode
+	if existing && r.Module != "module.vpc" {
+		return
+	}
+
+	switch r.Type {
+	case "aws_vpc":
+		inv.Network = &Network{ID: v.String("id"), CIDR: v.String("cidr_block"), Existing: existing}
+	case "aws_subnet":
+		inv.Subnets = append(inv.Subnets, Subnet{
+			Role:     r.Name,
+			ID:       v.String("id"),
+			CIDR:     v.String("cidr_block"),
+			AZ:       v.String("availability_zone"),
+			Existing: existing,
+		})
+	case "aws_eks_cluster":
+		inv.Cluster = &Cluster{
+			Name:     v.String("name"),
+			Version:  v.String("version"),
+			Endpoint: v.String("endpoint"),
+			Status:   v.String("status"),
+		}
+	case "aws_eks_node_group":
+		ng := NodeGroup{
+			Name:          v.String("node_group_name"),
+			InstanceTypes: v.Strings("instance_types"),
+			AMIType:       v.String("ami_type"),
+			Version:       v.String("version"),
+			Status:        v.String("status"),
+		}
+		if scaling := v.Blocks("scaling_config"); len(scaling) > 0 {
+			ng.MinSize = int(scaling[0].Number("min_size"))
+			ng.MaxSize = int(scaling[0].Number("max_size"))
+			ng.DesiredSize = int(scaling[0].Number("desired_size"))
+		}
+		inv.NodeGroups = append(inv.NodeGroups, ng)
+	case "aws_efs_file_system":
+		inv.Storage = &Storage{Backend: "efs", ID: v.String("id"), Endpoint: v.String("dns_name")}
+	case "aws_fsx_ontap_file_system":
+		inv.Storage = &Storage{Backend: "ontap", ID: v.String("id"), Endpoint: ontapEndpoint(v)}
+	case "aws_db_instance":
+		inv.Databases = append(inv.Databases, Database{
+			Name:          moduleKey(r.Module),
+			Identifier:    v.String("identifier"),
+			EngineVersion: v.String("engine_version_actual"),
+			InstanceClass: v.String("instance_class"),
+			Address:       v.String("address"),
+			Port:          int(v.Number("port")),
+		})
+	case "aws_instance":
+		vm := VM{
+			Name:         moduleName(r.Module),
+			ID:           v.String("id"),
+			InstanceType: v.String("instance_type"),
+			PrivateIP:    v.String("private_ip"),
+			PublicIP:     v.String("public_ip"),
+			State:        v.String("instance_state"),
+		}
+		inv.VMs = append(inv.VMs, vm)
+		if vm.Name == "nfs" {
+			inv.Storage = &Storage{Backend: "nfs", ID: vm.ID, Endpoint: v.String("private_dns")}
+		}
+	case "aws_security_group":
+		inv.SecurityGroups = append(inv.SecurityGroups, Named{Address: r.Address, Name: v.String("name"), ID: v.String("id")})
+	case "aws_iam_role":
+		inv.IAMRoles = append(inv.IAMRoles, Named{Address: r.Address, Name: v.String("name"), ID: v.String("arn")})
+	}
+}
+
+// ontapEndpoint is the management DNS name of an ONTAP file system
+func ontapEndpoint(v planfile.Attributes) string {
+	for _, endpoints := range v.Blocks("endpoints") {
+		for _, management := range endpoints.Blocks("management") {
+			if name := management.String("dns_name"); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// moduleName returns the name of the outermost module of a module path, like
+// jump for module.jump[0]
+func moduleName(module string) string {
+	name := strings.TrimPrefix(strings.SplitN(module, ".module.", 2)[0], "module.")
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// moduleKey returns the for_each key of the outermost module of a module
+// path, like default for module.postgresql["default"]
+func moduleKey(module string) string {
+	outer := strings.SplitN(module, ".module.", 2)[0]
+	start, end := strings.Index(outer, `["`), strings.LastIndex(outer, `"]`)
+	if start < 0 || end < start {
+		return moduleName(module)
+	}
+	return outer[start+2 : end]
+}
+
+// check reports the problems beyond tainted resources
+func (inv *Inventory) check(kubeconfig bool) {
+	if inv.Cluster == nil {
+		inv.add(Error, "", "the state has no EKS cluster, it was never applied or was destroyed")
+		return
+	}
+	if !kubeconfig {
+		inv.add(Warning, KubeconfigAddress, "missing, apply again to write the kubeconfig file")
+	}
+	if inv.Cluster.Status != "" && inv.Cluster.Status != "ACTIVE" {
+		inv.add(Error, "", "cluster %s is %s", inv.Cluster.Name, inv.Cluster.Status)
+	}
+	for _, ng := range inv.NodeGroups {
+		if ng.Status != "" && ng.Status != "ACTIVE" {
+			inv.add(Error, "", "node group %s is %s", ng.Name, ng.Status)
+		}
+		if ng.Version != "" && inv.Cluster.Version != "" && ng.Version != inv.Cluster.Version {
+			inv.add(Warning, "", "node group %s runs %s, the cluster runs %s", ng.Name, ng.Version, inv.Cluster.Version)
+		}
+	}
+	for _, vm := range inv.VMs {
+		if vm.State != "" && vm.State != "running" {
+			inv.add(Warning, "", "%s VM %s is %s", vm.Name, vm.ID, vm.State)
+		}
+	}
+}
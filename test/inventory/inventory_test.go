@@ -0,0 +1,121 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"bytes"
+	"testing"
+
+	"test/report"
+	"test/statefile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func load(t *testing.T) *Inventory {
+	state, err := statefile.Load("testdata/terraform.tfstate")
+	require.NoError(t, err)
+	return Build(state)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	inv := load(t)
+	assert.Equal(t, 12, inv.Resources, "data sources are not counted")
+	assert.Equal(t, &Network{ID: "vpc-0123456789abcdef0", CIDR: "192.168.0.0/16"}, inv.Network)
+	assert.Equal(t, []Subnet{
+		{Role: "public", ID: "subnet-0000000000000000c", CIDR: "192.168.129.0/25", AZ: "us-east-1a"},
+		{Role: "private", ID: "subnet-0000000000000000a", CIDR: "192.168.0.0/18", AZ: "us-east-1a"},
+		{Role: "private", ID: "subnet-0000000000000000b", CIDR: "192.168.32.0/18", AZ: "us-east-1b"},
+	}, inv.Subnets, "subnets are sorted by role, then AZ")
+	assert.Equal(t, NodeGroup{
+		Name:          "default-20250101",
+		InstanceTypes: []string{"m7i-flex.2xlarge"},
+		AMIType:       "AL2023_x86_64_STANDARD",
+		Version:       "1.32",
+		MinSize:       1,
+		MaxSize:       5,
+		DesiredSize:   2,
+		Status:        "ACTIVE",
+	}, inv.NodeGroups[1])
+	assert.Equal(t, &Storage{Backend: "efs", ID: "fs-0123456789abcdef0", Endpoint: "fs-0123456789abcdef0.efs.us-east-1.amazonaws.com"}, inv.Storage)
+	assert.Equal(t, []Database{{
+		Name:          "default",
+		Identifier:    "viya-default-pgsql",
+		EngineVersion: "15.7",
+		InstanceClass: "db.m6idn.xlarge",
+		Address:       "viya-default-pgsql.abcdefghij.us-east-1.rds.amazonaws.com",
+		Port:          5432,
+	}}, inv.Databases)
+	assert.Equal(t, []VM{{Name: "jump", ID: "i-0123456789abcdef0", InstanceType: "m6in.xlarge", PrivateIP: "192.168.129.10", PublicIP: "3.210.1.3", State: "stopped"}}, inv.VMs)
+	assert.Equal(t, []Named{{Address: "aws_security_group.sg[0]", Name: "viya-sg", ID: "sg-0a1b2c3d4e5f60718"}}, inv.SecurityGroups,
+		"data sources outside module.vpc are left out")
+	assert.Len(t, inv.IAMRoles, 1)
+}
+
+func TestFindings(t *testing.T) {
+	t.Parallel()
+
+	inv := load(t)
+	var findings []string
+	for _, f := range inv.Findings {
+		findings = append(findings, f.String())
+	}
+	assert.Equal(t, []string{
+		`error: module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]: tainted, the next apply replaces it`,
+		"warning: module.kubeconfig.local_file.kubeconfig: missing, apply again to write the kubeconfig file",
+		"error: node group cas-20250101 is DEGRADED",
+		"warning: node group cas-20250101 runs 1.31, the cluster runs 1.32",
+		"warning: jump VM i-0123456789abcdef0 is stopped",
+	}, findings)
+	assert.False(t, inv.Passed())
+}
+
+func TestExistingNetwork(t *testing.T) {
+	t.Parallel()
+
+	state, err := statefile.Parse([]byte(`{
+  "version": 4,
+  "resources": [
+    {"module": "module.vpc", "mode": "data", "type": "aws_vpc", "name": "vpc", "instances": [{"index_key": 0, "attributes": {"id": "vpc-1", "cidr_block": "10.0.0.0/16"}}]},
+    {"module": "module.vpc", "mode": "data", "type": "aws_subnet", "name": "control_plane", "instances": [{"index_key": 0, "attributes": {"id": "subnet-1", "cidr_block": "10.0.0.0/28", "availability_zone": "us-east-1a"}}]},
+    {"module": "module.eks", "mode": "managed", "type": "aws_eks_cluster", "name": "this", "instances": [{"index_key": 0, "attributes": {"name": "byon-eks", "version": "1.35", "status": "ACTIVE"}}]},
+    {"module": "module.kubeconfig", "mode": "managed", "type": "local_file", "name": "kubeconfig", "instances": [{"attributes": {"filename": "byon-eks-kubeconfig.conf"}}]},
+    {"module": "module.nfs[0]", "mode": "managed", "type": "aws_instance", "name": "vm", "instances": [{"attributes": {"id": "i-1", "private_ip": "10.0.1.5", "private_dns": "ip-10-0-1-5.ec2.internal", "instance_state": "running"}}]}
+  ]
+}`))
+	require.NoError(t, err)
+	inv := Build(state)
+	assert.Equal(t, &Network{ID: "vpc-1", CIDR: "10.0.0.0/16", Existing: true}, inv.Network)
+	assert.Equal(t, []Subnet{{Role: "control_plane", ID: "subnet-1", CIDR: "10.0.0.0/28", AZ: "us-east-1a", Existing: true}}, inv.Subnets)
+	assert.Equal(t, &Storage{Backend: "nfs", ID: "i-1", Endpoint: "ip-10-0-1-5.ec2.internal"}, inv.Storage)
+	assert.Empty(t, inv.Findings)
+	assert.True(t, inv.Passed())
+}
+
+func TestEmptyState(t *testing.T) {
+	t.Parallel()
+
+	inv := Build(&statefile.State{})
+	assert.Equal(t, []Finding{{Severity: Error, Message: "the state has no EKS cluster, it was never applied or was destroyed"}}, inv.Findings)
+	require.Len(t, inv.Tables(), 1, "only the findings have rows")
+}
+
+func TestTables(t *testing.T) {
+	t.Parallel()
+
+	inv := load(t)
+	var titles []string
+	for _, table := range inv.Tables() {
+		titles = append(titles, table.Title)
+	}
+	assert.Equal(t, []string{"Network", "Cluster", "Node groups", "Storage", "PostgreSQL servers", "VMs", "Security groups", "IAM roles", "Findings"}, titles)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf, report.Markdown, inv, inv.Tables()...))
+	assert.Contains(t, buf.String(), "| default | viya-default-pgsql | 15.7 | db.m6idn.xlarge | viya-default-pgsql.abcdefghij.us-east-1.rds.amazonaws.com:5432 |")
+	assert.NotContains(t, buf.String(), "hidden", "attributes outside the inventory, like passwords, are not shown")
+}
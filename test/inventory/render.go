@@ -0,0 +1,125 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"test/report"
+)
+
+// Tables renders the sections of the inventory that have contents, and the
+// findings
+func (inv *Inventory) Tables() []*report.Table {
+	var tables []*report.Table
+	add := func(t *report.Table) {
+		if len(t.Rows) > 0 {
+			tables = append(tables, t)
+		}
+	}
+
+	network := &report.Table{
+		Title:   "Network",
+		Columns: []report.Column{{Header: "Role"}, {Header: "ID"}, {Header: "CIDR"}, {Header: "AZ"}, {Header: "Existing"}},
+	}
+	if inv.Network != nil {
+		network.AddRow("vpc", inv.Network.ID, inv.Network.CIDR, "", yesNo(inv.Network.Existing))
+	}
+	for _, s := range inv.Subnets {
+		network.AddRow(s.Role, s.ID, s.CIDR, s.AZ, yesNo(s.Existing))
+	}
+	add(network)
+
+	cluster := &report.Table{
+		Title:   "Cluster",
+		Columns: []report.Column{{Header: "Name"}, {Header: "Version"}, {Header: "Status"}, {Header: "Endpoint"}},
+	}
+	if inv.Cluster != nil {
+		cluster.AddRow(inv.Cluster.Name, inv.Cluster.Version, inv.Cluster.Status, inv.Cluster.Endpoint)
+	}
+	add(cluster)
+
+	nodeGroups := &report.Table{
+		Title: "Node groups",
+		Columns: []report.Column{
+			{Header: "Name"},
+			{Header: "Instance types"},
+			{Header: "AMI type"},
+			{Header: "Version"},
+			{Header: "Min", Right: true},
+			{Header: "Desired", Right: true},
+			{Header: "Max", Right: true},
+			{Header: "Status"},
+		},
+	}
+	for _, ng := range inv.NodeGroups {
+		nodeGroups.AddRow(ng.Name, strings.Join(ng.InstanceTypes, ", "), ng.AMIType, ng.Version,
+			strconv.Itoa(ng.MinSize), strconv.Itoa(ng.DesiredSize), strconv.Itoa(ng.MaxSize), ng.Status)
+	}
+	add(nodeGroups)
+
+	storage := &report.Table{
+		Title:   "Storage",
+		Columns: []report.Column{{Header: "Backend"}, {Header: "ID"}, {Header: "Endpoint"}},
+	}
+	if inv.Storage != nil {
+		storage.AddRow(inv.Storage.Backend, inv.Storage.ID, inv.Storage.Endpoint)
+	}
+	add(storage)
+
+	databases := &report.Table{
+		Title:   "PostgreSQL servers",
+		Columns: []report.Column{{Header: "Name"}, {Header: "Identifier"}, {Header: "Version"}, {Header: "Class"}, {Header: "Endpoint"}},
+	}
+	for _, db := range inv.Databases {
+		databases.AddRow(db.Name, db.Identifier, db.EngineVersion, db.InstanceClass, fmt.Sprintf("%s:%d", db.Address, db.Port))
+	}
+	add(databases)
+
+	vms := &report.Table{
+		Title:   "VMs",
+		Columns: []report.Column{{Header: "Name"}, {Header: "ID"}, {Header: "Type"}, {Header: "Private IP"}, {Header: "Public IP"}, {Header: "State"}},
+	}
+	for _, vm := range inv.VMs {
+		vms.AddRow(vm.Name, vm.ID, vm.InstanceType, vm.PrivateIP, vm.PublicIP, vm.State)
+	}
+	add(vms)
+
+	securityGroups := &report.Table{
+		Title:   "Security groups",
+		Columns: []report.Column{{Header: "Name"}, {Header: "ID"}, {Header: "Address"}},
+	}
+	for _, sg := range inv.SecurityGroups {
+		securityGroups.AddRow(sg.Name, sg.ID, sg.Address)
+	}
+	add(securityGroups)
+
+	roles := &report.Table{
+		Title:   "IAM roles",
+		Columns: []report.Column{{Header: "Name"}, {Header: "ARN"}, {Header: "Address"}},
+	}
+	for _, role := range inv.IAMRoles {
+		roles.AddRow(role.Name, role.ID, role.Address)
+	}
+	add(roles)
+
+	findings := &report.Table{
+		Title:   "Findings",
+		Columns: []report.Column{{Header: "Severity"}, {Header: "Address"}, {Header: "Message"}},
+	}
+	for _, f := range inv.Findings {
+		findings.AddRow(string(f.Severity), f.Address, f.Message)
+	}
+	add(findings)
+	return tables
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,97 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// current returns a copy of a kubeconfig and its current context
+func current(config *api.Config) (*api.Config, *api.Context, error) {
+	copied := config.DeepCopy()
+	context, ok := copied.Contexts[copied.CurrentContext]
+	if !ok {
+		return nil, nil, fmt.Errorf("current-context %q has no context", copied.CurrentContext)
+	}
+	return copied, context, nil
+}
+
+// setUser replaces the user of a context, dropping the old user when no other
+// context refers to it
+func setUser(config *api.Config, context *api.Context, name string, user *api.AuthInfo) {
+	old := context.AuthInfo
+	context.AuthInfo = name
+	config.AuthInfos[name] = user
+	if old == name {
+		return
+	}
+	for _, c := range config.Contexts {
+		if c.AuthInfo == old {
+			return
+		}
+	}
+	delete(config.AuthInfos, old)
+}
+
+// ToStatic returns a copy of a kubeconfig whose current context authenticates
+// with a token, like kubeconfig-sa.tmpl. User is the name of the service
+// account user, and namespace the namespace of the context.
+func ToStatic(config *api.Config, user, token, namespace string) (*api.Config, error) {
+	if token == "" {
+		return nil, fmt.Errorf("a static kubeconfig needs a token")
+	}
+	copied, context, err := current(config)
+	if err != nil {
+		return nil, err
+	}
+	if user == "" {
+		user = context.AuthInfo
+	}
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = token
+	setUser(copied, context, user, authInfo)
+	context.Namespace = namespace
+	return copied, nil
+}
+
+// ToProvider returns a copy of a kubeconfig whose current context gets its
+// token from `aws eks get-token`, like kubeconfig-provider.tmpl. The cluster
+// of the context must be named after the EKS cluster, as both templates do.
+func ToProvider(config *api.Config, region string) (*api.Config, error) {
+	if region == "" {
+		return nil, fmt.Errorf("a provider kubeconfig needs the region of the cluster")
+	}
+	copied, context, err := current(config)
+	if err != nil {
+		return nil, err
+	}
+	cluster := context.Cluster
+	authInfo := api.NewAuthInfo()
+	authInfo.Exec = &api.ExecConfig{
+		APIVersion:      ExecAPIVersion,
+		Command:         "aws",
+		Args:            []string{"--region", region, "eks", "get-token", "--cluster-name", cluster, "--output", "json"},
+		InteractiveMode: api.IfAvailableExecInteractiveMode,
+	}
+	setUser(copied, context, cluster, authInfo)
+	context.Namespace = ""
+	return copied, nil
+}
+
+// Write writes a kubeconfig readable by the owner only, even when the file
+// already exists
+func Write(path string, config *api.Config) error {
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0o600)
+}
@@ -0,0 +1,199 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kubeconfig checks the kubeconfig files modules/kubeconfig writes and
+// converts between its two forms: the provider form, which gets a token from
+// `aws eks get-token` with an exec plugin, and the static form, which embeds
+// the long-lived token of a cluster-admin service account.
+package kubeconfig
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Mode is the form of a kubeconfig
+type Mode string
+
+const (
+	// Provider kubeconfigs run `aws eks get-token`, see kubeconfig-provider.tmpl
+	Provider Mode = "provider"
+	// Static kubeconfigs embed a service account token, see kubeconfig-sa.tmpl
+	Static Mode = "static"
+)
+
+// ExecAPIVersion is the client authentication API version the provider
+// template uses
+const ExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// execAPIVersions are the client authentication API versions current kubectl
+// supports, v1alpha1 was removed in Kubernetes 1.24
+var execAPIVersions = map[string]bool{
+	ExecAPIVersion:                    true,
+	"client.authentication.k8s.io/v1": true,
+}
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Finding is a problem of a kubeconfig
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+}
+
+// Result is the mode and the findings of a kubeconfig
+type Result struct {
+	Path     string    `json:"path,omitempty"`
+	Mode     Mode      `json:"mode,omitempty"`
+	Context  string    `json:"context"`
+	Server   string    `json:"server,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+// Passed reports whether no finding is an error
+func (r *Result) Passed() bool {
+	for _, f := range r.Findings {
+		if f.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Result) add(severity Severity, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// Load reads a kubeconfig file
+func Load(path string) (*api.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Check validates a kubeconfig file and its permissions
+func Check(path string) (*Result, error) {
+	config, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	r := Validate(config)
+	r.Path = path
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		if r.Mode == Static {
+			r.add(Error, "%s has mode %04o, others can read the cluster-admin token, chmod 600 it", path, perm)
+		} else {
+			r.add(Warning, "%s has mode %04o, chmod 600 it", path, perm)
+		}
+	}
+	return r, nil
+}
+
+// Validate checks the current context of a kubeconfig: that it exists, that
+// its cluster is reached over https with a CA certificate that decodes, and
+// that its user has a current exec plugin or a token
+func Validate(config *api.Config) *Result {
+	r := &Result{Context: config.CurrentContext, Findings: []Finding{}}
+	if config.CurrentContext == "" {
+		r.add(Error, "current-context is not set")
+		return r
+	}
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		r.add(Error, "current-context %s has no context", config.CurrentContext)
+		return r
+	}
+
+	if cluster, ok := config.Clusters[context.Cluster]; ok {
+		r.Server = cluster.Server
+		checkCluster(r, context.Cluster, cluster)
+	} else {
+		r.add(Error, "context %s refers to cluster %s, which does not exist", config.CurrentContext, context.Cluster)
+	}
+	if user, ok := config.AuthInfos[context.AuthInfo]; ok {
+		checkUser(r, context.AuthInfo, user)
+	} else {
+		r.add(Error, "context %s refers to user %s, which does not exist", config.CurrentContext, context.AuthInfo)
+	}
+	return r
+}
+
+func checkCluster(r *Result, name string, cluster *api.Cluster) {
+	u, err := url.Parse(cluster.Server)
+	switch {
+	case err != nil || u.Host == "":
+		r.add(Error, "cluster %s has an invalid server %q", name, cluster.Server)
+	case u.Scheme != "https":
+		r.add(Error, "cluster %s server %s is not https", name, cluster.Server)
+	}
+	if cluster.InsecureSkipTLSVerify {
+		r.add(Error, "cluster %s skips TLS verification", name)
+	}
+
+	if len(cluster.CertificateAuthorityData) == 0 {
+		r.add(Error, "cluster %s has no certificate-authority-data", name)
+		return
+	}
+	block, _ := pem.Decode(cluster.CertificateAuthorityData)
+	if block == nil || block.Type != "CERTIFICATE" {
+		r.add(Error, "cluster %s certificate-authority-data is not a PEM certificate", name)
+		return
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		r.add(Error, "cluster %s certificate-authority-data: %v", name, err)
+		return
+	}
+	if time.Now().After(cert.NotAfter) {
+		r.add(Error, "cluster %s CA certificate expired on %s", name, cert.NotAfter.Format(time.DateOnly))
+	}
+}
+
+func checkUser(r *Result, name string, user *api.AuthInfo) {
+	switch {
+	case user.Exec != nil && user.Token != "":
+		r.add(Error, "user %s has both an exec plugin and a token", name)
+	case user.Exec != nil:
+		r.Mode = Provider
+		if !execAPIVersions[user.Exec.APIVersion] {
+			r.add(Error, "user %s exec apiVersion %s is not supported by current kubectl, use %s", name, user.Exec.APIVersion, ExecAPIVersion)
+		}
+		if user.Exec.Command == "" {
+			r.add(Error, "user %s exec has no command", name)
+		}
+	case user.Token != "":
+		r.Mode = Static
+	case user.TokenFile != "" || len(user.ClientCertificateData) > 0 || user.ClientCertificate != "":
+		r.add(Warning, "user %s authenticates with neither an exec plugin nor a token, which the kubeconfig module does not write", name)
+	default:
+		r.add(Error, "user %s has no credentials, the service account token is missing", name)
+	}
+}
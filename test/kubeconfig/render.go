@@ -0,0 +1,28 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeconfig
+
+import "test/report"
+
+// Tables renders the kubeconfig and its findings
+func (r *Result) Tables() []*report.Table {
+	summary := &report.Table{
+		Title:   "Kubeconfig",
+		Columns: []report.Column{{Header: "Path"}, {Header: "Mode"}, {Header: "Context"}, {Header: "Server"}},
+	}
+	summary.AddRow(r.Path, string(r.Mode), r.Context, r.Server)
+	tables := []*report.Table{summary}
+
+	if len(r.Findings) > 0 {
+		findings := &report.Table{
+			Title:   "Findings",
+			Columns: []report.Column{{Header: "Severity"}, {Header: "Message"}},
+		}
+		for _, f := range r.Findings {
+			findings.AddRow(string(f.Severity), f.Message)
+		}
+		tables = append(tables, findings)
+	}
+	return tables
+}
@@ -0,0 +1,158 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeconfigapply
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"test/kubeconfig"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// TestApplyStaticKubeconfig applies modules/kubeconfig with
+// create_static_kubeconfig against a kind cluster, whose controller manager
+// fills in the service account token the module reads back, and checks that
+// the kubeconfig it writes authenticates as the service account with
+// cluster-admin rights.
+func TestApplyStaticKubeconfig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("creates a kind cluster")
+	}
+	for _, tool := range []string{"kind", "terraform"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+
+	prefix := "terratest-" + strings.ToLower(random.UniqueId())
+	dir := t.TempDir()
+	admin := filepath.Join(dir, "admin.conf")
+	server := createCluster(t, prefix, admin)
+
+	ec2 := httptest.NewServer(http.HandlerFunc(stubEC2))
+	defer ec2.Close()
+
+	path := filepath.Join(dir, prefix+"-eks-kubeconfig.conf")
+	options := &terraform.Options{
+		TerraformDir: test_structure.CopyTerraformFolderToTemp(t, "../../", "test/kubeconfigapply/testdata"),
+		Vars: map[string]interface{}{
+			"prefix":           prefix,
+			"admin_kubeconfig": admin,
+			"endpoint":         server,
+			"ec2_endpoint":     ec2.URL,
+			"path":             path,
+		},
+		NoColor: true,
+	}
+	defer terraform.Destroy(t, options)
+	terraform.InitAndApply(t, options)
+
+	config, err := kubeconfig.Load(path)
+	require.NoError(t, err)
+	result := kubeconfig.Validate(config)
+	assert.Equal(t, kubeconfig.Static, result.Mode)
+	assert.True(t, result.Passed(), result.Findings)
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, nil).ClientConfig()
+	require.NoError(t, err)
+	client, err := kubernetes.NewForConfig(restConfig)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	review, err := client.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "system:serviceaccount:kube-system:"+prefix+"-cluster-admin-sa", review.Status.UserInfo.Username)
+
+	tests := map[string]authorizationv1.SelfSubjectAccessReviewSpec{
+		"all resources": {
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"},
+		},
+		"cluster role bindings": {
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+		},
+		"secrets in any namespace": {
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: "default"},
+		},
+		"non-resource URLs": {
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Verb: "*", Path: "*"},
+		},
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			access, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{Spec: spec}, metav1.CreateOptions{})
+			require.NoError(t, err)
+			assert.True(t, access.Status.Allowed, "denied: %s", access.Status.Reason)
+		})
+	}
+}
+
+// createCluster creates a kind cluster, deleted when the test ends, writes its
+// admin kubeconfig to path and returns its API server URL
+func createCluster(t *testing.T, name, path string) string {
+	out, err := exec.Command("kind", "create", "cluster", "--name", name, "--kubeconfig", path, "--wait", "2m").CombinedOutput()
+	t.Cleanup(func() {
+		if out, err := exec.Command("kind", "delete", "cluster", "--name", name).CombinedOutput(); err != nil {
+			t.Errorf("deleting kind cluster %s: %v\n%s", name, err, out)
+		}
+	})
+	require.NoError(t, err, string(out))
+
+	config, err := kubeconfig.Load(path)
+	require.NoError(t, err)
+	current, ok := config.Contexts[config.CurrentContext]
+	require.True(t, ok, "kind kubeconfig has no current context")
+	cluster, ok := config.Clusters[current.Cluster]
+	require.True(t, ok, "kind kubeconfig has no cluster %s", current.Cluster)
+	return cluster.Server
+}
+
+// stubEC2 answers the DescribeSecurityGroups call of the module's
+// aws_security_group data source and rejects everything else
+func stubEC2(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	action := r.Form.Get("Action")
+	if action != "DescribeSecurityGroups" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `<Response><Errors><Error><Code>UnsupportedOperation</Code><Message>stub does not implement %s</Message></Error></Errors><RequestID>stub</RequestID></Response>`, action)
+		return
+	}
+	fmt.Fprintf(w, `<DescribeSecurityGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>stub</requestId>
+  <securityGroupInfo>
+    <item>
+      <ownerId>123456789012</ownerId>
+      <groupId>%s</groupId>
+      <groupName>stub</groupName>
+      <groupDescription>stub</groupDescription>
+      <vpcId>vpc-0123456789abcdef0</vpcId>
+      <ipPermissions/>
+      <ipPermissionsEgress/>
+      <tagSet/>
+    </item>
+  </securityGroupInfo>
+</DescribeSecurityGroupsResponse>`, r.Form.Get("GroupId.1"))
+}
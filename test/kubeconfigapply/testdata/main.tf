@@ -0,0 +1,82 @@
+# Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+# SPDX-License-Identifier: Apache-2.0
+
+# Root module applying only modules/kubeconfig with create_static_kubeconfig
+# against a local Kubernetes API server. The AWS provider talks to a stub EC2
+# endpoint served by the test, the module only reads the security group.
+
+terraform {
+  required_version = ">= 1.10.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "~> 2.0"
+    }
+    kubernetes = {
+      source  = "hashicorp/kubernetes"
+      version = "~> 2.0"
+    }
+  }
+}
+
+variable "prefix" {
+  type = string
+}
+
+variable "admin_kubeconfig" {
+  description = "Path to the kubeconfig of the cluster administrator the module creates the service account with"
+  type        = string
+}
+
+variable "endpoint" {
+  type = string
+}
+
+variable "ec2_endpoint" {
+  description = "URL of the stub EC2 API"
+  type        = string
+}
+
+variable "path" {
+  description = "Path to output the kubeconfig file"
+  type        = string
+}
+
+provider "aws" {
+  region                      = "us-east-1"
+  access_key                  = "test"
+  secret_key                  = "test"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  endpoints {
+    ec2 = var.ec2_endpoint
+  }
+}
+
+provider "kubernetes" {
+  config_path = var.admin_kubeconfig
+}
+
+module "kubeconfig" {
+  source                   = "../../../modules/kubeconfig"
+  prefix                   = var.prefix
+  create_static_kubeconfig = true
+  path                     = var.path
+  namespace                = "kube-system"
+
+  cluster_name = "${var.prefix}-eks"
+  region       = "us-east-1"
+  endpoint     = var.endpoint
+  ca_crt       = ""
+  sg_id        = "sg-0123456789abcdef0"
+}
+
+output "kube_config" {
+  value     = module.kubeconfig.kube_config
+  sensitive = true
+}
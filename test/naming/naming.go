@@ -0,0 +1,158 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package naming derives the names of the AWS and Kubernetes resources created
+// from the prefix and node pool keys, following the naming rules in locals.tf,
+// main.tf, vms.tf, security.tf and the local modules, and validates them against
+// the limits of each resource type.
+package naming
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"test/tfvars"
+)
+
+// Name is a resource name derived from the inputs
+type Name struct {
+	Rule
+	// Resource is the address of the resource the name is used for
+	Resource string
+	// Template is the expression the name is built from
+	Template string
+	Value    string
+	Prefix   string
+	// Input is the variable entry, other than prefix, the name is built from
+	Input string
+}
+
+// usesPrefix reports whether the prefix is part of the name
+func (n Name) usesPrefix() bool {
+	return strings.Contains(n.Template, "${prefix}") || strings.Contains(n.Template, "${cluster_name}")
+}
+
+// MaxPrefixLength is the longest prefix that keeps this name within its limit, or -1 if the name does not use the prefix
+func (n Name) MaxPrefixLength() int {
+	if !n.usesPrefix() {
+		return -1
+	}
+	return n.MaxLen - (len(n.Value) - len(n.Prefix))
+}
+
+// Derive computes every name the configuration will create for the given inputs
+func Derive(in tfvars.Inputs) ([]Name, error) {
+	prefix := in.String("prefix")
+	clusterName := prefix + "-eks"
+	storageType := in.String("storage_type")
+	backend := storageBackend(storageType, in.String("storage_type_backend"))
+
+	var names []Name
+	add := func(rule Rule, resource, template, value, input string) {
+		names = append(names, Name{
+			Rule:     rule,
+			Resource: resource,
+			Template: template,
+			Value:    value,
+			Prefix:   prefix,
+			Input:    input,
+		})
+	}
+
+	add(prefixRule, "var.prefix", "${prefix}", prefix, "")
+	add(eksClusterName, "module.eks.aws_eks_cluster.this[0]", "${prefix}-eks", clusterName, "")
+	add(resourceGroupName, "aws_resourcegroups_group.aws_rg", "${prefix}-rg", prefix+"-rg", "")
+	if in.String("cluster_iam_role_arn") == "" {
+		add(iamRoleNamePrefix, "module.eks.aws_iam_role.this[0]", "${cluster_name}-cluster-", clusterName+"-cluster-", "")
+	}
+
+	if in.String("security_group_id") == "" {
+		add(securityGroupName, "aws_security_group.sg[0]", "${prefix}-sg", prefix+"-sg", "")
+	}
+	if in.String("cluster_security_group_id") == "" {
+		add(securityGroupName, "aws_security_group.cluster_security_group[0]", "${prefix}-eks_cluster_sg", prefix+"-eks_cluster_sg", "")
+	}
+	if in.String("workers_security_group_id") == "" {
+		add(securityGroupName, "aws_security_group.workers_security_group[0]", "${prefix}-eks_worker_sg", prefix+"-eks_worker_sg", "")
+	}
+
+	pools, err := in.NodePools()
+	if err != nil {
+		return nil, err
+	}
+	for _, np := range pools {
+		input := fmt.Sprintf("node_pools[%q]", np.Name)
+		if np.Name == tfvars.DefaultNodePoolName {
+			input = "default node pool"
+		}
+		ng := fmt.Sprintf("module.eks.module.eks_managed_node_group[%q]", np.Name)
+		add(eksNodeGroupNamePrefix, ng+".aws_eks_node_group.this[0]", "${key}-", np.Name+"-", input)
+		add(launchTemplateNamePrefix, ng+".aws_launch_template.this[0]", "${cluster_name}-${key}-lt-", clusterName+"-"+np.Name+"-lt-", input)
+		if in.String("workers_iam_role_arn") == "" {
+			add(iamRoleName, ng+".aws_iam_role.this[0]", "${prefix}-${key}-eks-node-group", prefix+"-"+np.Name+"-eks-node-group", input)
+		}
+	}
+
+	if in.Bool("autoscaling_enabled") {
+		add(iamPolicyNamePrefix, "module.autoscaling[0].aws_iam_policy.worker_autoscaling", "${prefix}-eks-worker-autoscaling", prefix+"-eks-worker-autoscaling", "")
+		add(iamRoleName, "module.autoscaling[0].module.iam_assumable_role_with_oidc.aws_iam_role.this[0]", "${prefix}-cluster-autoscaler", prefix+"-cluster-autoscaler", "")
+	}
+	add(iamPolicyNamePrefix, "module.ebs.aws_iam_policy.ebs_csi", "${prefix}-ebs-csi-policy", prefix+"-ebs-csi-policy", "")
+	add(iamRoleName, "module.ebs.module.iam_assumable_role_with_oidc.aws_iam_role.this[0]", "${prefix}-ebs-csi-role", prefix+"-ebs-csi-role", "")
+
+	if in.String("storage_type_backend") == "ontap" {
+		add(iamPolicyNamePrefix, "module.ontap[0].aws_iam_policy.fsx_ontap", "${prefix}-fsx-ontap", prefix+"-fsx-ontap", "")
+	}
+	switch backend {
+	case "ontap":
+		add(ontapSVMName, "aws_fsx_ontap_storage_virtual_machine.ontap-svm[0]", "${prefix}-ontap-svm", prefix+"-ontap-svm", "")
+		add(ontapVolumeName, "aws_fsx_ontap_volume.ontap-vol[0]", `replace("${prefix}_ontap_vol", "-", "_")`, strings.ReplaceAll(prefix+"_ontap_vol", "-", "_"), "")
+	case "efs":
+		add(efsCreationToken, "aws_efs_file_system.efs-fs[0]", "${prefix}-efs", prefix+"-efs", "")
+	case "nfs":
+		add(keyPairName, "module.nfs[0].aws_key_pair.admin", "${prefix}-nfs-server-admin", prefix+"-nfs-server-admin", "")
+	}
+	if in.Bool("create_jump_vm") {
+		add(keyPairName, "module.jump[0].aws_key_pair.admin", "${prefix}-jump-admin", prefix+"-jump-admin", "")
+	}
+
+	var servers map[string]interface{}
+	if err := in.Decode("postgres_servers", &servers); err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(servers) {
+		add(rdsIdentifier, fmt.Sprintf("module.postgresql[%q].module.db_instance.aws_db_instance.this[0]", key),
+			`lower("${prefix}-${key}-pgsql")`, strings.ToLower(prefix+"-"+key+"-pgsql"), fmt.Sprintf("postgres_servers[%q]", key))
+	}
+
+	if in.Bool("create_static_kubeconfig") {
+		add(kubernetesName, "module.kubeconfig.kubernetes_service_account.kubernetes_sa[0]", "${prefix}-cluster-admin-sa", prefix+"-cluster-admin-sa", "")
+		add(kubernetesName, "module.kubeconfig.kubernetes_secret.sa_secret[0]", "${prefix}-sa-secret", prefix+"-sa-secret", "")
+		add(kubernetesName, "module.kubeconfig.kubernetes_cluster_role_binding.kubernetes_crb[0]", "${prefix}-cluster-admin-crb", prefix+"-cluster-admin-crb", "")
+	}
+
+	return names, nil
+}
+
+// storageBackend mirrors local.storage_type_backend
+func storageBackend(storageType, backend string) string {
+	switch {
+	case storageType == "standard":
+		return "nfs"
+	case storageType == "ha" && backend == "ontap":
+		return "ontap"
+	case storageType == "ha":
+		return "efs"
+	}
+	return "none"
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"test/tfvars"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadInputs(t *testing.T, contents string) tfvars.Inputs {
+	path := filepath.Join(t.TempDir(), "input.tfvars")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	in, err := tfvars.LoadInputs("../..", path)
+	require.NoError(t, err)
+	return in
+}
+
+func TestDeriveDefaults(t *testing.T) {
+	t.Parallel()
+
+	in := loadInputs(t, `
+prefix = "viya"
+postgres_servers = { default = {} }
+`)
+	names, err := Derive(in)
+	require.NoError(t, err)
+
+	values := make(map[string]string)
+	for _, n := range names {
+		values[n.Resource] = n.Value
+	}
+
+	tests := map[string]struct {
+		resource string
+		expected string
+	}{
+		"clusterName":       {"module.eks.aws_eks_cluster.this[0]", "viya-eks"},
+		"nodeGroupRole":     {"module.eks.module.eks_managed_node_group[\"cas\"].aws_iam_role.this[0]", "viya-cas-eks-node-group"},
+		"launchTemplate":    {"module.eks.module.eks_managed_node_group[\"stateful\"].aws_launch_template.this[0]", "viya-eks-stateful-lt-"},
+		"rdsIdentifier":     {"module.postgresql[\"default\"].module.db_instance.aws_db_instance.this[0]", "viya-default-pgsql"},
+		"jumpKeyPair":       {"module.jump[0].aws_key_pair.admin", "viya-jump-admin"},
+		"nfsKeyPair":        {"module.nfs[0].aws_key_pair.admin", "viya-nfs-server-admin"},
+		"clusterRolePrefix": {"module.eks.aws_iam_role.this[0]", "viya-eks-cluster-"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, values[tc.resource])
+		})
+	}
+
+	assert.Empty(t, Validate(names))
+	// The cluster IAM role name_prefix "${prefix}-eks-cluster-" is limited to 38 characters
+	assert.Equal(t, 25, MaxPrefixLength(names))
+}
+
+func TestValidateViolations(t *testing.T) {
+	t.Parallel()
+
+	in := loadInputs(t, `
+prefix = "a-very-long-prefix-for-naming"
+storage_type         = "ha"
+storage_type_backend = "ontap"
+postgres_servers = { default = {}, cds- = {} }
+`)
+	names, err := Derive(in)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, v := range Validate(names) {
+		messages = append(messages, v.String())
+	}
+
+	assert.Contains(t, messages,
+		`module.eks.aws_iam_role.this[0]: IAM role name prefix "a-very-long-prefix-for-naming-eks-cluster-" is 42 characters, the limit is 38 (from ${cluster_name}-cluster- with prefix "a-very-long-prefix-for-naming"); prefix can be at most 25 characters`)
+	assert.Contains(t, messages,
+		`module.postgresql["cds-"].module.db_instance.aws_db_instance.this[0]: RDS DB instance identifier "a-very-long-prefix-for-naming-cds--pgsql" must not contain two consecutive hyphens (from lower("${prefix}-${key}-pgsql") with prefix "a-very-long-prefix-for-naming" and postgres_servers["cds-"])`)
+
+	for _, m := range messages {
+		assert.False(t, strings.HasPrefix(m, "aws_fsx_ontap_volume"), "the ONTAP volume name replaces hyphens: %s", m)
+	}
+}
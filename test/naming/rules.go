@@ -0,0 +1,125 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"regexp"
+	"strings"
+)
+
+// uniqueSuffixLength is the length of the suffix terraform appends to a name_prefix
+const uniqueSuffixLength = 26
+
+// A Rule holds the AWS or Kubernetes constraints for one kind of name
+type Rule struct {
+	Kind    string
+	MaxLen  int
+	Pattern *regexp.Regexp
+	Charset string
+	// Extra reports constraints a regular expression can not express clearly
+	Extra func(name string) string
+}
+
+var (
+	// prefixRule mirrors the validation of the prefix variable
+	prefixRule = Rule{
+		Kind:    "prefix",
+		MaxLen:  255,
+		Pattern: regexp.MustCompile(`^[a-z][-0-9a-z]*[0-9a-z]$`),
+		Charset: "lowercase letters, digits and hyphens, starting with a letter and not ending with a hyphen",
+	}
+	eksClusterName = Rule{
+		Kind:    "EKS cluster name",
+		MaxLen:  100,
+		Pattern: regexp.MustCompile(`^[0-9A-Za-z][A-Za-z0-9_-]*$`),
+		Charset: "letters, digits, hyphens and underscores, starting with a letter or digit",
+	}
+	eksNodeGroupNamePrefix = Rule{
+		Kind:    "EKS node group name prefix",
+		MaxLen:  63 - uniqueSuffixLength,
+		Pattern: regexp.MustCompile(`^[0-9A-Za-z][A-Za-z0-9_-]*$`),
+		Charset: "letters, digits, hyphens and underscores, starting with a letter or digit",
+	}
+	iamRoleName = Rule{
+		Kind:    "IAM role name",
+		MaxLen:  64,
+		Pattern: regexp.MustCompile(`^[\w+=,.@-]+$`),
+		Charset: "letters, digits and +=,.@_-",
+	}
+	iamRoleNamePrefix = Rule{
+		Kind:    "IAM role name prefix",
+		MaxLen:  64 - uniqueSuffixLength,
+		Pattern: iamRoleName.Pattern,
+		Charset: iamRoleName.Charset,
+	}
+	iamPolicyNamePrefix = Rule{
+		Kind:    "IAM policy name prefix",
+		MaxLen:  128 - uniqueSuffixLength,
+		Pattern: iamRoleName.Pattern,
+		Charset: iamRoleName.Charset,
+	}
+	launchTemplateNamePrefix = Rule{
+		Kind:    "launch template name prefix",
+		MaxLen:  125 - uniqueSuffixLength,
+		Pattern: regexp.MustCompile(`^[a-zA-Z0-9().\-/_]+$`),
+		Charset: "letters, digits and ().-/_",
+	}
+	rdsIdentifier = Rule{
+		Kind:    "RDS DB instance identifier",
+		MaxLen:  63,
+		Pattern: regexp.MustCompile(`^[a-z][a-z0-9-]*$`),
+		Charset: "lowercase letters, digits and hyphens, starting with a letter",
+		Extra: func(name string) string {
+			if strings.Contains(name, "--") {
+				return "must not contain two consecutive hyphens"
+			}
+			if strings.HasSuffix(name, "-") {
+				return "must not end with a hyphen"
+			}
+			return ""
+		},
+	}
+	ontapVolumeName = Rule{
+		Kind:    "FSx ONTAP volume name",
+		MaxLen:  203,
+		Pattern: regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`),
+		Charset: "letters, digits and underscores, starting with a letter or underscore",
+	}
+	ontapSVMName = Rule{
+		Kind:    "FSx ONTAP storage virtual machine name",
+		MaxLen:  47,
+		Pattern: regexp.MustCompile(`^[A-Za-z0-9_.-]+$`),
+		Charset: "letters, digits and ._-",
+	}
+	efsCreationToken = Rule{
+		Kind:    "EFS creation token",
+		MaxLen:  64,
+		Pattern: regexp.MustCompile(`^.+$`),
+		Charset: "any characters",
+	}
+	keyPairName = Rule{
+		Kind:    "EC2 key pair name",
+		MaxLen:  255,
+		Pattern: regexp.MustCompile(`^[\x20-\x7e]+$`),
+		Charset: "printable ASCII characters",
+	}
+	securityGroupName = Rule{
+		Kind:    "security group name",
+		MaxLen:  255,
+		Pattern: regexp.MustCompile(`^[a-zA-Z0-9 ._\-:/()#,@\[\]+=&;{}!$*]+$`),
+		Charset: "letters, digits, spaces and ._-:/()#,@[]+=&;{}!$*",
+	}
+	resourceGroupName = Rule{
+		Kind:    "resource group name",
+		MaxLen:  128,
+		Pattern: regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`),
+		Charset: "letters, digits and ._-",
+	}
+	kubernetesName = Rule{
+		Kind:    "Kubernetes object name",
+		MaxLen:  253,
+		Pattern: regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`),
+		Charset: "lowercase letters, digits, '-' and '.', starting and ending with a letter or digit",
+	}
+)
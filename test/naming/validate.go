@@ -0,0 +1,76 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a derived name that breaks the rules of its resource type
+type Violation struct {
+	Name   Name
+	Reason string
+}
+
+func (v Violation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s %q %s (from %s with prefix %q", v.Name.Resource, v.Name.Kind, v.Name.Value, v.Reason, v.Name.Template, v.Name.Prefix)
+	if v.Name.Input != "" {
+		fmt.Fprintf(&b, " and %s", v.Name.Input)
+	}
+	b.WriteString(")")
+	if limit := v.Name.MaxPrefixLength(); limit >= 0 && len(v.Name.Value) > v.Name.MaxLen {
+		if limit > 0 {
+			fmt.Fprintf(&b, "; prefix can be at most %d characters", limit)
+		} else {
+			b.WriteString("; shorten the key, no prefix fits")
+		}
+	}
+	return b.String()
+}
+
+// Validate checks each name against the length and character rules of its resource type
+// Character problems caused by an invalid prefix are only reported once, on the prefix itself.
+func Validate(names []Name) []Violation {
+	var violations []Violation
+	badPrefix := false
+	for _, n := range names {
+		if n.Kind == prefixRule.Kind && !n.Pattern.MatchString(n.Value) {
+			badPrefix = true
+		}
+	}
+	for _, n := range names {
+		if len(n.Value) > n.MaxLen {
+			violations = append(violations, Violation{
+				Name:   n,
+				Reason: fmt.Sprintf("is %d characters, the limit is %d", len(n.Value), n.MaxLen),
+			})
+		}
+		if !n.Pattern.MatchString(n.Value) && (n.Kind == prefixRule.Kind || !badPrefix || !n.usesPrefix()) {
+			violations = append(violations, Violation{
+				Name:   n,
+				Reason: "may only contain " + n.Charset,
+			})
+		}
+		if n.Extra != nil {
+			if reason := n.Extra(n.Value); reason != "" {
+				violations = append(violations, Violation{Name: n, Reason: reason})
+			}
+		}
+	}
+	return violations
+}
+
+// MaxPrefixLength returns the longest prefix that keeps every name within its
+// limit, given the node pool and server keys in use.
+func MaxPrefixLength(names []Name) int {
+	limit := -1
+	for _, n := range names {
+		if m := n.MaxPrefixLength(); m >= 0 && (limit < 0 || m < limit) {
+			limit = m
+		}
+	}
+	return limit
+}
@@ -0,0 +1,277 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nist verifies the NIST SP 800-53 oriented controls a plan is
+// expected to meet when enable_nist_features is set. The flag does not change
+// the planned resources yet, so the outcome depends on the other inputs.
+//
+// Each control is a set of policy rules. A control passes when none of its
+// rules report an error, and does not apply when the plan has no resources
+// its rules inspect, for example SC-8 without postgres_servers.
+//
+//	AU-2   EKS control plane logs the api, audit and authenticator log types
+//	SC-28  EBS volumes, EFS file systems and RDS storage are encrypted at rest
+//	AC-6   Instances and node launch templates require IMDSv2 with a hop limit of 1
+//	SC-7   The EKS API endpoint is private only
+//	AC-17  No security group allows SSH from anywhere
+//	SC-8   RDS parameter groups set rds.force_ssl = 1
+package nist
+
+import (
+	"fmt"
+	"strings"
+
+	"test/planfile"
+	"test/policy"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// Status of a control
+type Status string
+
+const (
+	Pass          Status = "pass"
+	Fail          Status = "fail"
+	NotApplicable Status = "n/a"
+)
+
+// AuditLogTypes are the EKS control plane log types AU-2 requires
+var AuditLogTypes = []string{"api", "audit", "authenticator"}
+
+// Control is a NIST control and the rules that verify it
+type Control struct {
+	ID    string
+	Title string
+	Rules []policy.Rule
+}
+
+// ControlResult is the outcome of a control
+type ControlResult struct {
+	ID        string             `json:"id"`
+	Title     string             `json:"title"`
+	Status    Status             `json:"status"`
+	Resources int                `json:"resources"`
+	Failures  []policy.Violation `json:"failures,omitempty"`
+}
+
+// Report is the control by control outcome for a plan
+type Report struct {
+	// NISTFeatures is the planned value of enable_nist_features
+	NISTFeatures bool            `json:"enableNistFeatures"`
+	Controls     []ControlResult `json:"controls"`
+}
+
+// Passed reports whether no control failed
+func (r *Report) Passed() bool {
+	for _, c := range r.Controls {
+		if c.Status == Fail {
+			return false
+		}
+	}
+	return true
+}
+
+// Statuses maps control IDs to their status
+func (r *Report) Statuses() map[string]Status {
+	statuses := map[string]Status{}
+	for _, c := range r.Controls {
+		statuses[c.ID] = c.Status
+	}
+	return statuses
+}
+
+// Controls returns the verified controls
+func Controls() []Control {
+	return []Control{
+		{
+			ID:    "AU-2",
+			Title: "EKS control plane audit logging",
+			Rules: []policy.Rule{{
+				ID:            "NIST-AU-2",
+				Description:   "EKS clusters log the " + strings.Join(AuditLogTypes, ", ") + " log types",
+				Severity:      policy.Error,
+				ResourceTypes: []string{"aws_eks_cluster"},
+				Check:         checkAuditLogs,
+			}},
+		},
+		{
+			ID:    "SC-28",
+			Title: "Encryption at rest",
+			Rules: builtin("IAC-ENC-001", "IAC-ENC-002", "IAC-ENC-003"),
+		},
+		{
+			ID:    "AC-6",
+			Title: "IMDSv2 with a hop limit of 1",
+			Rules: append(builtin("IAC-IMDS-001"), policy.Rule{
+				ID:            "NIST-AC-6",
+				Description:   "Instance metadata responses do not reach containers",
+				Severity:      policy.Error,
+				ResourceTypes: []string{"aws_instance", "aws_launch_template"},
+				Check:         checkHopLimit,
+			}),
+		},
+		{
+			ID:    "SC-7",
+			Title: "Private EKS API endpoint",
+			Rules: []policy.Rule{{
+				ID:            "NIST-SC-7",
+				Description:   "The EKS API endpoint is reachable from the VPC only",
+				Severity:      policy.Error,
+				ResourceTypes: []string{"aws_eks_cluster"},
+				Check:         checkPrivateEndpoint,
+			}},
+		},
+		{
+			ID:    "AC-17",
+			Title: "Restricted SSH access",
+			Rules: []policy.Rule{{
+				ID:            "NIST-AC-17",
+				Description:   "SSH ingress is limited to known CIDR ranges",
+				Severity:      policy.Error,
+				ResourceTypes: []string{"aws_vpc_security_group_ingress_rule"},
+				Check:         checkSSHIngress,
+			}},
+		},
+		{
+			ID:    "SC-8",
+			Title: "RDS SSL enforcement",
+			Rules: []policy.Rule{{
+				ID:            "NIST-SC-8",
+				Description:   "PostgreSQL parameter groups set rds.force_ssl = 1",
+				Severity:      policy.Error,
+				ResourceTypes: []string{"aws_db_parameter_group"},
+				Check:         checkForceSSL,
+			}},
+		},
+	}
+}
+
+// inspects reports whether any rule of the control applies to the resource type
+func (c Control) inspects(resourceType string) bool {
+	for _, r := range c.Rules {
+		for _, t := range r.ResourceTypes {
+			if t == resourceType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// builtin returns the built-in policy rules with the given IDs
+func builtin(ids ...string) []policy.Rule {
+	var rules []policy.Rule
+	for _, id := range ids {
+		for _, r := range policy.Builtin() {
+			if r.ID == id {
+				rules = append(rules, r)
+			}
+		}
+	}
+	return rules
+}
+
+// Check evaluates every control against the plan
+func Check(plan *terraform.PlanStruct) *Report {
+	ctx := &policy.Context{Plan: plan}
+	enabled, _ := ctx.Variable("enable_nist_features").(bool)
+	r := &Report{NISTFeatures: enabled}
+
+	resources := planfile.Resources(plan)
+	for _, control := range Controls() {
+		result := policy.Evaluate(plan, control.Rules, nil)
+		c := ControlResult{ID: control.ID, Title: control.Title, Status: Pass}
+		for _, resource := range resources {
+			if control.inspects(resource.Type) {
+				c.Resources++
+			}
+		}
+		for _, v := range result.Violations {
+			if v.Severity == policy.Error {
+				c.Failures = append(c.Failures, v)
+			}
+		}
+		switch {
+		case c.Resources == 0:
+			c.Status = NotApplicable
+		case len(c.Failures) > 0:
+			c.Status = Fail
+		}
+		r.Controls = append(r.Controls, c)
+	}
+	return r
+}
+
+func checkAuditLogs(_ *policy.Context, r planfile.Resource) []string {
+	enabled := map[string]bool{}
+	for _, t := range r.Values.Strings("enabled_cluster_log_types") {
+		enabled[t] = true
+	}
+	var missing []string
+	for _, t := range AuditLogTypes {
+		if !enabled[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		return []string{"enabled_cluster_log_types is missing " + strings.Join(missing, ", ") + ", set cluster_enabled_log_types"}
+	}
+	return nil
+}
+
+func checkHopLimit(_ *policy.Context, r planfile.Resource) []string {
+	options := r.Values.Blocks("metadata_options")
+	if len(options) == 0 {
+		// IAC-IMDS-001 reports the missing block
+		return nil
+	}
+	if limit := options[0].Number("http_put_response_hop_limit"); limit != 1 {
+		return []string{fmt.Sprintf("metadata_options.http_put_response_hop_limit is %g, expected 1", limit)}
+	}
+	return nil
+}
+
+func checkPrivateEndpoint(_ *policy.Context, r planfile.Resource) []string {
+	config := r.Values.Blocks("vpc_config")
+	if len(config) == 0 {
+		return []string{"vpc_config is not set"}
+	}
+	var messages []string
+	if config[0].Bool("endpoint_public_access") {
+		messages = append(messages, "endpoint_public_access is enabled, set cluster_api_mode = \"private\"")
+	}
+	if !config[0].Bool("endpoint_private_access") {
+		messages = append(messages, "endpoint_private_access is disabled")
+	}
+	return messages
+}
+
+func checkSSHIngress(_ *policy.Context, r planfile.Resource) []string {
+	protocol := r.Values.String("ip_protocol")
+	from, to := r.Values.Number("from_port"), r.Values.Number("to_port")
+	if protocol != "-1" && (protocol != "tcp" || from > 22 || to < 22) {
+		return nil
+	}
+	for _, attr := range []string{"cidr_ipv4", "cidr_ipv6"} {
+		if cidr := r.Values.String(attr); cidr == "0.0.0.0/0" || cidr == "::/0" {
+			return []string{fmt.Sprintf("%s %s allows SSH from anywhere", attr, cidr)}
+		}
+	}
+	return nil
+}
+
+func checkForceSSL(_ *policy.Context, r planfile.Resource) []string {
+	if !strings.HasPrefix(r.Values.String("family"), "postgres") {
+		return nil
+	}
+	for _, p := range r.Values.Blocks("parameter") {
+		if p.String("name") == "rds.force_ssl" {
+			if p.String("value") != "1" {
+				return []string{"rds.force_ssl is " + p.String("value") + ", set ssl_enforcement_enabled in postgres_servers"}
+			}
+			return nil
+		}
+	}
+	return []string{"rds.force_ssl is not set"}
+}
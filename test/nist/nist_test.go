@@ -0,0 +1,186 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nist
+
+import (
+	"bytes"
+	"testing"
+
+	"test/report"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type values = map[string]interface{}
+
+type list = []interface{}
+
+type resource struct {
+	Type   string
+	Values values
+}
+
+const (
+	cluster        = "module.eks.aws_eks_cluster.this[0]"
+	launchTemplate = `module.eks.module.eks_managed_node_group["default"].aws_launch_template.this[0]`
+	jump           = "module.jump[0].aws_instance.vm"
+	sshRule        = `aws_vpc_security_group_ingress_rule.vms["10.0.0.0/16"]`
+	parameterGroup = `module.postgresql["default"].module.db_parameter_group.aws_db_parameter_group.this[0]`
+	database       = `module.postgresql["default"].module.db_instance.aws_db_instance.this[0]`
+)
+
+// compliant returns the resources of a plan that passes every control
+func compliant() map[string]resource {
+	return map[string]resource{
+		cluster: {"aws_eks_cluster", values{
+			"enabled_cluster_log_types": list{"api", "audit", "authenticator"},
+			"vpc_config":                list{values{"endpoint_private_access": true, "endpoint_public_access": false}},
+		}},
+		launchTemplate: {"aws_launch_template", values{
+			"metadata_options":      list{values{"http_tokens": "required", "http_put_response_hop_limit": float64(1)}},
+			"block_device_mappings": list{values{"device_name": "/dev/xvda", "ebs": list{values{"encrypted": true}}}},
+		}},
+		jump: {"aws_instance", values{
+			"metadata_options":  list{values{"http_tokens": "required", "http_put_response_hop_limit": float64(1)}},
+			"root_block_device": list{values{"encrypted": true}},
+		}},
+		sshRule: {"aws_vpc_security_group_ingress_rule", values{
+			"cidr_ipv4": "10.0.0.0/16", "ip_protocol": "tcp", "from_port": float64(22), "to_port": float64(22),
+		}},
+		parameterGroup: {"aws_db_parameter_group", values{
+			"family":    "postgres16",
+			"parameter": list{values{"name": "rds.force_ssl", "value": "1", "apply_method": "immediate"}},
+		}},
+		database: {"aws_db_instance", values{"storage_encrypted": true}},
+	}
+}
+
+func testPlan(resources map[string]resource) *terraform.PlanStruct {
+	plan := &terraform.PlanStruct{
+		ResourcePlannedValuesMap: map[string]*tfjson.StateResource{},
+		RawPlan: tfjson.Plan{Variables: map[string]*tfjson.PlanVariable{
+			"enable_nist_features": {Value: true},
+		}},
+	}
+	for address, r := range resources {
+		plan.ResourcePlannedValuesMap[address] = &tfjson.StateResource{
+			Address:         address,
+			Mode:            tfjson.ManagedResourceMode,
+			Type:            r.Type,
+			AttributeValues: r.Values,
+		}
+	}
+	return plan
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		change   func(map[string]resource)
+		expected map[string]Status
+		failure  string
+	}{
+		"compliant": {
+			change:   func(map[string]resource) {},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": Pass},
+		},
+		"auditLogs": {
+			change: func(r map[string]resource) {
+				r[cluster].Values["enabled_cluster_log_types"] = list{"api"}
+			},
+			expected: map[string]Status{"AU-2": Fail, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": Pass},
+			failure:  "enabled_cluster_log_types is missing audit, authenticator, set cluster_enabled_log_types",
+		},
+		"unencryptedDatabase": {
+			change: func(r map[string]resource) {
+				r[database].Values["storage_encrypted"] = false
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Fail, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": Pass},
+			failure:  "storage encryption is disabled, set storage_encrypted in postgres_servers",
+		},
+		"hopLimit": {
+			change: func(r map[string]resource) {
+				r[launchTemplate].Values["metadata_options"] = list{values{"http_tokens": "required", "http_put_response_hop_limit": float64(2)}}
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Fail, "SC-7": Pass, "AC-17": Pass, "SC-8": Pass},
+			failure:  "metadata_options.http_put_response_hop_limit is 2, expected 1",
+		},
+		"publicEndpoint": {
+			change: func(r map[string]resource) {
+				r[cluster].Values["vpc_config"] = list{values{"endpoint_private_access": true, "endpoint_public_access": true}}
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Fail, "AC-17": Pass, "SC-8": Pass},
+			failure:  `endpoint_public_access is enabled, set cluster_api_mode = "private"`,
+		},
+		"openSSH": {
+			change: func(r map[string]resource) {
+				r[sshRule] = resource{"aws_vpc_security_group_ingress_rule", values{"cidr_ipv4": "0.0.0.0/0", "ip_protocol": "-1"}}
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Fail, "SC-8": Pass},
+			failure:  "cidr_ipv4 0.0.0.0/0 allows SSH from anywhere",
+		},
+		"openHTTPS": {
+			change: func(r map[string]resource) {
+				r[sshRule] = resource{"aws_vpc_security_group_ingress_rule", values{"cidr_ipv4": "0.0.0.0/0", "ip_protocol": "tcp", "from_port": float64(443), "to_port": float64(443)}}
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": Pass},
+		},
+		"sslDisabled": {
+			change: func(r map[string]resource) {
+				r[parameterGroup].Values["parameter"] = list{values{"name": "rds.force_ssl", "value": "0"}}
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": Fail},
+			failure:  "rds.force_ssl is 0, set ssl_enforcement_enabled in postgres_servers",
+		},
+		"noDatabase": {
+			change: func(r map[string]resource) {
+				delete(r, parameterGroup)
+				delete(r, database)
+			},
+			expected: map[string]Status{"AU-2": Pass, "SC-28": Pass, "AC-6": Pass, "SC-7": Pass, "AC-17": Pass, "SC-8": NotApplicable},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			resources := compliant()
+			tc.change(resources)
+			r := Check(testPlan(resources))
+			assert.True(t, r.NISTFeatures)
+			assert.Equal(t, tc.expected, r.Statuses())
+
+			var failures []string
+			for _, c := range r.Controls {
+				for _, v := range c.Failures {
+					failures = append(failures, v.Message)
+				}
+			}
+			if tc.failure == "" {
+				assert.Empty(t, failures)
+				assert.True(t, r.Passed())
+			} else {
+				assert.Equal(t, []string{tc.failure}, failures)
+				assert.False(t, r.Passed())
+			}
+		})
+	}
+}
+
+func TestTables(t *testing.T) {
+	t.Parallel()
+
+	resources := compliant()
+	delete(resources[jump].Values, "metadata_options")
+	r := Check(testPlan(resources))
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out, report.Markdown, r, r.Tables()...))
+	assert.Contains(t, out.String(), "### NIST controls (enable_nist_features = true)")
+	assert.Contains(t, out.String(), "| AC-6 | IMDSv2 with a hop limit of 1 | fail | 2 | 1 |")
+	assert.Contains(t, out.String(), "| AC-6 | module.jump[0].aws_instance.vm | metadata_options is not set")
+}
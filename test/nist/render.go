@@ -0,0 +1,39 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nist
+
+import (
+	"fmt"
+	"strconv"
+
+	"test/report"
+)
+
+// Tables renders the controls and the failures behind each failed control
+func (r *Report) Tables() []*report.Table {
+	controls := &report.Table{
+		Title: fmt.Sprintf("NIST controls (enable_nist_features = %t)", r.NISTFeatures),
+		Columns: []report.Column{
+			{Header: "Control"},
+			{Header: "Title"},
+			{Header: "Status"},
+			{Header: "Resources", Right: true},
+			{Header: "Failures", Right: true},
+		},
+	}
+	failures := &report.Table{
+		Title:   "Failures",
+		Columns: []report.Column{{Header: "Control"}, {Header: "Address"}, {Header: "Message"}},
+	}
+	for _, c := range r.Controls {
+		controls.AddRow(c.ID, c.Title, string(c.Status), strconv.Itoa(c.Resources), strconv.Itoa(len(c.Failures)))
+		for _, v := range c.Failures {
+			failures.AddRow(c.ID, v.Address, v.Message)
+		}
+	}
+	if len(failures.Rows) == 0 {
+		return []*report.Table{controls}
+	}
+	return []*report.Table{controls, failures}
+}
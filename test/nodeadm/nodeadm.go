@@ -0,0 +1,337 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nodeadm builds and parses the user data of the node groups: a MIME
+// multipart document, like the eks module renders cloudinit_pre_nodeadm, with
+// a nodeadm NodeConfig that passes the node labels and taints to the kubelet,
+// followed by the optional custom_data shell script.
+package nodeadm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion is the NodeConfig API version of nodeadm
+	APIVersion = "node.eks.aws/v1alpha1"
+	// Kind is the kind of a NodeConfig
+	Kind = "NodeConfig"
+
+	// NodeConfigContentType is the MIME type nodeadm reads a NodeConfig from
+	NodeConfigContentType = "application/node.eks.aws"
+	// ShellScriptContentType is the MIME type of custom_data in locals.tf
+	ShellScriptContentType = `text/x-shellscript; charset="us-ascii"`
+
+	// Boundary is the MIME boundary the eks module renders the user data with
+	Boundary = "MIMEBOUNDARY"
+)
+
+const (
+	// LabelsFlag is the kubelet flag of the node labels
+	LabelsFlag = "--node-labels"
+	// TaintsFlag is the kubelet flag of the node taints
+	TaintsFlag = "--register-with-taints"
+)
+
+// NodeConfig is the nodeadm configuration of a node. Only the fields nodeadm
+// knows are decoded, anything else fails ParseNodeConfig.
+type NodeConfig struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Spec       NodeConfigSpec         `json:"spec"`
+}
+
+// NodeConfigSpec is the spec of a NodeConfig
+type NodeConfigSpec struct {
+	Cluster    *ClusterDetails    `json:"cluster,omitempty"`
+	Containerd *ContainerdOptions `json:"containerd,omitempty"`
+	Instance   *InstanceOptions   `json:"instance,omitempty"`
+	Kubelet    KubeletOptions     `json:"kubelet"`
+}
+
+// ClusterDetails are the details of the cluster, EKS fills them in for
+// managed node groups
+type ClusterDetails struct {
+	Name                 string `json:"name,omitempty"`
+	APIServerEndpoint    string `json:"apiServerEndpoint,omitempty"`
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	CIDR                 string `json:"cidr,omitempty"`
+	EnableOutpost        *bool  `json:"enableOutpost,omitempty"`
+	ID                   string `json:"id,omitempty"`
+}
+
+// ContainerdOptions configure containerd
+type ContainerdOptions struct {
+	Config          string                 `json:"config,omitempty"`
+	BaseRuntimeSpec map[string]interface{} `json:"baseRuntimeSpec,omitempty"`
+}
+
+// InstanceOptions configure the instance
+type InstanceOptions struct {
+	LocalStorage LocalStorageOptions `json:"localStorage"`
+}
+
+// LocalStorageOptions configure the instance store volumes
+type LocalStorageOptions struct {
+	// Strategy is RAID0, RAID10 or Mount
+	Strategy       string   `json:"strategy,omitempty"`
+	MountPath      string   `json:"mountPath,omitempty"`
+	DisabledMounts []string `json:"disabledMounts,omitempty"`
+}
+
+// KubeletOptions configure the kubelet
+type KubeletOptions struct {
+	Config map[string]interface{} `json:"config,omitempty"`
+	Flags  []string               `json:"flags,omitempty"`
+}
+
+// Part is a part of the user data
+type Part struct {
+	ContentType string
+	Content     string
+}
+
+// UserData is the MIME multipart user data of a launch template
+type UserData struct {
+	Parts []Part
+}
+
+// labelsValue returns the value of the node labels flag, sorted by key like
+// the for expression in locals.tf. The kubelet splits the flag at commas and
+// each label at its first =, so neither may appear in a key, nor a comma in a
+// value.
+func labelsValue(labels map[string]string) (string, error) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "" || strings.ContainsAny(k, ",=") || strings.Contains(labels[k], ",") {
+			return "", fmt.Errorf("label %q=%q cannot be passed in %s, which separates labels with , and keys from values with =", k, labels[k], LabelsFlag)
+		}
+		entries[i] = k + "=" + labels[k]
+	}
+	return strings.Join(entries, ","), nil
+}
+
+// Build returns the user data locals.tf gives a node pool with labels, taints
+// in the key=value:Effect form of node_taints, and an optional shell script
+func Build(labels map[string]string, taints []string, script string) (*UserData, error) {
+	labelsFlag, err := labelsValue(labels)
+	if err != nil {
+		return nil, err
+	}
+	for _, taint := range taints {
+		if strings.Contains(taint, ",") {
+			return nil, fmt.Errorf("taint %q cannot be passed in %s, which separates taints with ,", taint, TaintsFlag)
+		}
+	}
+
+	config := NodeConfig{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Spec: NodeConfigSpec{
+			Kubelet: KubeletOptions{
+				Flags: []string{
+					LabelsFlag + "=" + labelsFlag,
+					TaintsFlag + "=" + strings.Join(taints, ","),
+				},
+			},
+		},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &UserData{Parts: []Part{{ContentType: NodeConfigContentType, Content: "---\n" + string(data)}}}
+	if script != "" {
+		u.Parts = append(u.Parts, Part{ContentType: ShellScriptContentType, Content: script})
+	}
+	return u, nil
+}
+
+// Marshal renders the user data as MIME multipart, like the cloudinit_config
+// data source of the eks module
+func (u *UserData) Marshal() ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.SetBoundary(Boundary); err != nil {
+		return nil, err
+	}
+	for _, p := range u.Parts {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Transfer-Encoding": {"7bit"},
+			"Content-Type":              {p.ContentType},
+			"Mime-Version":              {"1.0"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(part, p.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", Boundary)
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+// Base64 returns the user data the way the launch template holds it
+func (u *UserData) Base64() (string, error) {
+	data, err := u.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decode parses user data, base64 encoded and gzipped or not, as the launch
+// template user_data attribute holds it
+func Decode(userData string) (*UserData, error) {
+	data := []byte(strings.TrimSpace(userData))
+	if decoded, err := base64.StdEncoding.DecodeString(string(data)); err == nil {
+		data = decoded
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	return Parse(data)
+}
+
+// Parse parses MIME multipart user data
+func Parse(data []byte) (*UserData, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("user data is not MIME: %w", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("user data Content-Type: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("user data is %s, not multipart", mediaType)
+	}
+
+	u := &UserData{}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return u, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("user data part %d: %w", len(u.Parts)+1, err)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("user data part %d: %w", len(u.Parts)+1, err)
+		}
+		u.Parts = append(u.Parts, Part{ContentType: part.Header.Get("Content-Type"), Content: string(content)})
+	}
+}
+
+// NodeConfigs parses the NodeConfig parts of the user data, nodeadm merges
+// them in order
+func (u *UserData) NodeConfigs() ([]*NodeConfig, error) {
+	var configs []*NodeConfig
+	for i, p := range u.Parts {
+		if mediaType, _, _ := mime.ParseMediaType(p.ContentType); mediaType != NodeConfigContentType {
+			continue
+		}
+		config, err := ParseNodeConfig([]byte(p.Content))
+		if err != nil {
+			return nil, fmt.Errorf("user data part %d: %w", i+1, err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// Scripts returns the shell scripts of the user data
+func (u *UserData) Scripts() []string {
+	var scripts []string
+	for _, p := range u.Parts {
+		if mediaType, _, _ := mime.ParseMediaType(p.ContentType); mediaType == "text/x-shellscript" {
+			scripts = append(scripts, p.Content)
+		}
+	}
+	return scripts
+}
+
+// ParseNodeConfig parses a NodeConfig document, failing on fields nodeadm
+// does not know
+func ParseNodeConfig(data []byte) (*NodeConfig, error) {
+	var config NodeConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("NodeConfig: %w", err)
+	}
+	return &config, nil
+}
+
+// Flag returns the value of the last occurrence of a kubelet flag, as the
+// kubelet reads it
+func (c *NodeConfig) Flag(name string) (string, bool) {
+	value, found := "", false
+	for _, flag := range c.Spec.Kubelet.Flags {
+		if flag == name {
+			value, found = "", true
+		} else if v, ok := strings.CutPrefix(flag, name+"="); ok {
+			value, found = v, true
+		}
+	}
+	return value, found
+}
+
+// Labels returns the node labels of the kubelet flags
+func (c *NodeConfig) Labels() (map[string]string, error) {
+	labels := map[string]string{}
+	value, _ := c.Flag(LabelsFlag)
+	if value == "" {
+		return labels, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(entry, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%s: %q is not key=value", LabelsFlag, entry)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+// Taints returns the node taints of the kubelet flags, in the key=value:Effect
+// form of node_taints
+func (c *NodeConfig) Taints() []string {
+	value, _ := c.Flag(TaintsFlag)
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,242 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeadm
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"test/tfvars"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		labels map[string]string
+		taints []string
+		script string
+		// invalid labels round-trip, though the kubelet rejects them
+		invalid bool
+	}{
+		"default pool": {
+			labels: map[string]string{"kubernetes.azure.com/mode": "system"},
+			taints: []string{},
+		},
+		"cas with custom data": {
+			labels: map[string]string{"workload.sas.com/class": "cas"},
+			taints: []string{"workload.sas.com/class=cas:NoSchedule"},
+			script: "#!/bin/bash\necho custom data\n",
+		},
+		"several labels and taints": {
+			labels: map[string]string{"workload.sas.com/class": "compute", "launcher.sas.com/prepullImage": "sas-programming-environment", "empty": ""},
+			taints: []string{"workload.sas.com/class=compute:NoSchedule", "dedicated:PreferNoSchedule"},
+		},
+		"values with = and quotes": {
+			labels:  map[string]string{"example.com/selector": `a=b`, "example.com/quoted": `"q"`},
+			taints:  []string{"example.com/key=v:NoExecute"},
+			invalid: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			built, err := Build(tc.labels, tc.taints, tc.script)
+			require.NoError(t, err)
+			userData, err := built.Base64()
+			require.NoError(t, err)
+
+			decoded, err := Decode(userData)
+			require.NoError(t, err)
+			configs, err := decoded.NodeConfigs()
+			require.NoError(t, err)
+			require.Len(t, configs, 1)
+			assert.Equal(t, tc.invalid, len(configs[0].Validate()) > 0)
+
+			labels, err := configs[0].Labels()
+			require.NoError(t, err)
+			assert.Equal(t, tc.labels, labels)
+			assert.Equal(t, tc.taints, configs[0].Taints())
+			if tc.script == "" {
+				assert.Empty(t, decoded.Scripts())
+			} else {
+				assert.Equal(t, []string{tc.script}, decoded.Scripts())
+			}
+		})
+	}
+}
+
+func TestBuildRejectsSeparators(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		labels map[string]string
+		taints []string
+	}{
+		"comma in label value": {labels: map[string]string{"example.com/list": "a,b"}},
+		"= in label key":       {labels: map[string]string{"a=b": "c"}},
+		"empty label key":      {labels: map[string]string{"": "c"}},
+		"comma in taint":       {taints: []string{"a=b:NoSchedule,c=d:NoSchedule"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Build(tc.labels, tc.taints, "")
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestDecodeModuleUserData decodes user data laid out like the eks module
+// renders it from the cloudinit_pre_nodeadm of locals.tf
+func TestDecodeModuleUserData(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/cas-user-data.b64")
+	require.NoError(t, err)
+	userData, err := Decode(string(data))
+	require.NoError(t, err)
+	require.Len(t, userData.Parts, 2)
+	assert.Equal(t, NodeConfigContentType, userData.Parts[0].ContentType)
+	assert.Equal(t, ShellScriptContentType, userData.Parts[1].ContentType)
+	assert.Equal(t, []string{"#!/bin/bash\necho \"custom data\"\n"}, userData.Scripts())
+
+	configs, err := userData.NodeConfigs()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Empty(t, configs[0].Validate())
+	labels, err := configs[0].Labels()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"workload.sas.com/class": "cas"}, labels)
+	assert.Equal(t, []string{"workload.sas.com/class=cas:NoSchedule"}, configs[0].Taints())
+
+	// a rebuilt user data renders the same flags
+	built, err := Build(labels, configs[0].Taints(), userData.Scripts()[0])
+	require.NoError(t, err)
+	rebuilt, err := built.NodeConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, configs[0].Spec.Kubelet.Flags, rebuilt[0].Spec.Kubelet.Flags)
+}
+
+func TestDecodeNotMultipart(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode("#!/bin/bash\necho hello\n")
+	assert.ErrorContains(t, err, "user data is not MIME")
+	_, err = Parse([]byte("Content-Type: text/x-shellscript\r\n\r\necho hello\r\n"))
+	assert.EqualError(t, err, "user data is text/x-shellscript, not multipart")
+}
+
+func TestParseNodeConfigUnknownField(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseNodeConfig([]byte("apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  kubelet:\n    flag:\n      - --node-labels=a=b\n"))
+	assert.ErrorContains(t, err, `unknown field "flag"`)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config   string
+		expected []string
+	}{
+		"api version and kind": {
+			config:   "apiVersion: node.eks.aws/v1beta2\nkind: NodeConfiguration\nspec: {}\n",
+			expected: []string{`apiVersion: is "node.eks.aws/v1beta2", nodeadm reads node.eks.aws/v1alpha1`, `kind: is "NodeConfiguration", not NodeConfig`},
+		},
+		"flags": {
+			config: "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  kubelet:\n    flags:\n      - node-labels=a=b\n      - --max-pods=110\n      - --max-pods=58\n",
+			expected: []string{
+				`spec.kubelet.flags[0]: "node-labels=a=b" is not a --name=value flag`,
+				"spec.kubelet.flags[2]: --max-pods is repeated, the kubelet only keeps the last one",
+			},
+		},
+		"jsonencode labels": {
+			config:   "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  kubelet:\n    flags:\n      - --node-labels=workload.sas.com/class=cas,broken\n",
+			expected: []string{`spec.kubelet.flags: --node-labels: "broken" is not key=value`},
+		},
+		"label grammar": {
+			config: "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  kubelet:\n    flags:\n      - --node-labels=Example.com/class=cas,workload.sas.com/class=a:b\n",
+			expected: []string{
+				`spec.kubelet.flags: --node-labels: key "Example.com/class" prefix "Example.com" must be a DNS subdomain: lowercase alphanumerics, '-' and '.', starting and ending with an alphanumeric`,
+				`spec.kubelet.flags: --node-labels: value "a:b" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+			},
+		},
+		"taints": {
+			config: "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  kubelet:\n    flags:\n      - --register-with-taints=a=b,=c:NoSchedule,d=e:NO_SCHEDULE\n",
+			expected: []string{
+				`spec.kubelet.flags: --register-with-taints: taint "a=b" has no effect, expected key=value:Effect`,
+				`spec.kubelet.flags: --register-with-taints: taint "=c:NoSchedule": key is empty`,
+				`spec.kubelet.flags: --register-with-taints: taint "d=e:NO_SCHEDULE" has effect "NO_SCHEDULE", expected NoSchedule, PreferNoSchedule or NoExecute`,
+			},
+		},
+		"cluster and instance": {
+			config: "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  cluster:\n    apiServerEndpoint: http://example.com\n    certificateAuthority: not base64!\n    cidr: 10.100.0.0\n  instance:\n    localStorage:\n      strategy: RAID5\n",
+			expected: []string{
+				`spec.cluster.apiServerEndpoint: "http://example.com" is not an https URL`,
+				"spec.cluster.certificateAuthority: is not base64: illegal base64 data at input byte 3",
+				`spec.cluster.cidr: "10.100.0.0" is not a CIDR`,
+				`spec.instance.localStorage.strategy: is "RAID5", expected RAID0, RAID10 or Mount`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseNodeConfig([]byte(tc.config))
+			require.NoError(t, err)
+			var problems []string
+			for _, p := range config.Validate() {
+				problems = append(problems, p.String())
+			}
+			assert.Equal(t, tc.expected, problems)
+		})
+	}
+}
+
+// TestLabelValidation evaluates the validation blocks of the label variables in
+// variables.tf, so terraform plan rejects the labels the kubelet rejects in
+// --node-labels
+func TestLabelValidation(t *testing.T) {
+	t.Parallel()
+
+	variables, err := tfvars.LoadVariables("../..")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		key, value string
+		valid      bool
+	}{
+		"default":              {"kubernetes.azure.com/mode", "system", true},
+		"empty value":          {"dedicated", "", true},
+		"prefixed":             {"workload.sas.com/class", "cas", true},
+		"long name":            {strings.Repeat("a", 63), "v", true},
+		"too long name":        {strings.Repeat("a", 64), "v", false},
+		"long prefix":          {strings.Repeat("a", 253) + "/b", "v", true},
+		"too long prefix":      {strings.Repeat("a", 254) + "/b", "v", false},
+		"uppercase prefix":     {"SAS.com/class", "cas", false},
+		"comma in value":       {"launcher.sas.com/prepullImage", "sas-programming-environment,sas-studio", false},
+		"colon in value":       {"example.com/port", "a:b", false},
+		"quote in value":       {"example.com/quoted", `"q"`, false},
+		"= in key":             {"a=b", "c", false},
+		"too long value":       {"example.com/value", strings.Repeat("v", 64), false},
+		"value ends with dash": {"example.com/value", "cas-", false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			labels := cty.MapVal(map[string]cty.Value{tc.key: cty.StringVal(tc.value)})
+			valid, err := variables["default_nodepool_labels"].Valid(labels)
+			require.NoError(t, err)
+			assert.Equal(t, tc.valid, valid, "default_nodepool_labels")
+
+			pools := cty.MapVal(map[string]cty.Value{"cas": cty.ObjectVal(map[string]cty.Value{"node_labels": labels})})
+			valid, err = variables["node_pools"].Valid(pools)
+			require.NoError(t, err)
+			assert.Equal(t, tc.valid, valid, "node_pools")
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeadm
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"test/nodepool"
+)
+
+// Problem is a NodeConfig value nodeadm or the kubelet rejects
+type Problem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Field, p.Message)
+}
+
+// localStorageStrategies are the instance store strategies of nodeadm
+var localStorageStrategies = map[string]bool{
+	"RAID0":  true,
+	"RAID10": true,
+	"Mount":  true,
+}
+
+var flagPattern = regexp.MustCompile(`^--[A-Za-z0-9][A-Za-z0-9-]*(=.*)?$`)
+
+// Validate checks a NodeConfig against the nodeadm schema, and the label and
+// taint flags against the forms the kubelet parses
+func (c *NodeConfig) Validate() []Problem {
+	var problems []Problem
+	add := func(field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if c.APIVersion != APIVersion {
+		add("apiVersion", "is %q, nodeadm reads %s", c.APIVersion, APIVersion)
+	}
+	if c.Kind != Kind {
+		add("kind", "is %q, not %s", c.Kind, Kind)
+	}
+
+	if cluster := c.Spec.Cluster; cluster != nil {
+		if cluster.APIServerEndpoint != "" {
+			if u, err := url.Parse(cluster.APIServerEndpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+				add("spec.cluster.apiServerEndpoint", "%q is not an https URL", cluster.APIServerEndpoint)
+			}
+		}
+		if cluster.CertificateAuthority != "" {
+			if _, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthority); err != nil {
+				add("spec.cluster.certificateAuthority", "is not base64: %v", err)
+			}
+		}
+		if cluster.CIDR != "" {
+			if _, _, err := net.ParseCIDR(cluster.CIDR); err != nil {
+				add("spec.cluster.cidr", "%q is not a CIDR", cluster.CIDR)
+			}
+		}
+	}
+	if instance := c.Spec.Instance; instance != nil {
+		if s := instance.LocalStorage.Strategy; s != "" && !localStorageStrategies[s] {
+			add("spec.instance.localStorage.strategy", "is %q, expected RAID0, RAID10 or Mount", s)
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, flag := range c.Spec.Kubelet.Flags {
+		field := fmt.Sprintf("spec.kubelet.flags[%d]", i)
+		if !flagPattern.MatchString(flag) {
+			add(field, "%q is not a --name=value flag", flag)
+			continue
+		}
+		name, _, _ := strings.Cut(flag, "=")
+		if seen[name] {
+			add(field, "%s is repeated, the kubelet only keeps the last one", name)
+		}
+		seen[name] = true
+	}
+
+	labels, err := c.Labels()
+	if err != nil {
+		add("spec.kubelet.flags", "%v", err)
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := nodepool.ValidateLabel(k, labels[k]); err != nil {
+			add("spec.kubelet.flags", "%s: %v", LabelsFlag, err)
+		}
+	}
+	for _, taint := range c.Taints() {
+		if _, err := nodepool.ParseTaint(taint); err != nil {
+			add("spec.kubelet.flags", "%s: %v", TaintsFlag, err)
+		}
+	}
+	return problems
+}
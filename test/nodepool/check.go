@@ -0,0 +1,110 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodepool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"test/tfvars"
+)
+
+// Problem is a malformed label or taint of a node pool
+type Problem struct {
+	// Pool is the node pool name, default for the default_nodepool_* variables
+	Pool string
+	// Variable is the entry of the inputs, like node_pools["cas"].node_taints[0]
+	Variable string
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Variable, p.Message)
+}
+
+// pool is the labels and taints of a node pool and the variables they come
+// from
+type pool struct {
+	name, labelsVariable, taintsVariable string
+	labels                               map[string]string
+	taints                               []string
+}
+
+// Check reports the malformed labels and taints of the default node pool and
+// of every node_pools entry. Besides the Kubernetes grammar, locals.tf needs
+// each taint to have a value, it splits it at = and :.
+func Check(in tfvars.Inputs) ([]Problem, error) {
+	def := pool{
+		name:           tfvars.DefaultNodePoolName,
+		labelsVariable: "default_nodepool_labels",
+		taintsVariable: "default_nodepool_taints",
+	}
+	if err := in.Decode("default_nodepool_taints", &def.taints); err != nil {
+		return nil, err
+	}
+	if err := in.Decode("default_nodepool_labels", &def.labels); err != nil {
+		return nil, err
+	}
+	pools := []pool{def}
+
+	// the default_nodepool_* variables are checked even when a node_pools entry
+	// replaces the default pool, locals.tf evaluates them all the same
+	var nodePools map[string]tfvars.NodePool
+	if err := in.Decode("node_pools", &nodePools); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(nodePools))
+	for name := range nodePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		np := nodePools[name]
+		pools = append(pools, pool{
+			name:           name,
+			labelsVariable: fmt.Sprintf("node_pools[%q].node_labels", name),
+			taintsVariable: fmt.Sprintf("node_pools[%q].node_taints", name),
+			labels:         np.NodeLabels,
+			taints:         np.NodeTaints,
+		})
+	}
+
+	var problems []Problem
+	for _, p := range pools {
+		problems = append(problems, p.check()...)
+	}
+	return problems, nil
+}
+
+func (p pool) check() []Problem {
+	var problems []Problem
+	add := func(variable, format string, args ...interface{}) {
+		problems = append(problems, Problem{Pool: p.name, Variable: variable, Message: fmt.Sprintf(format, args...)})
+	}
+
+	keys := make([]string, 0, len(p.labels))
+	for k := range p.labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := ValidateLabel(k, p.labels[k]); err != nil {
+			add(fmt.Sprintf("%s[%q]", p.labelsVariable, k), "%v", err)
+		}
+	}
+
+	for i, s := range p.taints {
+		variable := fmt.Sprintf("%s[%d]", p.taintsVariable, i)
+		t, err := ParseTaint(s)
+		if err != nil {
+			add(variable, "%v", err)
+			continue
+		}
+		if !strings.Contains(s, "=") {
+			add(variable, "taint %q has no value, locals.tf needs key=value:Effect, use %s=:%s for an empty value", s, t.Key, t.Effect)
+		}
+	}
+	return problems
+}
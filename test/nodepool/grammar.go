@@ -0,0 +1,83 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nodepool implements the Kubernetes grammar of the node labels and
+// taints of the node pools, converts taints to the representation of EKS
+// managed node groups, and reports which pool and entry of the inputs is
+// malformed before locals.tf fails on it with an index error.
+package nodepool
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxNameLength is the longest name part of a qualified name, and the
+	// longest label value
+	MaxNameLength = 63
+	// MaxPrefixLength is the longest DNS subdomain prefix of a qualified name
+	MaxPrefixLength = 253
+)
+
+var (
+	namePattern      = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	subdomainPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateKey checks a label or taint key: a name of at most 63 alphanumerics,
+// '-', '_' or '.' starting and ending with an alphanumeric, optionally
+// prefixed by a DNS subdomain and a /
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("key is empty")
+	}
+	prefix, name, prefixed := strings.Cut(key, "/")
+	if !prefixed {
+		prefix, name = "", key
+	}
+	if prefixed {
+		switch {
+		case strings.Contains(name, "/"):
+			return fmt.Errorf("key %q has more than one /", key)
+		case prefix == "":
+			return fmt.Errorf("key %q has an empty prefix before the /", key)
+		case len(prefix) > MaxPrefixLength:
+			return fmt.Errorf("key %q prefix is %d characters, at most %d are allowed", key, len(prefix), MaxPrefixLength)
+		case !subdomainPattern.MatchString(prefix):
+			return fmt.Errorf("key %q prefix %q must be a DNS subdomain: lowercase alphanumerics, '-' and '.', starting and ending with an alphanumeric", key, prefix)
+		}
+	}
+	switch {
+	case name == "":
+		return fmt.Errorf("key %q has an empty name after the /", key)
+	case len(name) > MaxNameLength:
+		return fmt.Errorf("key %q name is %d characters, at most %d are allowed", key, len(name), MaxNameLength)
+	case !namePattern.MatchString(name):
+		return fmt.Errorf("key %q name %q must consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric", key, name)
+	}
+	return nil
+}
+
+// ValidateValue checks a label or taint value: empty, or at most 63
+// alphanumerics, '-', '_' or '.' starting and ending with an alphanumeric
+func ValidateValue(value string) error {
+	switch {
+	case value == "":
+		return nil
+	case len(value) > MaxNameLength:
+		return fmt.Errorf("value %q is %d characters, at most %d are allowed", value, len(value), MaxNameLength)
+	case !namePattern.MatchString(value):
+		return fmt.Errorf("value %q must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric", value)
+	}
+	return nil
+}
+
+// ValidateLabel checks the key and value of a label
+func ValidateLabel(key, value string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	return ValidateValue(value)
+}
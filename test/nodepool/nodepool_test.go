@@ -0,0 +1,156 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodepool
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"test/tfvars"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"workload.sas.com/class":           "",
+		"launcher.sas.com/prepullImage":    "",
+		"dedicated":                        "",
+		"node.kubernetes.io/instance-type": "",
+		"":                                 `key is empty`,
+		"a/b/c":                            `key "a/b/c" has more than one /`,
+		"/class":                           `key "/class" has an empty prefix before the /`,
+		"sas.com/":                         `key "sas.com/" has an empty name after the /`,
+		"SAS.com/class":                    `key "SAS.com/class" prefix "SAS.com" must be a DNS subdomain: lowercase alphanumerics, '-' and '.', starting and ending with an alphanumeric`,
+		"sas.com/-class":                   `key "sas.com/-class" name "-class" must consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		"workload.sas.com/class=cas":       `key "workload.sas.com/class=cas" name "class=cas" must consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		strings.Repeat("a", 64):            `key "` + strings.Repeat("a", 64) + `" name is 64 characters, at most 63 are allowed`,
+		strings.Repeat("a", 254) + "/b":    `key "` + strings.Repeat("a", 254) + `/b" prefix is 254 characters, at most 253 are allowed`,
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			err := ValidateKey(key)
+			if expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                      "",
+		"cas":                   "",
+		"sas-programming.env_1": "",
+		"a,b":                   `value "a,b" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		"a:b":                   `value "a:b" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		`"cas"`:                 `value "\"cas\"" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		"cas-":                  `value "cas-" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+		strings.Repeat("v", 64): `value "` + strings.Repeat("v", 64) + `" is 64 characters, at most 63 are allowed`,
+	}
+	for value, expected := range tests {
+		t.Run(value, func(t *testing.T) {
+			err := ValidateValue(value)
+			if expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]struct {
+		taint Taint
+		eks   string
+	}{
+		"workload.sas.com/class=cas:NoSchedule": {Taint{Key: "workload.sas.com/class", Value: "cas", Effect: NoSchedule}, "NO_SCHEDULE"},
+		"nvidia.com/gpu=present:NoExecute":      {Taint{Key: "nvidia.com/gpu", Value: "present", Effect: NoExecute}, "NO_EXECUTE"},
+		"dedicated=:PreferNoSchedule":           {Taint{Key: "dedicated", Effect: PreferNoSchedule}, "PREFER_NO_SCHEDULE"},
+		"dedicated:NoSchedule":                  {Taint{Key: "dedicated", Effect: NoSchedule}, "NO_SCHEDULE"},
+	}
+	for s, tc := range valid {
+		t.Run(s, func(t *testing.T) {
+			taint, err := ParseTaint(s)
+			require.NoError(t, err)
+			assert.Equal(t, tc.taint, taint)
+			assert.Equal(t, tc.eks, taint.EKSEffect())
+			eks, err := EKSTaint(taint.Key, taint.Value, taint.EKSEffect())
+			require.NoError(t, err)
+			assert.Equal(t, taint, eks)
+			if strings.Contains(s, "=:") {
+				assert.Equal(t, strings.Replace(s, "=:", ":", 1), taint.String())
+			} else {
+				assert.Equal(t, s, taint.String())
+			}
+		})
+	}
+
+	invalid := map[string]string{
+		"":                                      "taint is empty, expected key=value:Effect",
+		"workload.sas.com/class=cas":            `taint "workload.sas.com/class=cas" has no effect, expected key=value:Effect`,
+		"a=b:c:NoSchedule":                      `taint "a=b:c:NoSchedule" has more than one :, expected key=value:Effect`,
+		"workload.sas.com/class=cas:noschedule": `taint "workload.sas.com/class=cas:noschedule" has effect "noschedule", expected NoSchedule, PreferNoSchedule or NoExecute`,
+		"=cas:NoSchedule":                       `taint "=cas:NoSchedule": key is empty`,
+		"workload.sas.com/class=c a s:NoSchedule": `taint "workload.sas.com/class=c a s:NoSchedule": value "c a s" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+	}
+	for s, expected := range invalid {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseTaint(s)
+			assert.EqualError(t, err, expected)
+		})
+	}
+
+	_, err := EKSTaint("dedicated", "", NoSchedule)
+	assert.EqualError(t, err, `taint dedicated has EKS effect "NoSchedule", expected NO_SCHEDULE, PREFER_NO_SCHEDULE or NO_EXECUTE`)
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	in, err := tfvars.LoadInputs("../..", "testdata/malformed.tfvars")
+	require.NoError(t, err)
+	problems, err := Check(in)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	assert.Equal(t, []string{
+		`default_nodepool_taints[0]: taint "dedicated:NoSchedule" has no value, locals.tf needs key=value:Effect, use dedicated=:NoSchedule for an empty value`,
+		`node_pools["cas"].node_taints[1]: taint "workload.sas.com/class=cas" has no effect, expected key=value:Effect`,
+		`node_pools["cas"].node_taints[2]: taint "workload.sas.com/class=cas:noschedule" has effect "noschedule", expected NoSchedule, PreferNoSchedule or NoExecute`,
+		`node_pools["compute"].node_labels["launcher.sas.com/prepullImage"]: value "sas-programming-environment, sas-studio" must be empty or consist of alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric`,
+	}, messages)
+	assert.Equal(t, []string{"default", "cas", "cas", "compute"}, []string{problems[0].Pool, problems[1].Pool, problems[2].Pool, problems[3].Pool})
+}
+
+func TestCheckExamples(t *testing.T) {
+	t.Parallel()
+
+	examples, err := filepath.Glob("../../examples/*.tfvars")
+	require.NoError(t, err)
+	require.NotEmpty(t, examples)
+	for _, example := range examples {
+		t.Run(filepath.Base(example), func(t *testing.T) {
+			in, err := tfvars.LoadInputs("../..", example)
+			require.NoError(t, err)
+			problems, err := Check(in)
+			require.NoError(t, err)
+			assert.Empty(t, problems)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodepool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Taint effects of Kubernetes
+const (
+	NoSchedule       = "NoSchedule"
+	PreferNoSchedule = "PreferNoSchedule"
+	NoExecute        = "NoExecute"
+)
+
+// eksEffects are the taint effects of EKS managed node groups
+var eksEffects = map[string]string{
+	NoSchedule:       "NO_SCHEDULE",
+	PreferNoSchedule: "PREFER_NO_SCHEDULE",
+	NoExecute:        "NO_EXECUTE",
+}
+
+// Taint is a node taint
+type Taint struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Effect is the Kubernetes effect, like NoSchedule
+	Effect string `json:"effect"`
+}
+
+// String returns the taint in the key=value:Effect form of kubectl and
+// node_taints, key:Effect when it has no value
+func (t Taint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
+
+// EKSEffect returns the effect as EKS managed node groups take it, like
+// NO_SCHEDULE
+func (t Taint) EKSEffect() string {
+	return eksEffects[t.Effect]
+}
+
+// ParseTaint parses a taint in the key=value:Effect or key:Effect form
+func ParseTaint(s string) (Taint, error) {
+	if s == "" {
+		return Taint{}, fmt.Errorf("taint is empty, expected key=value:Effect")
+	}
+	parts := strings.Split(s, ":")
+	switch {
+	case len(parts) == 1:
+		return Taint{}, fmt.Errorf("taint %q has no effect, expected key=value:Effect", s)
+	case len(parts) > 2:
+		return Taint{}, fmt.Errorf("taint %q has more than one :, expected key=value:Effect", s)
+	}
+
+	key, value, _ := strings.Cut(parts[0], "=")
+	t := Taint{Key: key, Value: value, Effect: parts[1]}
+	if _, ok := eksEffects[t.Effect]; !ok {
+		return Taint{}, fmt.Errorf("taint %q has effect %q, expected %s, %s or %s", s, t.Effect, NoSchedule, PreferNoSchedule, NoExecute)
+	}
+	if err := ValidateKey(t.Key); err != nil {
+		return Taint{}, fmt.Errorf("taint %q: %w", s, err)
+	}
+	if err := ValidateValue(t.Value); err != nil {
+		return Taint{}, fmt.Errorf("taint %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// EKSTaint returns the taint of an EKS managed node group taint block, whose
+// effect is like NO_SCHEDULE
+func EKSTaint(key, value, effect string) (Taint, error) {
+	for k, eks := range eksEffects {
+		if eks == effect {
+			return Taint{Key: key, Value: value, Effect: k}, nil
+		}
+	}
+	return Taint{}, fmt.Errorf("taint %s has EKS effect %q, expected %s, %s or %s", key, effect, eksEffects[NoSchedule], eksEffects[PreferNoSchedule], eksEffects[NoExecute])
+}
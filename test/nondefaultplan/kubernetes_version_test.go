@@ -0,0 +1,26 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nondefaultplan
+
+import (
+	"testing"
+
+	"test/helpers"
+)
+
+// Versions before the 1.30 gate, including one that sorts after "1.30" as a string
+func TestPlanKubernetesVersionGates(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{"1.29", "1.4"} {
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			variables := helpers.GetDefaultPlanVars(t)
+			variables["prefix"] = "k8s-version"
+			variables["kubernetes_version"] = version
+			helpers.AssertVersionGates(t, helpers.GetPlan(t, variables))
+		})
+	}
+}
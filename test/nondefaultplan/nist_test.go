@@ -0,0 +1,72 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package nondefaultplan
+
+import (
+	"sort"
+	"testing"
+
+	"test/helpers"
+	"test/nist"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// plannedAddresses returns the sorted addresses of the planned resources
+func plannedAddresses(plan *terraform.PlanStruct) []string {
+	addresses := make([]string, 0, len(plan.ResourcePlannedValuesMap))
+	for address := range plan.ResourcePlannedValuesMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// TestPlanNISTFeatures plans the inputs a NIST deployment sets with
+// enable_nist_features off and on, and records which controls the module
+// meets. The flag only reaches the echo of a local-exec provisioner in
+// modules/aws_vm today, so both plans have the same controls outcome.
+func TestPlanNISTFeatures(t *testing.T) {
+	t.Parallel()
+
+	plan := func(prefix string, enabled bool) *terraform.PlanStruct {
+		variables := helpers.GetDefaultPlanVars(t)
+		variables["prefix"] = prefix
+		variables["enable_nist_features"] = enabled
+		variables["cluster_api_mode"] = "private"
+		variables["cluster_enabled_log_types"] = nist.AuditLogTypes
+		variables["enable_ebs_encryption"] = true
+		variables["enable_efs_encryption"] = true
+		// modules/aws_vm does not set metadata_options, so plan no jump or NFS VM
+		variables["create_jump_vm"] = false
+		variables["storage_type"] = "ha"
+		variables["storage_type_backend"] = "efs"
+		variables["postgres_servers"] = map[string]any{
+			"default": map[string]any{},
+		}
+		return helpers.GetPlan(t, variables)
+	}
+	offPlan, onPlan := plan("nist-off", false), plan("nist-on", true)
+
+	off, on := nist.Check(offPlan), nist.Check(onPlan)
+	assert.False(t, off.NISTFeatures)
+	assert.True(t, on.NISTFeatures)
+	assert.Equal(t, map[string]nist.Status{
+		// cluster_enabled_log_types has the api, audit and authenticator types
+		"AU-2": nist.Pass,
+		// the node volumes, the EFS file system and the RDS storage are encrypted
+		"SC-28": nist.Pass,
+		// only the node launch templates, which require tokens with a hop limit of 1
+		"AC-6": nist.Pass,
+		// cluster_api_mode is private
+		"SC-7": nist.Pass,
+		// default_public_access_cidrs is a single address
+		"AC-17": nist.Pass,
+		// the default PostgreSQL server enforces SSL
+		"SC-8": nist.Pass,
+	}, on.Statuses())
+	assert.Equal(t, on.Statuses(), off.Statuses())
+	assert.Equal(t, plannedAddresses(offPlan), plannedAddresses(onPlan))
+}
@@ -176,5 +176,8 @@ func TestPlanNodePools(t *testing.T) {
 		}
 	}
 
-	helpers.RunTests(t, tests, helpers.GetPlan(t, variables))
+	plan := helpers.GetPlan(t, variables)
+	helpers.RunTests(t, tests, plan)
+	helpers.AssertNodeUserData(t, plan)
+	helpers.AssertNodeTaints(t, plan)
 }
@@ -0,0 +1,188 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package outputs reads the root module outputs of the stack, declared in
+// outputs.tf, into a typed struct, from `terraform output -json` or from a
+// state file.
+//
+// The API is versioned by APIVersion. Within a version fields are only added,
+// never renamed, retyped or removed, and outputs the struct does not know are
+// ignored, so callers keep working when outputs.tf grows.
+package outputs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"test/statefile"
+)
+
+// APIVersion is the version of the Outputs API, bumped on incompatible
+// changes
+const APIVersion = 1
+
+// Outputs are the root module outputs. Outputs that are null, like the jump_*
+// outputs without a jump VM, are zero.
+type Outputs struct {
+	ClusterEndpoint string `json:"cluster_endpoint"`
+	ClusterName     string `json:"cluster_name"`
+	ClusterAPIMode  string `json:"cluster_api_mode"`
+	// ClusterNodePoolMode is "standard" or "minimal"
+	ClusterNodePoolMode string `json:"cluster_node_pool_mode"`
+	K8sVersion          string `json:"k8s_version"`
+	// KubeConfig is the kubeconfig file content, sensitive
+	KubeConfig string `json:"kube_config"`
+	// ClusterIAMRoleARN is false, not null, when the cluster_iam_role_arn
+	// input was used
+	ClusterIAMRoleARN  OptionalString `json:"cluster_iam_role_arn"`
+	WorkersIAMRoleARN  string         `json:"workers_iam_role_arn"`
+	AutoscalerAccount  string         `json:"autoscaler_account"`
+	EBSCSIAccount      string         `json:"ebs_csi_account"`
+	Prefix             string         `json:"prefix"`
+	Provider           string         `json:"provider"`
+	Location           string         `json:"location"`
+	CREndpoint         string         `json:"cr_endpoint"`
+	NATIP              string         `json:"nat_ip"`
+	BYONetworkScenario int            `json:"byo_network_scenario"`
+	EnableNISTFeatures bool           `json:"enable_nist_features"`
+
+	AWSSharedCredentialsFile string   `json:"aws_shared_credentials_file"`
+	AWSSharedCredentials     []string `json:"aws_shared_credentials"`
+
+	// StorageTypeBackend is nfs, efs, ontap or none, see StorageType
+	StorageTypeBackend   string `json:"storage_type_backend"`
+	RWXFilestoreID       string `json:"rwx_filestore_id"`
+	RWXFilestoreEndpoint string `json:"rwx_filestore_endpoint"`
+	RWXFilestorePath     string `json:"rwx_filestore_path"`
+	EFSARN               string `json:"efs_arn"`
+	// AWSFSxONTAPFSxadminPassword is set for the ontap backend, sensitive
+	AWSFSxONTAPFSxadminPassword string `json:"aws_fsx_ontap_fsxadmin_password"`
+
+	JumpPrivateIP        string `json:"jump_private_ip"`
+	JumpPublicIP         string `json:"jump_public_ip"`
+	JumpAdminUsername    string `json:"jump_admin_username"`
+	JumpPrivateDNS       string `json:"jump_private_dns"`
+	JumpPublicDNS        string `json:"jump_public_dns"`
+	JumpRWXFilestorePath string `json:"jump_rwx_filestore_path"`
+
+	NFSPrivateIP     string `json:"nfs_private_ip"`
+	NFSPublicIP      string `json:"nfs_public_ip"`
+	NFSAdminUsername string `json:"nfs_admin_username"`
+	NFSPrivateDNS    string `json:"nfs_private_dns"`
+	NFSPublicDNS     string `json:"nfs_public_dns"`
+
+	// PostgresServers are keyed by the postgres_servers key, sensitive
+	PostgresServers map[string]PostgresServer `json:"postgres_servers"`
+}
+
+// PostgresServer is an RDS instance of postgres_servers
+type PostgresServer struct {
+	ServerName            string `json:"server_name"`
+	FQDN                  string `json:"fqdn"`
+	Admin                 string `json:"admin"`
+	Password              string `json:"password"`
+	ServerPort            int    `json:"server_port"`
+	SSLEnforcementEnabled bool   `json:"ssl_enforcement_enabled"`
+	Internal              bool   `json:"internal"`
+}
+
+// OptionalString is a string output that is false or null when unset
+type OptionalString string
+
+// UnmarshalJSON accepts a string, false or null
+func (s *OptionalString) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "false", "null":
+		*s = ""
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("expected a string or false: %w", err)
+	}
+	*s = OptionalString(v)
+	return nil
+}
+
+// StorageType returns the storage_type input the storage backend is created
+// for, standard, ha or none, empty for an unknown backend
+func (o *Outputs) StorageType() string {
+	switch o.StorageTypeBackend {
+	case "nfs":
+		return "standard"
+	case "efs", "ontap":
+		return "ha"
+	case "none":
+		return "none"
+	}
+	return ""
+}
+
+// Load reads the outputs from a file of `terraform output -json` output, a
+// raw .tfstate file or `terraform show -json` output. State files are
+// recognized by their top level terraform_version key.
+func Load(path string) (*Outputs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var probe struct {
+		TerraformVersion *string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("%s: decoding outputs: %w", path, err)
+	}
+
+	var o *Outputs
+	if probe.TerraformVersion != nil {
+		var state *statefile.State
+		if state, err = statefile.Parse(data); err == nil {
+			o, err = FromState(state)
+		}
+	} else {
+		o, err = Parse(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return o, nil
+}
+
+// Parse decodes `terraform output -json` output
+func Parse(data []byte) (*Outputs, error) {
+	var raw map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("decoding outputs: %w", err)
+	}
+	values := make(map[string]json.RawMessage, len(raw))
+	for name, o := range raw {
+		values[name] = o.Value
+	}
+	return decode(values)
+}
+
+// FromState returns the outputs recorded in a state
+func FromState(state *statefile.State) (*Outputs, error) {
+	values := make(map[string]interface{}, len(state.Outputs))
+	for name, o := range state.Outputs {
+		values[name] = o.Value
+	}
+	return decode(values)
+}
+
+// decode converts the output values, keyed by output name, to Outputs
+func decode(values interface{}) (*Outputs, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var o Outputs
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("decoding outputs: %w", err)
+	}
+	return &o, nil
+}
@@ -0,0 +1,212 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"test/statefile"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every output of outputs.tf has a field, except the ones that only validate
+// inputs
+func TestOutputsCoverOutputsTF(t *testing.T) {
+	t.Parallel()
+
+	f, diags := hclparse.NewParser().ParseHCLFile("../../outputs.tf")
+	require.False(t, diags.HasErrors(), diags.Error())
+	content, _, diags := f.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "output", LabelNames: []string{"name"}}},
+	})
+	require.False(t, diags.HasErrors(), diags.Error())
+	var declared []string
+	for _, block := range content.Blocks {
+		if block.Labels[0] != "validate_subnet_azs" {
+			declared = append(declared, block.Labels[0])
+		}
+	}
+	sort.Strings(declared)
+
+	var fields []string
+	typ := reflect.TypeOf(Outputs{})
+	for i := 0; i < typ.NumField(); i++ {
+		fields = append(fields, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	sort.Strings(fields)
+	assert.Equal(t, declared, fields)
+}
+
+func TestStorageBackends(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fixture     string
+		storageType string
+		filestore   string
+	}{
+		"standard/nfs": {fixture: "standard-nfs", storageType: "standard", filestore: "ip-192-168-129-20.ec2.internal:/export"},
+		"ha/efs":       {fixture: "ha-efs", storageType: "ha", filestore: "fs-0123456789abcdef0.efs.us-east-1.amazonaws.com:/"},
+		"ha/ontap":     {fixture: "ha-ontap", storageType: "ha", filestore: "svm-0123456789abcdef0.fs-0fedcba9876543210.fsx.us-east-1.amazonaws.com:/ontap"},
+		"none/none":    {fixture: "none", storageType: "none", filestore: ":"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			o, err := Load("testdata/" + tc.fixture + ".json")
+			require.NoError(t, err)
+			assert.Empty(t, o.Validate())
+			assert.Equal(t, tc.storageType, o.StorageType())
+			assert.Equal(t, tc.filestore, o.RWXFilestoreEndpoint+":"+o.RWXFilestorePath)
+			assert.Equal(t, "aws", o.Provider)
+			assert.Equal(t, tc.fixture+"-eks", o.ClusterName)
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	t.Parallel()
+
+	o, err := Load("testdata/ha-efs.json")
+	require.NoError(t, err)
+	assert.Equal(t, OptionalString(""), o.ClusterIAMRoleARN, "false reads as unset")
+	assert.Equal(t, []string{"~/.aws/credentials"}, o.AWSSharedCredentials)
+	assert.Equal(t, map[string]PostgresServer{
+		"default": {
+			ServerName:            "ha-efs-default-pgsql",
+			FQDN:                  "ha-efs-default-pgsql.abcdefghij.us-east-1.rds.amazonaws.com",
+			Admin:                 "pgadmin",
+			Password:              "my$up3rS3cretPassw0rd",
+			ServerPort:            5432,
+			SSLEnforcementEnabled: true,
+		},
+	}, o.PostgresServers)
+
+	o, err = Parse([]byte(`{"cluster_iam_role_arn": {"value": "arn:aws:iam::123456789012:role/eks"}, "unknown": {"value": 1}}`))
+	require.NoError(t, err)
+	assert.Equal(t, OptionalString("arn:aws:iam::123456789012:role/eks"), o.ClusterIAMRoleARN, "unknown outputs are ignored")
+
+	_, err = Parse([]byte(`{"byo_network_scenario": {"value": "two"}}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"cluster_iam_role_arn": {"value": true}}`))
+	assert.Error(t, err)
+}
+
+// A state file and `terraform output -json` of it read the same
+func TestLoadState(t *testing.T) {
+	t.Parallel()
+
+	fromOutput, err := Load("testdata/standard-nfs.json")
+	require.NoError(t, err)
+	fromState, err := Load("testdata/standard-nfs.tfstate")
+	require.NoError(t, err)
+	assert.Equal(t, fromOutput, fromState)
+
+	o, err := FromState(&statefile.State{Outputs: map[string]statefile.Output{"prefix": {Value: "viya"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "viya", o.Prefix)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fixture  string
+		modify   func(o *Outputs)
+		expected []string
+	}{
+		"nfs without server": {
+			fixture: "standard-nfs",
+			modify: func(o *Outputs) {
+				o.NFSPrivateIP = ""
+				o.RWXFilestorePath = "/"
+			},
+			expected: []string{
+				`rwx_filestore_path: expected "/export" for the nfs backend, got "/"`,
+				"nfs_private_ip: required, but not set",
+			},
+		},
+		"efs without arn": {
+			fixture: "ha-efs",
+			modify: func(o *Outputs) {
+				o.EFSARN = ""
+				o.NFSPrivateIP = "192.168.129.20"
+			},
+			expected: []string{
+				"efs_arn: required, but not set",
+				`nfs_private_ip: set to "192.168.129.20", but there is no NFS server VM for the efs backend`,
+			},
+		},
+		"ontap without password": {
+			fixture:  "ha-ontap",
+			modify:   func(o *Outputs) { o.AWSFSxONTAPFSxadminPassword = "" },
+			expected: []string{"aws_fsx_ontap_fsxadmin_password: required, but not set"},
+		},
+		"none with filestore": {
+			fixture: "none",
+			modify:  func(o *Outputs) { o.RWXFilestorePath = "/export" },
+			expected: []string{
+				`rwx_filestore_path: set to "/export", but storage_type_backend is none`,
+			},
+		},
+		"unknown backend": {
+			fixture: "none",
+			modify: func(o *Outputs) {
+				o.StorageTypeBackend = "fsx"
+				o.Provider = "azure"
+			},
+			expected: []string{
+				`provider: expected "aws", got "azure"`,
+				`storage_type_backend: unknown backend "fsx", expected nfs, efs, ontap or none`,
+			},
+		},
+		"jump without share": {
+			fixture:  "standard-nfs",
+			modify:   func(o *Outputs) { o.JumpRWXFilestorePath = "" },
+			expected: []string{"jump_rwx_filestore_path: required, but not set"},
+		},
+		"postgres without port": {
+			fixture: "ha-efs",
+			modify: func(o *Outputs) {
+				o.PostgresServers["default"] = PostgresServer{FQDN: "db.example.com", Admin: "pgadmin"}
+			},
+			expected: []string{`postgres_servers["default"].server_port: expected a port, got 0`},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			o, err := Load("testdata/" + tc.fixture + ".json")
+			require.NoError(t, err)
+			tc.modify(o)
+			var problems []string
+			for _, p := range o.Validate() {
+				problems = append(problems, p.String())
+			}
+			assert.Equal(t, tc.expected, problems)
+		})
+	}
+}
+
+// Outputs marshal back to the output names, so tools can pass them on as JSON
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(Outputs{Prefix: "viya", ClusterIAMRoleARN: "arn"})
+	require.NoError(t, err)
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &values))
+	assert.Equal(t, "viya", values["prefix"])
+	assert.Equal(t, "arn", values["cluster_iam_role_arn"])
+}
@@ -0,0 +1,135 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Problem is an output that is missing or has a value its storage backend
+// does not allow
+type Problem struct {
+	Output  string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Output, p.Message)
+}
+
+// rwxPaths are the rwx_filestore_path of each storage backend
+var rwxPaths = map[string]string{
+	"nfs":   "/export",
+	"efs":   "/",
+	"ontap": "/ontap",
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) report(output, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Output: output, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(output, value string) {
+	if value == "" {
+		v.report(output, "required, but not set")
+	}
+}
+
+func (v *validator) empty(output, value, reason string) {
+	if value != "" {
+		v.report(output, "set to %q, but %s", value, reason)
+	}
+}
+
+// Validate reports the outputs a consumer can not rely on: the outputs every
+// stack has, the outputs of the storage backend, and the outputs of the
+// optional jump VM and PostgreSQL servers when they exist
+func (o *Outputs) Validate() []Problem {
+	v := &validator{}
+	v.required("cluster_endpoint", o.ClusterEndpoint)
+	v.required("cluster_name", o.ClusterName)
+	v.required("prefix", o.Prefix)
+	v.required("location", o.Location)
+	v.required("k8s_version", o.K8sVersion)
+	v.required("cr_endpoint", o.CREndpoint)
+	if o.Provider != "aws" {
+		v.report("provider", "expected %q, got %q", "aws", o.Provider)
+	}
+
+	backend := o.StorageTypeBackend
+	switch backend {
+	case "nfs", "efs", "ontap":
+		v.required("rwx_filestore_endpoint", o.RWXFilestoreEndpoint)
+		if o.RWXFilestorePath != rwxPaths[backend] {
+			v.report("rwx_filestore_path", "expected %q for the %s backend, got %q", rwxPaths[backend], backend, o.RWXFilestorePath)
+		}
+	case "none":
+		reason := "storage_type_backend is none"
+		v.empty("rwx_filestore_id", o.RWXFilestoreID, reason)
+		v.empty("rwx_filestore_endpoint", o.RWXFilestoreEndpoint, reason)
+		v.empty("rwx_filestore_path", o.RWXFilestorePath, reason)
+		v.empty("jump_rwx_filestore_path", o.JumpRWXFilestorePath, reason)
+	case "":
+		v.required("storage_type_backend", backend)
+	default:
+		v.report("storage_type_backend", "unknown backend %q, expected nfs, efs, ontap or none", backend)
+	}
+
+	switch backend {
+	case "nfs":
+		v.required("nfs_private_ip", o.NFSPrivateIP)
+		v.required("nfs_private_dns", o.NFSPrivateDNS)
+		v.required("nfs_admin_username", o.NFSAdminUsername)
+		v.empty("rwx_filestore_id", o.RWXFilestoreID, "the nfs backend has no file system ID")
+	case "efs":
+		v.required("rwx_filestore_id", o.RWXFilestoreID)
+		v.required("efs_arn", o.EFSARN)
+	case "ontap":
+		v.required("rwx_filestore_id", o.RWXFilestoreID)
+		v.required("aws_fsx_ontap_fsxadmin_password", o.AWSFSxONTAPFSxadminPassword)
+	}
+	if backend != "nfs" {
+		reason := fmt.Sprintf("there is no NFS server VM for the %s backend", backend)
+		v.empty("nfs_private_ip", o.NFSPrivateIP, reason)
+		v.empty("nfs_private_dns", o.NFSPrivateDNS, reason)
+	}
+	if backend != "efs" {
+		v.empty("efs_arn", o.EFSARN, fmt.Sprintf("there is no EFS file system for the %s backend", backend))
+	}
+	if backend != "ontap" {
+		v.empty("aws_fsx_ontap_fsxadmin_password", o.AWSFSxONTAPFSxadminPassword, fmt.Sprintf("there is no ONTAP file system for the %s backend", backend))
+	}
+
+	if o.JumpPrivateIP != "" {
+		v.required("jump_admin_username", o.JumpAdminUsername)
+		v.required("jump_private_dns", o.JumpPrivateDNS)
+		if backend != "none" {
+			v.required("jump_rwx_filestore_path", o.JumpRWXFilestorePath)
+		}
+	}
+
+	for _, name := range sortedKeys(o.PostgresServers) {
+		s := o.PostgresServers[name]
+		output := fmt.Sprintf("postgres_servers[%q]", name)
+		v.required(output+".fqdn", s.FQDN)
+		v.required(output+".admin", s.Admin)
+		if s.ServerPort <= 0 {
+			v.report(output+".server_port", "expected a port, got %d", s.ServerPort)
+		}
+	}
+	return v.problems
+}
+
+func sortedKeys(m map[string]PostgresServer) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package planfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Masked values of a change
+const (
+	Sensitive  = "(sensitive)"
+	KnownAfter = "(known after apply)"
+	Changed    = "(changed)"
+)
+
+// FormatPath formats an attribute path of a change, such as a replace path,
+// as ebs_block_device[0].volume_size
+func FormatPath(path []interface{}) string {
+	var b strings.Builder
+	for i, step := range path {
+		switch s := step.(type) {
+		case string:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s)
+		default:
+			fmt.Fprintf(&b, "[%v]", s)
+		}
+	}
+	return b.String()
+}
+
+// ChangeValues formats the values of an attribute path before and after a
+// change. Sensitive values are masked, values unknown until apply are shown as
+// such and nested blocks are shown as changed.
+func ChangeValues(change *tfjson.Change, path []interface{}) (before, after string) {
+	return value(change.Before, change.BeforeSensitive, nil, path),
+		value(change.After, change.AfterSensitive, change.AfterUnknown, path)
+}
+
+func value(values, sensitive, unknown interface{}, path []interface{}) string {
+	if flagged(sensitive, path) {
+		return Sensitive
+	}
+	if flagged(unknown, path) {
+		return KnownAfter
+	}
+	v := lookup(values, path)
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return Changed
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// flagged reports whether the path or one of its parents is marked true in
+// an after_unknown or sensitive tree
+func flagged(tree interface{}, path []interface{}) bool {
+	for i := 0; i <= len(path); i++ {
+		if flag, _ := lookup(tree, path[:i]).(bool); flag {
+			return true
+		}
+	}
+	return false
+}
+
+func lookup(v interface{}, path []interface{}) interface{} {
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			m, _ := v.(map[string]interface{})
+			v = m[s]
+		case float64:
+			list, _ := v.([]interface{})
+			if int(s) < 0 || int(s) >= len(list) {
+				return nil
+			}
+			v = list[int(s)]
+		default:
+			return nil
+		}
+	}
+	return v
+}
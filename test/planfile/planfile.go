@@ -0,0 +1,138 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package planfile loads terraform plans for the offline tools, either from
+// `terraform show -json` output or from a terratest PlanStruct saved as JSON.
+package planfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Load reads a plan from a file, see Parse
+func Load(path string) (*terraform.PlanStruct, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return plan, nil
+}
+
+// Parse decodes `terraform show -json` output or a saved PlanStruct, which is
+// recognized by its top level RawPlan key
+func Parse(data []byte) (*terraform.PlanStruct, error) {
+	var saved struct {
+		RawPlan json.RawMessage
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("decoding plan: %w", err)
+	}
+	if saved.RawPlan != nil {
+		data = saved.RawPlan
+	}
+
+	plan, err := terraform.ParsePlanJSON(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding plan: %w", err)
+	}
+	return plan, nil
+}
+
+// Save writes a PlanStruct as JSON so it can be loaded again without terraform
+func Save(path string, plan *terraform.PlanStruct) error {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Resource is a resource from the planned values
+type Resource struct {
+	Address string
+	Type    string
+	Values  Attributes
+}
+
+// Resources returns the planned managed resources sorted by address
+func Resources(plan *terraform.PlanStruct) []Resource {
+	resources := make([]Resource, 0, len(plan.ResourcePlannedValuesMap))
+	for address, r := range plan.ResourcePlannedValuesMap {
+		if r.Mode != "" && r.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+		resources = append(resources, Resource{Address: address, Type: r.Type, Values: r.AttributeValues})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Address < resources[j].Address
+	})
+	return resources
+}
+
+// MatchAddress reports whether a resource address matches a pattern, where
+// "*" matches any characters, like module.postgresql[*
+func MatchAddress(pattern, address string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(address)
+}
+
+// Attributes are the planned attribute values of a resource or block. Values
+// that are unknown until apply are absent and read as zero values.
+type Attributes map[string]interface{}
+
+// String returns a string attribute
+func (a Attributes) String(key string) string {
+	s, _ := a[key].(string)
+	return s
+}
+
+// Number returns a number attribute, which JSON decodes as float64
+func (a Attributes) Number(key string) float64 {
+	n, _ := a[key].(float64)
+	return n
+}
+
+// Bool returns a bool attribute
+func (a Attributes) Bool(key string) bool {
+	b, _ := a[key].(bool)
+	return b
+}
+
+// Strings returns a list of strings attribute
+func (a Attributes) Strings(key string) []string {
+	items, _ := a[key].([]interface{})
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// Blocks returns the nested blocks of a block type
+func (a Attributes) Blocks(key string) []Attributes {
+	items, _ := a[key].([]interface{})
+	var list []Attributes
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			list = append(list, m)
+		}
+	}
+	return list
+}
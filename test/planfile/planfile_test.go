@@ -0,0 +1,84 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package planfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSavedPlanStruct(t *testing.T) {
+	t.Parallel()
+
+	plan, err := Load("../cost/testdata/plan-standard.json")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, Save(path, plan))
+	saved, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, Resources(plan), Resources(saved))
+}
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	plan, err := Load("../cost/testdata/plan-standard.json")
+	require.NoError(t, err)
+
+	resources := Resources(plan)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, "module.eks.aws_eks_cluster.this[0]", resources[0].Address)
+	for _, r := range resources {
+		assert.NotEqual(t, "data.aws_ami.ubuntu", r.Address, "data sources are not planned resources")
+	}
+
+	var lt Attributes
+	for _, r := range resources {
+		if r.Address == `module.eks.module.eks_managed_node_group["default"].aws_launch_template.this[0]` {
+			lt = r.Values
+		}
+	}
+	ebs := lt.Blocks("block_device_mappings")[0].Blocks("ebs")[0]
+	assert.Equal(t, "gp2", ebs.String("volume_type"))
+	assert.Equal(t, 200.0, ebs.Number("volume_size"))
+	assert.Equal(t, 0.0, ebs.Number("throughput"), "null values read as zero")
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`{"planned_values": {}}`))
+	assert.ErrorContains(t, err, "format version is missing")
+}
+
+func TestMatchAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]map[string]bool{
+		"module.postgresql[*": {
+			`module.postgresql["default"].module.db_instance.aws_db_instance.this[0]`: true,
+			`module.jump[0].aws_instance.vm`:                                          false,
+		},
+		"aws_efs_file_system.efs-fs[0]": {
+			"aws_efs_file_system.efs-fs[0]":  true,
+			"aws_efs_file_system.efs-fs[10]": false,
+			"aws_efs_file_system.efs-fs.0":   false,
+		},
+		"*.aws_ebs_volume.*": {
+			"module.nfs[0].aws_ebs_volume.raid_disk[0]": true,
+			"aws_ebs_volume.raid_disk[0]":               false,
+		},
+		"*": {"anything": true},
+	}
+	for pattern, addresses := range tests {
+		for address, expected := range addresses {
+			assert.Equal(t, expected, MatchAddress(pattern, address), "%s %s", pattern, address)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"test/planfile"
+)
+
+// Builtin returns the guardrail rules for viya4-iac-aws plans
+func Builtin() []Rule {
+	return []Rule{
+		{
+			ID:            "IAC-IMDS-001",
+			Description:   "Instances require IMDSv2 session tokens",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_instance", "aws_launch_template"},
+			Check:         checkIMDSv2,
+		},
+		{
+			ID:            "IAC-ENC-001",
+			Description:   "EBS volumes are encrypted",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_ebs_volume", "aws_instance", "aws_launch_template"},
+			Check:         checkEBSEncryption,
+		},
+		{
+			ID:            "IAC-ENC-002",
+			Description:   "EFS file systems are encrypted",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_efs_file_system"},
+			Check:         checkAttribute("encrypted", "encryption is disabled, set enable_efs_encryption"),
+		},
+		{
+			ID:            "IAC-ENC-003",
+			Description:   "RDS storage is encrypted",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_db_instance"},
+			Check:         checkAttribute("storage_encrypted", "storage encryption is disabled, set storage_encrypted in postgres_servers"),
+		},
+		{
+			ID:            "IAC-ENC-004",
+			Description:   "FSx for NetApp ONTAP uses a customer managed key",
+			Severity:      Note,
+			ResourceTypes: []string{"aws_fsx_ontap_file_system"},
+			Check:         checkONTAPKey,
+		},
+		{
+			ID:            "IAC-NET-001",
+			Description:   "Security groups allow no ingress from the whole internet",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_vpc_security_group_ingress_rule"},
+			Check:         checkOpenIngress,
+		},
+		{
+			ID:            "IAC-RDS-001",
+			Description:   "RDS instances are public only when public access CIDRs are configured",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_db_instance"},
+			Check:         checkPublicDatabase,
+		},
+		{
+			ID:            "IAC-RDS-002",
+			Description:   "Production databases have deletion protection",
+			Severity:      Error,
+			ResourceTypes: []string{"aws_db_instance"},
+			Check:         checkDeletionProtection,
+		},
+	}
+}
+
+func checkIMDSv2(_ *Context, r planfile.Resource) []string {
+	options := r.Values.Blocks("metadata_options")
+	if len(options) == 0 {
+		return []string{"metadata_options is not set, so IMDSv1 stays enabled unless the AMI or account default requires tokens"}
+	}
+	if tokens := options[0].String("http_tokens"); tokens != "required" {
+		if tokens == "" {
+			tokens = "unset"
+		}
+		return []string{fmt.Sprintf("metadata_options.http_tokens is %s, expected required", tokens)}
+	}
+	return nil
+}
+
+func checkEBSEncryption(_ *Context, r planfile.Resource) []string {
+	var messages []string
+	unencrypted := func(name string, ebs planfile.Attributes) {
+		if !ebs.Bool("encrypted") {
+			messages = append(messages, name+" is not encrypted, set enable_ebs_encryption")
+		}
+	}
+	switch r.Type {
+	case "aws_ebs_volume":
+		unencrypted("volume", r.Values)
+	case "aws_instance":
+		for _, root := range r.Values.Blocks("root_block_device") {
+			unencrypted("root_block_device", root)
+		}
+		for _, disk := range r.Values.Blocks("ebs_block_device") {
+			unencrypted(fmt.Sprintf("ebs_block_device %s", disk.String("device_name")), disk)
+		}
+	case "aws_launch_template":
+		for _, mapping := range r.Values.Blocks("block_device_mappings") {
+			for _, ebs := range mapping.Blocks("ebs") {
+				unencrypted(fmt.Sprintf("block_device_mappings %s", mapping.String("device_name")), ebs)
+			}
+		}
+	}
+	return messages
+}
+
+// checkAttribute requires a bool attribute to be true
+func checkAttribute(name, message string) func(*Context, planfile.Resource) []string {
+	return func(_ *Context, r planfile.Resource) []string {
+		if !r.Values.Bool(name) {
+			return []string{message}
+		}
+		return nil
+	}
+}
+
+// checkONTAPKey notes file systems on the AWS managed key, since FSx for
+// NetApp ONTAP encrypts at rest unconditionally
+func checkONTAPKey(_ *Context, r planfile.Resource) []string {
+	if r.Values.String("kms_key_id") == "" {
+		return []string{"encrypted with the AWS managed key, set kms_key_id to use a customer managed key"}
+	}
+	return nil
+}
+
+func checkOpenIngress(_ *Context, r planfile.Resource) []string {
+	for _, attr := range []string{"cidr_ipv4", "cidr_ipv6"} {
+		if cidr := r.Values.String(attr); cidr == "0.0.0.0/0" || cidr == "::/0" {
+			return []string{fmt.Sprintf("%s %s allows ingress from anywhere on %s", attr, cidr, portRange(r.Values))}
+		}
+	}
+	return nil
+}
+
+func portRange(values planfile.Attributes) string {
+	protocol := values.String("ip_protocol")
+	if protocol == "-1" {
+		return "all protocols"
+	}
+	from, to := values.Number("from_port"), values.Number("to_port")
+	if from == to {
+		return fmt.Sprintf("%s port %g", protocol, from)
+	}
+	return fmt.Sprintf("%s ports %g-%g", protocol, from, to)
+}
+
+// checkPublicDatabase allows public databases only when postgres_public_access_cidrs,
+// or the default_public_access_cidrs it falls back to, opt into public access
+func checkPublicDatabase(ctx *Context, r planfile.Resource) []string {
+	if !r.Values.Bool("publicly_accessible") {
+		return nil
+	}
+	for _, name := range []string{"postgres_public_access_cidrs", "default_public_access_cidrs"} {
+		if cidrs, ok := ctx.Variable(name).([]interface{}); ok && len(cidrs) > 0 {
+			return nil
+		}
+	}
+	return []string{"publicly_accessible is true but postgres_public_access_cidrs and default_public_access_cidrs are empty"}
+}
+
+func checkDeletionProtection(_ *Context, r planfile.Resource) []string {
+	if !production(r.Values) || r.Values.Bool("deletion_protection") {
+		return nil
+	}
+	return []string{"tagged as production but deletion_protection is disabled, set deletion_protection in postgres_servers"}
+}
+
+// production reports whether an environment or env tag names production
+func production(values planfile.Attributes) bool {
+	for _, attr := range []string{"tags_all", "tags"} {
+		tags, _ := values[attr].(map[string]interface{})
+		for k, v := range tags {
+			if key := strings.ToLower(k); key != "environment" && key != "env" {
+				continue
+			}
+			if s, ok := v.(string); ok {
+				switch strings.ToLower(s) {
+				case "prod", "production":
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
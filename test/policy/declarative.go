@@ -0,0 +1,248 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"test/planfile"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is a policy file in YAML or JSON:
+//
+//	rules:
+//	  - id: ORG-RDS-001
+//	    description: Production databases keep automated backups for a week
+//	    severity: error
+//	    resourceTypes: [aws_db_instance]
+//	    when:
+//	      - path: tags_all.environment
+//	        equals: prod
+//	    require:
+//	      - path: backup_retention_period
+//	        oneOf: [7, 14, 35]
+//	waivers:
+//	  - rule: IAC-NET-001
+//	    address: aws_vpc_security_group_ingress_rule.vms["*"]
+//	    reason: Jump host is reachable from the VPN range only
+//	    expires: 2027-01-31
+//	disable: [IAC-RDS-002]
+type Config struct {
+	Rules   []DeclarativeRule `json:"rules"`
+	Waivers []Waiver          `json:"waivers"`
+	// Disable lists built-in rule IDs to skip
+	Disable []string `json:"disable"`
+}
+
+// DeclarativeRule is a rule made of attribute conditions
+type DeclarativeRule struct {
+	ID            string   `json:"id"`
+	Description   string   `json:"description"`
+	Severity      Severity `json:"severity"`
+	ResourceTypes []string `json:"resourceTypes"`
+	// When selects the resources the rule applies to, all conditions must hold
+	When []Condition `json:"when"`
+	// Require are the conditions every selected resource must satisfy
+	Require []Condition `json:"require"`
+	// Message replaces the generated violation message
+	Message string `json:"message"`
+}
+
+// Condition tests the values a path selects. Every selected value must pass;
+// a path that selects nothing only passes notEquals, noneOf and exists: false.
+type Condition struct {
+	// Path is a JSONPath into the planned attribute values, with or without the {.} wrapper
+	Path      string        `json:"path"`
+	Equals    interface{}   `json:"equals,omitempty"`
+	NotEquals interface{}   `json:"notEquals,omitempty"`
+	OneOf     []interface{} `json:"oneOf,omitempty"`
+	NoneOf    []interface{} `json:"noneOf,omitempty"`
+	Pattern   string        `json:"pattern,omitempty"`
+	Exists    *bool         `json:"exists,omitempty"`
+
+	path    *jsonpath.JSONPath
+	pattern *regexp.Regexp
+}
+
+// LoadConfig reads a policy file and compiles its rules
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range c.Rules {
+		if err := c.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("%s: rules[%d]: %w", path, i, err)
+		}
+	}
+	for i, w := range c.Waivers {
+		if w.Rule == "" || w.Address == "" || w.Reason == "" {
+			return nil, fmt.Errorf("%s: waivers[%d]: rule, address and reason are required", path, i)
+		}
+	}
+	return &c, nil
+}
+
+// Combine drops the rules the configs disable, then adds their rules and collects their waivers
+func Combine(rules []Rule, configs ...*Config) ([]Rule, []Waiver) {
+	disabled := map[string]bool{}
+	for _, c := range configs {
+		for _, id := range c.Disable {
+			disabled[id] = true
+		}
+	}
+	var combined []Rule
+	var waivers []Waiver
+	for _, r := range rules {
+		if !disabled[r.ID] {
+			combined = append(combined, r)
+		}
+	}
+	for _, c := range configs {
+		for _, d := range c.Rules {
+			if !disabled[d.ID] {
+				combined = append(combined, d.Rule())
+			}
+		}
+		waivers = append(waivers, c.Waivers...)
+	}
+	return combined, waivers
+}
+
+func (d *DeclarativeRule) compile() error {
+	if d.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if _, err := ParseSeverity(string(d.Severity)); err != nil {
+		return fmt.Errorf("%s: %w", d.ID, err)
+	}
+	if len(d.Require) == 0 {
+		return fmt.Errorf("%s: at least one require condition is needed", d.ID)
+	}
+	for _, conditions := range [][]Condition{d.When, d.Require} {
+		for i := range conditions {
+			if err := conditions[i].compile(); err != nil {
+				return fmt.Errorf("%s: %w", d.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Condition) compile() error {
+	expr := c.Path
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{." + strings.TrimPrefix(expr, ".") + "}"
+	}
+	c.path = jsonpath.New(c.Path).AllowMissingKeys(true)
+	if err := c.path.Parse(expr); err != nil {
+		return fmt.Errorf("path %q: %w", c.Path, err)
+	}
+	if c.Pattern != "" {
+		var err error
+		if c.pattern, err = regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("path %q: %w", c.Path, err)
+		}
+	}
+	return nil
+}
+
+// Rule converts the declarative rule to a Rule
+func (d DeclarativeRule) Rule() Rule {
+	return Rule{
+		ID:            d.ID,
+		Description:   d.Description,
+		Severity:      d.Severity,
+		ResourceTypes: d.ResourceTypes,
+		Check: func(_ *Context, r planfile.Resource) []string {
+			for _, c := range d.When {
+				if c.test(r.Values) != "" {
+					return nil
+				}
+			}
+			var messages []string
+			for _, c := range d.Require {
+				if msg := c.test(r.Values); msg != "" {
+					if d.Message != "" {
+						msg = d.Message
+					}
+					messages = append(messages, msg)
+				}
+			}
+			return messages
+		},
+	}
+}
+
+// test returns why the values fail the condition, or "" if they pass
+func (c *Condition) test(values planfile.Attributes) string {
+	var found []interface{}
+	results, err := c.path.FindResults(map[string]interface{}(values))
+	if err == nil {
+		for _, set := range results {
+			for _, v := range set {
+				if v.IsValid() && v.CanInterface() && v.Interface() != nil {
+					found = append(found, v.Interface())
+				}
+			}
+		}
+	}
+
+	if c.Exists != nil {
+		if *c.Exists != (len(found) > 0) {
+			if *c.Exists {
+				return fmt.Sprintf("%s is not set", c.Path)
+			}
+			return fmt.Sprintf("%s is set to %s", c.Path, encode(found))
+		}
+		return ""
+	}
+	if len(found) == 0 && (c.Equals != nil || c.OneOf != nil || c.Pattern != "") {
+		return fmt.Sprintf("%s is not set", c.Path)
+	}
+	for _, v := range found {
+		switch {
+		case c.Equals != nil && encode(v) != encode(c.Equals):
+			return fmt.Sprintf("%s is %s, expected %s", c.Path, encode(v), encode(c.Equals))
+		case c.NotEquals != nil && encode(v) == encode(c.NotEquals):
+			return fmt.Sprintf("%s must not be %s", c.Path, encode(v))
+		case c.OneOf != nil && !containsValue(c.OneOf, v):
+			return fmt.Sprintf("%s is %s, expected one of %s", c.Path, encode(v), encode(c.OneOf))
+		case c.NoneOf != nil && containsValue(c.NoneOf, v):
+			return fmt.Sprintf("%s must not be %s", c.Path, encode(v))
+		case c.pattern != nil && !c.pattern.MatchString(fmt.Sprint(v)):
+			return fmt.Sprintf("%s is %s, expected a match of %s", c.Path, encode(v), c.Pattern)
+		}
+	}
+	return ""
+}
+
+// encode renders a value as JSON, so numbers decoded from YAML and plan JSON compare equal
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if encode(item) == encode(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy evaluates guardrail rules against the resources of a plan.
+//
+// Rules are written in Go, see Builtin, or in the declarative format read by
+// LoadConfig. Violations can be waived per rule and resource address, and
+// results can be rendered as text, Markdown, JSON or SARIF.
+package policy
+
+import (
+	"fmt"
+	"sort"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// Severity of a rule, using the SARIF level names
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Note    Severity = "note"
+)
+
+// ParseSeverity returns the Severity with the given name
+func ParseSeverity(name string) (Severity, error) {
+	switch s := Severity(name); s {
+	case Error, Warning, Note:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown severity %q, expected error, warning or note", name)
+}
+
+// Context gives rules access to the whole plan
+type Context struct {
+	Plan *terraform.PlanStruct
+}
+
+// Variable returns the value of an input variable of the plan, or nil
+func (c *Context) Variable(name string) interface{} {
+	if v, ok := c.Plan.RawPlan.Variables[name]; ok && v != nil {
+		return v.Value
+	}
+	return nil
+}
+
+// Rule is a check applied to every planned resource of the given types
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	// ResourceTypes limits the rule to these resource types, all types if empty
+	ResourceTypes []string
+	// Check returns one message per violation found on the resource
+	Check func(ctx *Context, r planfile.Resource) []string
+}
+
+func (r Rule) appliesTo(resourceType string) bool {
+	if len(r.ResourceTypes) == 0 {
+		return true
+	}
+	for _, t := range r.ResourceTypes {
+		if t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Violation is a rule a resource does not satisfy
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Address  string   `json:"address"`
+	Message  string   `json:"message"`
+	// Waiver is the reason the violation was waived, if it was
+	Waiver string `json:"waiver,omitempty"`
+}
+
+// Waived reports whether a waiver covers the violation
+func (v Violation) Waived() bool {
+	return v.Waiver != ""
+}
+
+func (v Violation) String() string {
+	s := fmt.Sprintf("%s: %s: [%s] %s", v.Severity, v.Address, v.Rule, v.Message)
+	if v.Waived() {
+		s += " (waived: " + v.Waiver + ")"
+	}
+	return s
+}
+
+// RuleSummary describes an evaluated rule
+type RuleSummary struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Severity    Severity `json:"severity"`
+	Resources   int      `json:"resources"`
+}
+
+// Result holds the violations of an evaluation
+type Result struct {
+	Rules      []RuleSummary `json:"rules"`
+	Violations []Violation   `json:"violations"`
+	// UnusedWaivers are the waivers that matched no violation, expired ones included
+	UnusedWaivers []Waiver `json:"unusedWaivers,omitempty"`
+}
+
+// Failed reports whether any error violation is not waived
+func (r *Result) Failed() bool {
+	for _, v := range r.Violations {
+		if v.Severity == Error && !v.Waived() {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate applies the rules to every planned resource and applies the waivers to the violations
+func Evaluate(plan *terraform.PlanStruct, rules []Rule, waivers []Waiver) *Result {
+	ctx := &Context{Plan: plan}
+	resources := planfile.Resources(plan)
+	result := &Result{Rules: []RuleSummary{}, Violations: []Violation{}}
+	used := make([]bool, len(waivers))
+
+	for _, rule := range rules {
+		summary := RuleSummary{ID: rule.ID, Description: rule.Description, Severity: rule.Severity}
+		for _, r := range resources {
+			if !rule.appliesTo(r.Type) {
+				continue
+			}
+			summary.Resources++
+			for _, msg := range rule.Check(ctx, r) {
+				v := Violation{Rule: rule.ID, Severity: rule.Severity, Address: r.Address, Message: msg}
+				for i, w := range waivers {
+					if w.covers(v) {
+						v.Waiver = w.Reason
+						used[i] = true
+						break
+					}
+				}
+				result.Violations = append(result.Violations, v)
+			}
+		}
+		result.Rules = append(result.Rules, summary)
+	}
+
+	for i, w := range waivers {
+		if !used[i] {
+			result.UnusedWaivers = append(result.UnusedWaivers, w)
+		}
+	}
+	sort.SliceStable(result.Violations, func(i, j int) bool {
+		return result.Violations[i].Address < result.Violations[j].Address
+	})
+	return result
+}
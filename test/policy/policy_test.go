@@ -0,0 +1,256 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type values = map[string]interface{}
+
+type list = []interface{}
+
+// testPlan builds a plan from resource addresses and their planned values
+func testPlan(variables values, resources map[string]values) *terraform.PlanStruct {
+	plan := &terraform.PlanStruct{
+		ResourcePlannedValuesMap: map[string]*tfjson.StateResource{},
+		RawPlan:                  tfjson.Plan{Variables: map[string]*tfjson.PlanVariable{}},
+	}
+	for name, v := range variables {
+		plan.RawPlan.Variables[name] = &tfjson.PlanVariable{Value: v}
+	}
+	for address, v := range resources {
+		plan.ResourcePlannedValuesMap[address] = &tfjson.StateResource{
+			Address:         address,
+			Mode:            tfjson.ManagedResourceMode,
+			Type:            resourceType(address),
+			AttributeValues: v,
+		}
+	}
+	return plan
+}
+
+func resourceType(address string) string {
+	for _, t := range []string{"aws_instance", "aws_launch_template", "aws_ebs_volume", "aws_efs_file_system",
+		"aws_db_instance", "aws_fsx_ontap_file_system", "aws_vpc_security_group_ingress_rule"} {
+		if strings.Contains(address, t+".") {
+			return t
+		}
+	}
+	return ""
+}
+
+func violations(r *Result) []string {
+	messages := []string{}
+	for _, v := range r.Violations {
+		messages = append(messages, v.String())
+	}
+	return messages
+}
+
+func TestBuiltin(t *testing.T) {
+	t.Parallel()
+
+	compliantDB := values{"storage_encrypted": true, "publicly_accessible": false, "deletion_protection": false, "tags_all": values{"environment": "dev"}}
+	tests := map[string]struct {
+		variables values
+		resources map[string]values
+		expected  []string
+	}{
+		"compliant": {
+			resources: map[string]values{
+				"module.jump[0].aws_instance.vm": {
+					"metadata_options":  list{values{"http_tokens": "required"}},
+					"root_block_device": list{values{"encrypted": true}},
+				},
+				"aws_efs_file_system.efs-fs[0]":                          {"encrypted": true},
+				`module.postgresql["default"].aws_db_instance.this[0]`:   compliantDB,
+				`aws_vpc_security_group_ingress_rule.vms["10.0.0.0/16"]`: {"cidr_ipv4": "10.0.0.0/16", "ip_protocol": "tcp", "from_port": float64(22), "to_port": float64(22)},
+			},
+			expected: []string{},
+		},
+		"imds": {
+			resources: map[string]values{
+				"module.jump[0].aws_instance.vm": {"root_block_device": list{values{"encrypted": true}}},
+				`module.eks.aws_launch_template.this[0]`: {
+					"metadata_options": list{values{"http_tokens": "optional"}},
+				},
+			},
+			expected: []string{
+				"error: module.eks.aws_launch_template.this[0]: [IAC-IMDS-001] metadata_options.http_tokens is optional, expected required",
+				"error: module.jump[0].aws_instance.vm: [IAC-IMDS-001] metadata_options is not set, so IMDSv1 stays enabled unless the AMI or account default requires tokens",
+			},
+		},
+		"encryption": {
+			resources: map[string]values{
+				"module.nfs[0].aws_ebs_volume.raid_disk[0]": {"encrypted": false},
+				`module.eks.aws_launch_template.this[0]`: {
+					"metadata_options":      list{values{"http_tokens": "required"}},
+					"block_device_mappings": list{values{"device_name": "/dev/xvda", "ebs": list{values{"encrypted": false}}}},
+				},
+				"aws_efs_file_system.efs-fs[0]":                  {"encrypted": false},
+				"aws_fsx_ontap_file_system.ontap-fs[0]":          {},
+				`module.postgresql["a"].aws_db_instance.this[0]`: {"storage_encrypted": false},
+			},
+			expected: []string{
+				"error: aws_efs_file_system.efs-fs[0]: [IAC-ENC-002] encryption is disabled, set enable_efs_encryption",
+				"note: aws_fsx_ontap_file_system.ontap-fs[0]: [IAC-ENC-004] encrypted with the AWS managed key, set kms_key_id to use a customer managed key",
+				"error: module.eks.aws_launch_template.this[0]: [IAC-ENC-001] block_device_mappings /dev/xvda is not encrypted, set enable_ebs_encryption",
+				"error: module.nfs[0].aws_ebs_volume.raid_disk[0]: [IAC-ENC-001] volume is not encrypted, set enable_ebs_encryption",
+				`error: module.postgresql["a"].aws_db_instance.this[0]: [IAC-ENC-003] storage encryption is disabled, set storage_encrypted in postgres_servers`,
+			},
+		},
+		"openIngress": {
+			resources: map[string]values{
+				`aws_vpc_security_group_ingress_rule.vms["0.0.0.0/0"]`: {"cidr_ipv4": "0.0.0.0/0", "ip_protocol": "tcp", "from_port": float64(22), "to_port": float64(22)},
+				`aws_vpc_security_group_ingress_rule.all`:              {"cidr_ipv6": "::/0", "ip_protocol": "-1"},
+			},
+			expected: []string{
+				`error: aws_vpc_security_group_ingress_rule.all: [IAC-NET-001] cidr_ipv6 ::/0 allows ingress from anywhere on all protocols`,
+				`error: aws_vpc_security_group_ingress_rule.vms["0.0.0.0/0"]: [IAC-NET-001] cidr_ipv4 0.0.0.0/0 allows ingress from anywhere on tcp port 22`,
+			},
+		},
+		"publicDatabase": {
+			variables: values{"postgres_public_access_cidrs": nil, "default_public_access_cidrs": list{}},
+			resources: map[string]values{
+				`module.postgresql["a"].aws_db_instance.this[0]`: {"storage_encrypted": true, "publicly_accessible": true},
+			},
+			expected: []string{
+				`error: module.postgresql["a"].aws_db_instance.this[0]: [IAC-RDS-001] publicly_accessible is true but postgres_public_access_cidrs and default_public_access_cidrs are empty`,
+			},
+		},
+		"publicDatabaseOptedIn": {
+			variables: values{"postgres_public_access_cidrs": list{"123.45.67.89/32"}},
+			resources: map[string]values{
+				`module.postgresql["a"].aws_db_instance.this[0]`: {"storage_encrypted": true, "publicly_accessible": true},
+			},
+			expected: []string{},
+		},
+		"productionDatabase": {
+			resources: map[string]values{
+				`module.postgresql["a"].aws_db_instance.this[0]`: {"storage_encrypted": true, "tags_all": values{"Environment": "Production"}},
+				`module.postgresql["b"].aws_db_instance.this[0]`: {"storage_encrypted": true, "tags": values{"env": "prod"}, "deletion_protection": true},
+			},
+			expected: []string{
+				`error: module.postgresql["a"].aws_db_instance.this[0]: [IAC-RDS-002] tagged as production but deletion_protection is disabled, set deletion_protection in postgres_servers`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := Evaluate(testPlan(tc.variables, tc.resources), Builtin(), nil)
+			assert.Equal(t, tc.expected, violations(r))
+		})
+	}
+}
+
+func TestWaivers(t *testing.T) {
+	t.Parallel()
+
+	plan := testPlan(nil, map[string]values{
+		`aws_vpc_security_group_ingress_rule.vms["0.0.0.0/0"]`: {"cidr_ipv4": "0.0.0.0/0", "ip_protocol": "tcp", "from_port": float64(22), "to_port": float64(22)},
+		"aws_efs_file_system.efs-fs[0]":                        {"encrypted": false},
+	})
+	waivers := []Waiver{
+		{Rule: "IAC-NET-001", Address: `aws_vpc_security_group_ingress_rule.vms["*"]`, Reason: "behind the VPN", Expires: "2999-12-31"},
+		{Rule: "*", Address: "aws_efs_file_system.*", Reason: "migrating to ONTAP", Expires: "2020-01-31"},
+		{Rule: "IAC-RDS-002", Address: "*", Reason: "no databases yet"},
+	}
+
+	r := Evaluate(plan, Builtin(), waivers)
+	assert.Equal(t, []string{
+		"error: aws_efs_file_system.efs-fs[0]: [IAC-ENC-002] encryption is disabled, set enable_efs_encryption",
+		`error: aws_vpc_security_group_ingress_rule.vms["0.0.0.0/0"]: [IAC-NET-001] cidr_ipv4 0.0.0.0/0 allows ingress from anywhere on tcp port 22 (waived: behind the VPN)`,
+	}, violations(r))
+	assert.Equal(t, waivers[1:], r.UnusedWaivers)
+	assert.True(t, r.Failed())
+
+	r = Evaluate(plan, Builtin(), append(waivers, Waiver{Rule: "IAC-ENC-002", Address: "aws_efs_file_system.efs-fs[0]", Reason: "test data"}))
+	assert.False(t, r.Failed())
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadConfig("testdata/policy.yaml")
+	require.NoError(t, err)
+	rules, waivers := Combine(Builtin(), config)
+	require.Len(t, waivers, 2)
+
+	plan := testPlan(nil, map[string]values{
+		`module.eks.aws_launch_template.this[0]`: {
+			"metadata_options": list{values{"http_tokens": "required", "http_put_response_hop_limit": float64(2)}},
+		},
+		`module.postgresql["a"].aws_db_instance.this[0]`: {
+			"storage_encrypted": true, "deletion_protection": true, "backup_retention_period": float64(1), "tags_all": values{"environment": "prod"},
+		},
+		`module.postgresql["b"].aws_db_instance.this[0]`: {
+			"storage_encrypted": true, "backup_retention_period": float64(1), "tags_all": values{"environment": "dev"},
+		},
+		"aws_fsx_ontap_file_system.ontap-fs[0]": {},
+	})
+	r := Evaluate(plan, rules, waivers)
+	assert.Equal(t, []string{
+		"error: module.eks.aws_launch_template.this[0]: [ORG-LT-001] nodes must keep metadata_http_put_response_hop_limit at 1",
+		`warning: module.postgresql["a"].aws_db_instance.this[0]: [ORG-RDS-001] backup_retention_period is 1, expected one of [7,14,35]`,
+	}, violations(r))
+	for _, rule := range r.Rules {
+		assert.NotEqual(t, "IAC-ENC-004", rule.ID)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadConfig("testdata/missing.yaml")
+	assert.Error(t, err)
+
+	tests := map[string]string{
+		"severity": `{"id": "X", "severity": "fatal", "require": [{"path": "a", "equals": 1}]}`,
+		"require":  `{"id": "X", "severity": "error"}`,
+		"path":     `{"id": "X", "severity": "error", "require": [{"path": "{.a[", "equals": 1}]}`,
+		"pattern":  `{"id": "X", "severity": "error", "require": [{"path": "a", "pattern": "("}]}`,
+	}
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var d DeclarativeRule
+			require.NoError(t, json.Unmarshal([]byte(rule), &d))
+			assert.Error(t, d.compile())
+		})
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	plan := testPlan(nil, map[string]values{
+		"aws_efs_file_system.efs-fs[0]": {"encrypted": false},
+	})
+	r := Evaluate(plan, Builtin(), []Waiver{{Rule: "IAC-ENC-002", Address: "*", Reason: "test data"}})
+
+	var out bytes.Buffer
+	require.NoError(t, r.WriteSARIF(&out))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Builtin()))
+	require.Len(t, log.Runs[0].Results, 1)
+
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "IAC-ENC-002", result.RuleID)
+	assert.Equal(t, Error, result.Level)
+	assert.Equal(t, "aws_efs_file_system.efs-fs[0]", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, []sarifSuppression{{Kind: "external", Justification: "test data"}}, result.Suppressions)
+}
@@ -0,0 +1,41 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+
+	"test/report"
+)
+
+// Tables renders the violations and the waivers that matched nothing
+func (r *Result) Tables() []*report.Table {
+	violations := &report.Table{
+		Title:   fmt.Sprintf("Policy violations (%d rules evaluated)", len(r.Rules)),
+		Columns: []report.Column{{Header: "Severity"}, {Header: "Rule"}, {Header: "Address"}, {Header: "Message"}, {Header: "Waiver"}},
+	}
+	for _, v := range r.Violations {
+		violations.AddRow(string(v.Severity), v.Rule, v.Address, v.Message, v.Waiver)
+	}
+	if len(r.Violations) == 0 {
+		violations.AddRow("", "", "", "no violations", "")
+	}
+	tables := []*report.Table{violations}
+
+	if len(r.UnusedWaivers) > 0 {
+		waivers := &report.Table{
+			Title:   "Unused waivers",
+			Columns: []report.Column{{Header: "Rule"}, {Header: "Address"}, {Header: "Reason"}, {Header: "Expires"}},
+		}
+		for _, w := range r.UnusedWaivers {
+			expires := w.Expires
+			if w.Expired() {
+				expires += " (expired)"
+			}
+			waivers.AddRow(w.Rule, w.Address, w.Reason, expires)
+		}
+		tables = append(tables, waivers)
+	}
+	return tables
+}
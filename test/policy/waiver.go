@@ -0,0 +1,39 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"time"
+
+	"test/planfile"
+)
+
+// Waiver accepts the violations of a rule on matching resources
+type Waiver struct {
+	// Rule is the rule ID, or "*" for every rule
+	Rule string `json:"rule"`
+	// Address is a resource address, where "*" matches any characters
+	Address string `json:"address"`
+	// Reason is required and shows up in every report
+	Reason string `json:"reason"`
+	// Expires is an optional YYYY-MM-DD date after which the waiver no longer applies
+	Expires string `json:"expires,omitempty"`
+}
+
+// Expired reports whether the waiver's expiry date has passed
+func (w Waiver) Expired() bool {
+	if w.Expires == "" {
+		return false
+	}
+	expires, err := time.Parse("2006-01-02", w.Expires)
+	// an unreadable date never applies, rather than applying forever
+	return err != nil || time.Now().After(expires.AddDate(0, 0, 1))
+}
+
+func (w Waiver) covers(v Violation) bool {
+	if w.Expired() || (w.Rule != "*" && w.Rule != v.Rule) {
+		return false
+	}
+	return planfile.MatchAddress(w.Address, v.Address)
+}
@@ -0,0 +1,278 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reachability builds a graph of the planned security groups, their
+// rules and the resources attached to them, and answers which sources can
+// reach a resource on a port.
+//
+// Security group IDs are unknown until apply, so rules and attachments are
+// resolved to groups through the references in the plan's configuration.
+// Addresses of a VPC are unknown as well: a CIDR source counts as internal
+// when it contains a CIDR block of a planned VPC.
+package reachability
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// Ingress and Egress are rule directions
+const (
+	Ingress = "ingress"
+	Egress  = "egress"
+)
+
+// attachments are the attributes that attach a resource type to security groups
+var attachments = map[string]string{
+	"aws_db_instance":           "vpc_security_group_ids",
+	"aws_efs_mount_target":      "security_groups",
+	"aws_eks_cluster":           "vpc_config.security_group_ids",
+	"aws_fsx_ontap_file_system": "security_group_ids",
+	"aws_instance":              "vpc_security_group_ids",
+	"aws_launch_template":       "vpc_security_group_ids",
+	"aws_vpc_endpoint":          "security_group_ids",
+}
+
+// Rule is a security group rule
+type Rule struct {
+	Address   string `json:"address"`
+	Group     string `json:"group"`
+	Direction string `json:"direction"`
+	// Protocol is tcp, udp, icmp or -1 for all protocols
+	Protocol string `json:"protocol"`
+	FromPort int    `json:"fromPort"`
+	ToPort   int    `json:"toPort"`
+	// CIDRs, Peer or PrefixList is the source of ingress and the destination of egress
+	CIDRs      []string `json:"cidrs,omitempty"`
+	Peer       string   `json:"peer,omitempty"`
+	PrefixList string   `json:"prefixList,omitempty"`
+}
+
+// Allows reports whether the rule covers the protocol and port
+func (r Rule) Allows(protocol string, port int) bool {
+	if r.Protocol == "-1" {
+		return true
+	}
+	if r.Protocol != protocol {
+		return false
+	}
+	return protocol == "icmp" || (r.FromPort <= port && port <= r.ToPort)
+}
+
+// Endpoint is a resource attached to security groups
+type Endpoint struct {
+	Address string   `json:"address"`
+	Type    string   `json:"type"`
+	Groups  []string `json:"groups"`
+}
+
+// Graph holds the security groups of a plan
+type Graph struct {
+	// Groups are the addresses of planned groups and the IDs of existing ones
+	Groups    []string   `json:"groups"`
+	Rules     []Rule     `json:"rules"`
+	Endpoints []Endpoint `json:"endpoints"`
+	VPCCIDRs  []string   `json:"vpcCidrs"`
+	// Unresolved are the rules and attachments whose groups could not be resolved
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// Build reads the security groups, rules and attached resources of the plan
+func Build(plan *terraform.PlanStruct) *Graph {
+	g := &Graph{Groups: []string{}, Rules: []Rule{}, Endpoints: []Endpoint{}, VPCCIDRs: []string{}}
+	resources := planfile.Resources(plan)
+	planned := map[string]bool{}
+	for _, r := range resources {
+		switch r.Type {
+		case "aws_security_group":
+			planned[r.Address] = true
+		case "aws_vpc":
+			if cidr := r.Values.String("cidr_block"); cidr != "" {
+				g.VPCCIDRs = append(g.VPCCIDRs, cidr)
+			}
+		}
+	}
+	res := newResolver(plan, planned)
+
+	groups := map[string]bool{}
+	for address := range planned {
+		groups[address] = true
+	}
+	for _, r := range resources {
+		var rules []Rule
+		switch r.Type {
+		case "aws_vpc_security_group_ingress_rule", "aws_vpc_security_group_egress_rule":
+			rules = vpcRule(res, r)
+		case "aws_security_group_rule":
+			rules = legacyRule(res, r)
+		case "aws_security_group":
+			rules = inlineRules(r)
+		default:
+			attr, ok := attachments[r.Type]
+			if !ok {
+				continue
+			}
+			attached := res.groups(r, attr)
+			if len(attached) == 0 {
+				g.Unresolved = append(g.Unresolved, r.Address)
+				continue
+			}
+			for _, group := range attached {
+				groups[group] = true
+			}
+			g.Endpoints = append(g.Endpoints, Endpoint{Address: r.Address, Type: r.Type, Groups: attached})
+			continue
+		}
+		for _, rule := range rules {
+			if rule.Group == "" {
+				g.Unresolved = append(g.Unresolved, rule.Address)
+				continue
+			}
+			groups[rule.Group] = true
+			g.Rules = append(g.Rules, rule)
+		}
+	}
+	for group := range groups {
+		g.Groups = append(g.Groups, group)
+	}
+	sort.Strings(g.Groups)
+	return g
+}
+
+// vpcRule reads an aws_vpc_security_group_ingress_rule or egress rule
+func vpcRule(res *resolver, r planfile.Resource) []Rule {
+	rule := Rule{
+		Address:    r.Address,
+		Direction:  Ingress,
+		Protocol:   protocol(r.Values.String("ip_protocol")),
+		FromPort:   int(r.Values.Number("from_port")),
+		ToPort:     int(r.Values.Number("to_port")),
+		PrefixList: r.Values.String("prefix_list_id"),
+	}
+	if r.Type == "aws_vpc_security_group_egress_rule" {
+		rule.Direction = Egress
+	}
+	rule.Group = first(res.groups(r, "security_group_id"))
+	for _, attr := range []string{"cidr_ipv4", "cidr_ipv6"} {
+		if cidr := r.Values.String(attr); cidr != "" {
+			rule.CIDRs = append(rule.CIDRs, cidr)
+		}
+	}
+	if res.configured(r, "referenced_security_group_id") {
+		if rule.Peer = first(res.groups(r, "referenced_security_group_id")); rule.Peer == "" {
+			// an unresolved peer is not an open rule
+			rule.Group = ""
+		}
+	}
+	return []Rule{rule}
+}
+
+// legacyRule reads an aws_security_group_rule
+func legacyRule(res *resolver, r planfile.Resource) []Rule {
+	rule := Rule{
+		Address:    r.Address,
+		Direction:  r.Values.String("type"),
+		Protocol:   protocol(r.Values.String("protocol")),
+		FromPort:   int(r.Values.Number("from_port")),
+		ToPort:     int(r.Values.Number("to_port")),
+		CIDRs:      append(r.Values.Strings("cidr_blocks"), r.Values.Strings("ipv6_cidr_blocks")...),
+		PrefixList: strings.Join(r.Values.Strings("prefix_list_ids"), ","),
+	}
+	rule.Group = first(res.groups(r, "security_group_id"))
+	switch {
+	case r.Values.Bool("self"):
+		rule.Peer = rule.Group
+	case res.configured(r, "source_security_group_id"):
+		if rule.Peer = first(res.groups(r, "source_security_group_id")); rule.Peer == "" {
+			rule.Group = ""
+		}
+	}
+	return []Rule{rule}
+}
+
+// inlineRules reads the ingress and egress blocks of an aws_security_group
+func inlineRules(r planfile.Resource) []Rule {
+	var rules []Rule
+	for _, direction := range []string{Ingress, Egress} {
+		for i, block := range r.Values.Blocks(direction) {
+			rule := Rule{
+				Address:   r.Address + "." + direction + "[" + strconv.Itoa(i) + "]",
+				Group:     r.Address,
+				Direction: direction,
+				Protocol:  protocol(block.String("protocol")),
+				FromPort:  int(block.Number("from_port")),
+				ToPort:    int(block.Number("to_port")),
+				CIDRs:     append(block.Strings("cidr_blocks"), block.Strings("ipv6_cidr_blocks")...),
+			}
+			if block.Bool("self") {
+				rule.Peer = r.Address
+			}
+			rules = append(rules, rule)
+			for _, peer := range block.Strings("security_groups") {
+				peered := rule
+				peered.CIDRs, peered.Peer = nil, peer
+				rules = append(rules, peered)
+			}
+		}
+	}
+	return rules
+}
+
+// protocol normalizes protocol names and numbers
+func protocol(p string) string {
+	switch strings.ToLower(p) {
+	case "-1", "all", "":
+		return "-1"
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	}
+	return strings.ToLower(p)
+}
+
+func first(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return list[0]
+}
+
+// Endpoint returns the endpoint with the given address
+func (g *Graph) Endpoint(address string) (Endpoint, bool) {
+	for _, e := range g.Endpoints {
+		if e.Address == address {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// internal reports whether a CIDR contains a planned VPC, so it matches
+// any private address of the VPC
+func (g *Graph) internal(cidr string) bool {
+	_, outer, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	outerOnes, outerBits := outer.Mask.Size()
+	for _, vpc := range g.VPCCIDRs {
+		_, inner, err := net.ParseCIDR(vpc)
+		if err != nil {
+			continue
+		}
+		innerOnes, innerBits := inner.Mask.Size()
+		if outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP) {
+			return true
+		}
+	}
+	return false
+}
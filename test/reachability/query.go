@@ -0,0 +1,150 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package reachability
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Source is a CIDR, security group or prefix list an ingress rule admits
+type Source struct {
+	CIDR       string `json:"cidr,omitempty"`
+	Group      string `json:"group,omitempty"`
+	PrefixList string `json:"prefixList,omitempty"`
+	// Rule is the address of the admitting rule
+	Rule string `json:"rule"`
+}
+
+func (s Source) String() string {
+	switch {
+	case s.CIDR != "":
+		return s.CIDR
+	case s.Group != "":
+		return s.Group
+	}
+	return s.PrefixList
+}
+
+// Path is the pair of rules that lets a source reach a destination
+type Path struct {
+	Egress  string `json:"egress"`
+	Ingress string `json:"ingress"`
+}
+
+func (g *Graph) endpoint(address string) (Endpoint, error) {
+	e, ok := g.Endpoint(address)
+	if !ok {
+		return e, fmt.Errorf("%s is not a resource attached to a security group", address)
+	}
+	return e, nil
+}
+
+// rules returns the rules of a direction on any of the groups that allow the protocol and port
+func (g *Graph) rules(direction string, groups []string, protocol string, port int) []Rule {
+	member := map[string]bool{}
+	for _, group := range groups {
+		member[group] = true
+	}
+	var rules []Rule
+	for _, r := range g.Rules {
+		if r.Direction == direction && member[r.Group] && r.Allows(protocol, port) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// Sources returns what the ingress rules of the destination admit on a protocol and port
+func (g *Graph) Sources(destination, protocol string, port int) ([]Source, error) {
+	e, err := g.endpoint(destination)
+	if err != nil {
+		return nil, err
+	}
+	sources := []Source{}
+	for _, r := range g.rules(Ingress, e.Groups, protocol, port) {
+		for _, cidr := range r.CIDRs {
+			sources = append(sources, Source{CIDR: cidr, Rule: r.Address})
+		}
+		if r.Peer != "" {
+			sources = append(sources, Source{Group: r.Peer, Rule: r.Address})
+		}
+		if r.PrefixList != "" {
+			sources = append(sources, Source{PrefixList: r.PrefixList, Rule: r.Address})
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].String() < sources[j].String() })
+	return sources, nil
+}
+
+// CIDRs returns the distinct CIDRs that can reach the destination on a protocol and port
+func (g *Graph) CIDRs(destination, protocol string, port int) ([]string, error) {
+	sources, err := g.Sources(destination, protocol, port)
+	if err != nil {
+		return nil, err
+	}
+	cidrs := []string{}
+	seen := map[string]bool{}
+	for _, s := range sources {
+		if s.CIDR != "" && !seen[s.CIDR] {
+			seen[s.CIDR] = true
+			cidrs = append(cidrs, s.CIDR)
+		}
+	}
+	return cidrs, nil
+}
+
+// Reach returns the rules that let the source resource reach the destination
+// resource on a protocol and port, or nil when none do
+func (g *Graph) Reach(source, destination, protocol string, port int) (*Path, error) {
+	from, err := g.endpoint(source)
+	if err != nil {
+		return nil, err
+	}
+	to, err := g.endpoint(destination)
+	if err != nil {
+		return nil, err
+	}
+	egress := g.match(g.rules(Egress, from.Groups, protocol, port), to.Groups)
+	ingress := g.match(g.rules(Ingress, to.Groups, protocol, port), from.Groups)
+	if egress == "" || ingress == "" {
+		return nil, nil
+	}
+	return &Path{Egress: egress, Ingress: ingress}, nil
+}
+
+// match returns the first rule whose peer is one of the groups, or whose CIDR
+// contains the VPC
+func (g *Graph) match(rules []Rule, groups []string) string {
+	for _, r := range rules {
+		for _, group := range groups {
+			if r.Peer == group {
+				return r.Address
+			}
+		}
+		for _, cidr := range r.CIDRs {
+			if g.internal(cidr) {
+				return r.Address
+			}
+		}
+	}
+	return ""
+}
+
+// Matrix returns for every pair of endpoints whether the first reaches the
+// second on a protocol and port, indexed like Endpoints
+func (g *Graph) Matrix(protocol string, port int) [][]bool {
+	matrix := make([][]bool, len(g.Endpoints))
+	for i, from := range g.Endpoints {
+		matrix[i] = make([]bool, len(g.Endpoints))
+		for j, to := range g.Endpoints {
+			if i == j {
+				continue
+			}
+			path, _ := g.Reach(from.Address, to.Address, protocol, port)
+			matrix[i][j] = path != nil
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,148 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package reachability
+
+import (
+	"bytes"
+	"testing"
+
+	"test/planfile"
+	"test/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jump     = "module.jump[0].aws_instance.vm"
+	nfs      = "module.nfs[0].aws_instance.vm"
+	postgres = `module.postgresql["default"].module.db_instance.aws_db_instance.this[0]`
+	cluster  = "module.eks.aws_eks_cluster.this[0]"
+	nodes    = `module.eks.module.eks_managed_node_group["default"].aws_launch_template.this[0]`
+
+	sg      = "aws_security_group.sg[0]"
+	workers = "aws_security_group.workers_security_group[0]"
+)
+
+func testGraph(t *testing.T) *Graph {
+	plan, err := planfile.Load("testdata/plan.json")
+	require.NoError(t, err)
+	return Build(plan)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	assert.Equal(t, []string{"aws_security_group.cluster_security_group[0]", sg, workers}, g.Groups)
+	assert.Equal(t, []string{"192.168.0.0/16"}, g.VPCCIDRs)
+	assert.Equal(t, []string{"aws_vpc_security_group_ingress_rule.unbound[0]"}, g.Unresolved)
+
+	groups := map[string][]string{}
+	for _, e := range g.Endpoints {
+		groups[e.Address] = e.Groups
+	}
+	assert.Equal(t, map[string][]string{
+		jump:     {sg, workers},
+		nfs:      {sg, workers},
+		postgres: {sg, workers},
+		cluster:  {"aws_security_group.cluster_security_group[0]"},
+		nodes:    {workers},
+	}, groups)
+}
+
+func TestReach(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	tests := map[string]struct {
+		from, to string
+		port     Port
+		expected *Path
+	}{
+		"jumpToNFS": {jump, nfs, Port{"tcp", 2049}, &Path{
+			Egress:  "aws_vpc_security_group_egress_rule.workers_security_group[0]",
+			Ingress: "aws_vpc_security_group_ingress_rule.all",
+		}},
+		"nodesToPostgres": {nodes, postgres, Port{"tcp", 5432}, &Path{
+			Egress:  "aws_vpc_security_group_egress_rule.workers_security_group[0]",
+			Ingress: "aws_vpc_security_group_ingress_rule.worker_self[0]",
+		}},
+		"nodesToAPI": {nodes, cluster, Port{"tcp", 443}, &Path{
+			Egress:  "aws_vpc_security_group_egress_rule.workers_security_group[0]",
+			Ingress: "aws_vpc_security_group_ingress_rule.cluster_ingress[0]",
+		}},
+		"clusterToKubelet": {cluster, nodes, Port{"tcp", 10250}, &Path{
+			Egress:  "aws_vpc_security_group_egress_rule.cluster_security_group[0]",
+			Ingress: "aws_vpc_security_group_ingress_rule.worker_cluster_api[0]",
+		}},
+		"clusterToJumpSSH": {cluster, jump, Port{"tcp", 22}, nil},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path, err := g.Reach(tc.from, tc.to, tc.port.Protocol, tc.port.Number)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, path)
+		})
+	}
+
+	_, err := g.Reach(jump, "aws_s3_bucket.missing", "tcp", 443)
+	assert.EqualError(t, err, "aws_s3_bucket.missing is not a resource attached to a security group")
+}
+
+func TestSources(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	cidrs, err := g.CIDRs(postgres, "tcp", 5432)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"123.45.67.89/32"}, cidrs)
+
+	sources, err := g.Sources(postgres, "tcp", 5432)
+	require.NoError(t, err)
+	assert.Equal(t, []Source{
+		{CIDR: "123.45.67.89/32", Rule: `aws_vpc_security_group_ingress_rule.postgres_external["default-123.45.67.89/32"]`},
+		{Group: "aws_security_group.cluster_security_group[0]", Rule: "aws_vpc_security_group_ingress_rule.worker_cluster_api[0]"},
+		{Group: sg, Rule: "aws_vpc_security_group_ingress_rule.all"},
+		{Group: sg, Rule: `aws_vpc_security_group_ingress_rule.postgres_internal["5432"]`},
+		{Group: workers, Rule: "aws_vpc_security_group_ingress_rule.worker_self[0]"},
+	}, sources)
+
+	cidrs, err = g.CIDRs(cluster, "tcp", 443)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.0/16"}, cidrs)
+}
+
+func TestMatrixTable(t *testing.T) {
+	t.Parallel()
+
+	port, err := ParsePort("22")
+	require.NoError(t, err)
+	assert.Equal(t, Port{"tcp", 22}, port)
+	_, err = ParsePort("tcp/ssh")
+	assert.Error(t, err)
+
+	g := testGraph(t)
+	var out bytes.Buffer
+	require.NoError(t, g.MatrixTable(port).WriteMarkdown(&out))
+	assert.Equal(t, "### Reachability on tcp/22\n\n"+
+		"| # | From | 1 | 2 | 3 | 4 | 5 |\n"+
+		"| ---: | --- | --- | --- | --- | --- | --- |\n"+
+		"| 1 | "+cluster+" | - |  |  |  |  |\n"+
+		"| 2 | "+nodes+" |  | - | x | x | x |\n"+
+		"| 3 | "+jump+" |  | x | - | x | x |\n"+
+		"| 4 | "+nfs+" |  | x | x | - | x |\n"+
+		"| 5 | "+postgres+" |  | x | x | x | - |\n", out.String())
+}
+
+func TestSourcesTable(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph(t)
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out, report.Text, g, g.SourcesTable(Port{"tcp", 22})))
+	assert.Contains(t, out.String(), `aws_vpc_security_group_ingress_rule.vms["123.45.67.89/32"]`)
+	assert.NotContains(t, out.String(), "postgres_external")
+}
@@ -0,0 +1,94 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package reachability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"test/report"
+)
+
+// Port is a protocol and port to render
+type Port struct {
+	Protocol string
+	Number   int
+}
+
+// ParsePort reads 5432 or udp/53, where tcp is the default protocol
+func ParsePort(s string) (Port, error) {
+	p := Port{Protocol: "tcp"}
+	number := s
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		p.Protocol, number = protocol(s[:i]), s[i+1:]
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 || n > 65535 {
+		return p, fmt.Errorf("invalid port %q, expected a number or protocol/number", s)
+	}
+	p.Number = n
+	return p, nil
+}
+
+func (p Port) String() string {
+	return fmt.Sprintf("%s/%d", p.Protocol, p.Number)
+}
+
+// MatrixTable renders which endpoint, numbered in the first column, reaches
+// which on the port
+func (g *Graph) MatrixTable(port Port) *report.Table {
+	t := &report.Table{
+		Title:   "Reachability on " + port.String(),
+		Columns: []report.Column{{Header: "#", Right: true}, {Header: "From"}},
+	}
+	for i := range g.Endpoints {
+		t.Columns = append(t.Columns, report.Column{Header: strconv.Itoa(i + 1)})
+	}
+	for i, row := range g.Matrix(port.Protocol, port.Number) {
+		cells := []string{strconv.Itoa(i + 1), g.Endpoints[i].Address}
+		for j, reachable := range row {
+			switch {
+			case i == j:
+				cells = append(cells, "-")
+			case reachable:
+				cells = append(cells, "x")
+			default:
+				cells = append(cells, "")
+			}
+		}
+		t.AddRow(cells...)
+	}
+	return t
+}
+
+// SourcesTable renders what can reach each endpoint on the port from outside
+// the planned security groups
+func (g *Graph) SourcesTable(port Port) *report.Table {
+	t := &report.Table{
+		Title:   "CIDRs and prefix lists admitted on " + port.String(),
+		Columns: []report.Column{{Header: "Destination"}, {Header: "Source"}, {Header: "Rule"}},
+	}
+	for _, e := range g.Endpoints {
+		sources, _ := g.Sources(e.Address, port.Protocol, port.Number)
+		for _, s := range sources {
+			if s.Group == "" {
+				t.AddRow(e.Address, s.String(), s.Rule)
+			}
+		}
+	}
+	return t
+}
+
+// UnresolvedTable renders the rules and attachments left out of the graph
+func (g *Graph) UnresolvedTable() *report.Table {
+	t := &report.Table{
+		Title:   "Unresolved security groups",
+		Columns: []report.Column{{Header: "Address"}},
+	}
+	for _, address := range g.Unresolved {
+		t.AddRow(address)
+	}
+	return t
+}
@@ -0,0 +1,231 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package reachability
+
+import (
+	"sort"
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// bindings stand in for the locals that hold security group IDs, which the
+// plan's configuration does not record. Keys are the module path and the local.
+var bindings = map[string][]string{
+	"local.security_group_id":         {"aws_security_group.sg[0]"},
+	"local.cluster_security_group_id": {"aws_security_group.cluster_security_group[0]"},
+	"local.workers_security_group_id": {"aws_security_group.workers_security_group[0]"},
+	// terraform-aws-modules/eks with create_cluster_security_group and create_node_security_group disabled
+	"module.eks.local.cluster_security_group_id":                        {"var.cluster_security_group_id"},
+	"module.eks.local.node_security_group_id":                           {"var.node_security_group_id"},
+	"module.eks.module.eks_managed_node_group.local.security_group_ids": {"var.vpc_security_group_ids"},
+}
+
+// maxDepth bounds reference chains, in case of cycles through bindings
+const maxDepth = 16
+
+// scope is a module instance
+type scope struct {
+	// prefix is the instance address, e.g. module.jump[0].
+	prefix string
+	// path is the configuration path, e.g. module.jump.
+	path   string
+	module *tfjson.ConfigModule
+	parent *scope
+	// call is the module block in the parent
+	call *tfjson.ModuleCall
+}
+
+type resolver struct {
+	root    *tfjson.ConfigModule
+	planned map[string]bool
+}
+
+func newResolver(plan *terraform.PlanStruct, planned map[string]bool) *resolver {
+	r := &resolver{planned: planned}
+	if plan.RawPlan.Config != nil {
+		r.root = plan.RawPlan.Config.RootModule
+	}
+	return r
+}
+
+// splitAddress splits a resource address on the dots outside index brackets
+func splitAddress(address string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range address {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, address[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, address[start:])
+}
+
+func stripIndex(s string) string {
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// resource returns the scope and configuration of a planned resource
+func (r *resolver) resource(address string) (*scope, *tfjson.ConfigResource) {
+	if r.root == nil {
+		return nil, nil
+	}
+	s := &scope{module: r.root}
+	parts := splitAddress(address)
+	for len(parts) > 2 && parts[0] == "module" {
+		name := stripIndex(parts[1])
+		call, ok := s.module.ModuleCalls[name]
+		if !ok || call.Module == nil {
+			return nil, nil
+		}
+		s = &scope{
+			prefix: s.prefix + "module." + parts[1] + ".",
+			path:   s.path + "module." + name + ".",
+			module: call.Module,
+			parent: s,
+			call:   call,
+		}
+		parts = parts[2:]
+	}
+	if len(parts) != 2 {
+		return nil, nil
+	}
+	for _, c := range s.module.Resources {
+		if c.Mode == tfjson.ManagedResourceMode && c.Type == parts[0] && c.Name == stripIndex(parts[1]) {
+			return s, c
+		}
+	}
+	return nil, nil
+}
+
+// expression returns the configured expression of an attribute, where
+// block.attribute reads an attribute of the first nested block
+func (r *resolver) expression(address, attr string) (*scope, *tfjson.Expression) {
+	s, c := r.resource(address)
+	if c == nil {
+		return nil, nil
+	}
+	expressions := c.Expressions
+	path := strings.Split(attr, ".")
+	for _, block := range path[:len(path)-1] {
+		e, ok := expressions[block]
+		if !ok || len(e.NestedBlocks) == 0 {
+			return nil, nil
+		}
+		expressions = e.NestedBlocks[0]
+	}
+	e, ok := expressions[path[len(path)-1]]
+	if !ok || e == nil {
+		return nil, nil
+	}
+	return s, e
+}
+
+// configured reports whether the attribute is set in the configuration
+func (r *resolver) configured(res planfile.Resource, attr string) bool {
+	_, e := r.expression(res.Address, attr)
+	return e != nil
+}
+
+// groups returns the security groups an attribute refers to: the planned IDs
+// when they are known, else the groups its references resolve to
+func (r *resolver) groups(res planfile.Resource, attr string) []string {
+	if ids := known(res.Values, attr); len(ids) > 0 {
+		return ids
+	}
+	s, e := r.expression(res.Address, attr)
+	if e == nil {
+		return nil
+	}
+	return r.resolve(s, e.References, 0)
+}
+
+// known returns the planned string values of an attribute, or nil if any is unknown
+func known(values planfile.Attributes, attr string) []string {
+	path := strings.Split(attr, ".")
+	for _, block := range path[:len(path)-1] {
+		blocks := values.Blocks(block)
+		if len(blocks) == 0 {
+			return nil
+		}
+		values = blocks[0]
+	}
+	switch v := values[path[len(path)-1]].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var ids []string
+		for _, item := range v {
+			id, ok := item.(string)
+			if !ok || id == "" {
+				return nil
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+func (r *resolver) resolve(s *scope, refs []string, depth int) []string {
+	if s == nil || depth > maxDepth {
+		return nil
+	}
+	var groups []string
+	seen := map[string]bool{}
+	add := func(list []string) {
+		for _, g := range list {
+			if !seen[g] {
+				seen[g] = true
+				groups = append(groups, g)
+			}
+		}
+	}
+	for _, ref := range refs {
+		parts := splitAddress(ref)
+		switch {
+		case parts[0] == "aws_security_group" && len(parts) > 1:
+			add(r.instances(s.prefix + "aws_security_group." + parts[1]))
+		case parts[0] == "local" && len(parts) > 1:
+			add(r.resolve(s, bindings[s.path+"local."+stripIndex(parts[1])], depth+1))
+		case parts[0] == "var" && len(parts) > 1 && s.call != nil:
+			if e, ok := s.call.Expressions[stripIndex(parts[1])]; ok && e != nil {
+				add(r.resolve(s.parent, e.References, depth+1))
+			}
+		}
+	}
+	return groups
+}
+
+// instances returns the planned groups an address refers to, every instance
+// when it has no index
+func (r *resolver) instances(address string) []string {
+	if r.planned[address] {
+		return []string{address}
+	}
+	var groups []string
+	if !strings.HasSuffix(address, "]") {
+		for g := range r.planned {
+			if strings.HasPrefix(g, address+"[") {
+				groups = append(groups, g)
+			}
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
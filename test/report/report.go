@@ -0,0 +1,190 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package report renders the results of the offline tools as aligned text
+// tables, Markdown or JSON.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format is an output format
+type Format string
+
+const (
+	Text     Format = "text"
+	JSON     Format = "json"
+	Markdown Format = "markdown"
+)
+
+// Formats lists the supported formats, for flag help
+var Formats = []Format{Text, JSON, Markdown}
+
+// ParseFormat returns the Format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported format %q, expected one of %v", name, Formats)
+}
+
+// Column is a table column
+type Column struct {
+	Header string
+	// Right aligns the column, for numbers
+	Right bool
+}
+
+// Table is a titled table with an optional footer row
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]string
+	Footer  []string
+}
+
+// AddRow appends a row, one cell per column
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// WriteText writes the table with space aligned columns
+func (t *Table) WriteText(w io.Writer) error {
+	widths := make([]int, len(t.Columns))
+	for i, c := range t.Columns {
+		widths[i] = utf8.RuneCountInString(c.Header)
+	}
+	for _, row := range t.allRows() {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var b strings.Builder
+	if t.Title != "" {
+		b.WriteString(t.Title + "\n\n")
+	}
+	headers := make([]string, len(t.Columns))
+	rules := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = c.Header
+		rules[i] = strings.Repeat("-", widths[i])
+	}
+	t.writeTextRow(&b, widths, headers)
+	t.writeTextRow(&b, widths, rules)
+	for _, row := range t.Rows {
+		t.writeTextRow(&b, widths, row)
+	}
+	if t.Footer != nil {
+		t.writeTextRow(&b, widths, rules)
+		t.writeTextRow(&b, widths, t.Footer)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (t *Table) writeTextRow(b *strings.Builder, widths []int, row []string) {
+	cells := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if c.Right {
+			cells[i] = pad + cell
+		} else {
+			cells[i] = cell + pad
+		}
+	}
+	b.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+}
+
+// WriteMarkdown writes the table as a GitHub flavored Markdown table
+func (t *Table) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	if t.Title != "" {
+		b.WriteString("### " + t.Title + "\n\n")
+	}
+	headers := make([]string, len(t.Columns))
+	aligns := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = escapeMarkdown(c.Header)
+		aligns[i] = "---"
+		if c.Right {
+			aligns[i] = "---:"
+		}
+	}
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("| " + strings.Join(aligns, " | ") + " |\n")
+	for _, row := range t.Rows {
+		writeMarkdownRow(&b, len(t.Columns), row, false)
+	}
+	if t.Footer != nil {
+		writeMarkdownRow(&b, len(t.Columns), t.Footer, true)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, columns int, row []string, bold bool) {
+	cells := make([]string, columns)
+	for i := range cells {
+		if i < len(row) {
+			cells[i] = escapeMarkdown(row[i])
+		}
+		if bold && cells[i] != "" {
+			cells[i] = "**" + cells[i] + "**"
+		}
+	}
+	b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+func (t *Table) allRows() [][]string {
+	if t.Footer == nil {
+		return t.Rows
+	}
+	return append(t.Rows[:len(t.Rows):len(t.Rows)], t.Footer)
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// WriteJSON writes v as indented JSON
+func WriteJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// Write renders the tables in the given format, or v for JSON
+func Write(w io.Writer, format Format, v interface{}, tables ...*Table) error {
+	if format == JSON {
+		return WriteJSON(w, v)
+	}
+	for i, t := range tables {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		var err error
+		if format == Markdown {
+			err = t.WriteMarkdown(w)
+		} else {
+			err = t.WriteText(w)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
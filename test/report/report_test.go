@@ -0,0 +1,65 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() *Table {
+	t := &Table{
+		Title:   "Costs",
+		Columns: []Column{{Header: "Name"}, {Header: "Monthly", Right: true}},
+		Footer:  []string{"Total", "110.00"},
+	}
+	t.AddRow("nat|gateway", "10.00")
+	t.AddRow("node", "100.00")
+	return t
+}
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Write(&b, Text, nil, testTable()))
+	assert.Equal(t, `Costs
+
+Name         Monthly
+-----------  -------
+nat|gateway    10.00
+node          100.00
+-----------  -------
+Total         110.00
+`, b.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Write(&b, Markdown, nil, testTable()))
+	assert.Equal(t, `### Costs
+
+| Name | Monthly |
+| --- | ---: |
+| nat\|gateway | 10.00 |
+| node | 100.00 |
+| **Total** | **110.00** |
+`, b.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Write(&b, JSON, map[string]int{"total": 110}, testTable()))
+	assert.JSONEq(t, `{"total": 110}`, b.String())
+
+	_, err := ParseFormat("html")
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sizing
+
+import "test/ec2catalog"
+
+// Resources is an amount of CPU cores and memory
+type Resources struct {
+	CPU       float64 `json:"cpu"`
+	MemoryGiB float64 `json:"memoryGiB"`
+}
+
+func (r Resources) add(o Resources) Resources {
+	return Resources{r.CPU + o.CPU, r.MemoryGiB + o.MemoryGiB}
+}
+
+func (r Resources) times(n int) Resources {
+	return Resources{r.CPU * float64(n), r.MemoryGiB * float64(n)}
+}
+
+// Allocatable returns the resources kubelet leaves for pods on a node, using the
+// kube-reserved and eviction threshold formulas of the EKS optimized AMIs
+func Allocatable(it ec2catalog.InstanceType, maxPods int) Resources {
+	// CPU: 6% of the first core, 1% of the second, 0.5% of the next two and 0.25% of the rest
+	var reservedMilli float64
+	for core := 1; core <= it.VCPUs; core++ {
+		switch {
+		case core == 1:
+			reservedMilli += 60
+		case core == 2:
+			reservedMilli += 10
+		case core <= 4:
+			reservedMilli += 5
+		default:
+			reservedMilli += 2.5
+		}
+	}
+	// Memory: 255Mi plus 11Mi per pod, and the 100Mi hard eviction threshold
+	reservedMiB := 255 + 11*float64(maxPods) + 100
+
+	return Resources{
+		CPU:       float64(it.VCPUs) - reservedMilli/1000,
+		MemoryGiB: (float64(it.MemoryMiB) - reservedMiB) / 1024,
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sizing checks that the node pools give every SAS Viya workload class
+// the schedulable capacity of a sizing profile, and that the workload class
+// labels and taints of each pool agree.
+package sizing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"test/ec2catalog"
+	"test/nodepool"
+	"test/tfvars"
+)
+
+// KnownClasses are the workload.sas.com/class values SAS Viya uses
+var KnownClasses = []string{"cas", "compute", "connect", "stateful", "stateless"}
+
+// Options controls how capacity is computed
+type Options struct {
+	// Autoscaling tells whether node groups can grow past their minimum size
+	Autoscaling bool
+	// MaxPods is the pod limit kubelet reserves memory for, defaults to the profiles' maxPods
+	MaxPods int
+}
+
+// ClassCapacity is the schedulable capacity of one workload class
+type ClassCapacity struct {
+	Class string   `json:"class"`
+	Pools []string `json:"pools"`
+	// Shared is set when no pool is dedicated to the class and it runs on the untainted pools
+	Shared   bool        `json:"shared,omitempty"`
+	Node     Resources   `json:"node"`
+	Min      Resources   `json:"min"`
+	Max      Resources   `json:"max"`
+	Required Requirement `json:"required"`
+}
+
+// Report is the result of a sizing check
+type Report struct {
+	Profile  string               `json:"profile"`
+	Classes  []ClassCapacity      `json:"classes"`
+	Findings []ec2catalog.Finding `json:"findings"`
+}
+
+// HasErrors reports whether any finding is an error
+func (r *Report) HasErrors() bool {
+	for _, f := range r.Findings {
+		if !f.IsWarning() {
+			return true
+		}
+	}
+	return false
+}
+
+type checker struct {
+	catalog *ec2catalog.Catalog
+	options Options
+	report  *Report
+}
+
+func (c *checker) add(severity ec2catalog.Severity, subject, format string, args ...interface{}) {
+	c.report.Findings = append(c.report.Findings, ec2catalog.Finding{Severity: severity, Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+// Check computes the capacity of every class of the profile and checks the pools against it
+func Check(pools []Pool, catalog *ec2catalog.Catalog, profile *Profile, options Options) *Report {
+	if options.MaxPods == 0 {
+		options.MaxPods = DefaultProfiles().MaxPods
+	}
+	c := &checker{
+		catalog: catalog,
+		options: options,
+		report:  &Report{Profile: profile.Name, Classes: []ClassCapacity{}, Findings: []ec2catalog.Finding{}},
+	}
+
+	var untainted []Pool
+	for _, p := range pools {
+		c.checkLabels(p)
+		if !p.Tainted() {
+			untainted = append(untainted, p)
+		}
+	}
+	if len(untainted) == 0 {
+		c.add(ec2catalog.Error, "node pools", "every pool is tainted, so cluster services without tolerations can not be scheduled")
+	}
+
+	for _, class := range profile.ClassNames() {
+		var members []Pool
+		for _, p := range pools {
+			if p.Class() == class {
+				members = append(members, p)
+			}
+		}
+		shared := len(members) == 0
+		if shared {
+			c.add(ec2catalog.Warning, class, "no node pool is labeled %s=%s; its pods share the untainted pools", ClassLabel, class)
+			members = untainted
+		}
+		c.checkClass(class, members, shared, profile.Classes[class])
+	}
+	return c.report
+}
+
+func subject(p Pool) string {
+	if p.Name == tfvars.DefaultNodePoolName {
+		return "default node pool"
+	}
+	return fmt.Sprintf("node_pools[%q]", p.Name)
+}
+
+// checkLabels checks that a pool's workload class label and taint agree
+func (c *checker) checkLabels(p Pool) {
+	for _, e := range p.TaintErrors {
+		c.add(ec2catalog.Error, subject(p), "%s", e)
+	}
+
+	label, labeled := p.Labels[ClassLabel]
+	var taints []nodepool.Taint
+	for _, t := range p.Taints {
+		if t.Key == ClassLabel {
+			taints = append(taints, t)
+		}
+	}
+
+	if labeled && !contains(KnownClasses, label) {
+		c.add(ec2catalog.Warning, subject(p), "%s=%s is not a SAS Viya workload class, expected one of %s", ClassLabel, label, strings.Join(KnownClasses, ", "))
+	}
+	for _, t := range taints {
+		switch {
+		case !labeled:
+			c.add(ec2catalog.Error, subject(p), "taint %s has no matching %s label, so pods that tolerate it are never scheduled here", t, ClassLabel)
+		case t.Value != label:
+			c.add(ec2catalog.Error, subject(p), "taint %s does not match label %s=%s", t, ClassLabel, label)
+		case t.Effect != nodepool.NoSchedule:
+			c.add(ec2catalog.Warning, subject(p), "taint %s should use the NoSchedule effect SAS Viya tolerates", t)
+		}
+	}
+	if labeled && len(taints) == 0 {
+		c.add(ec2catalog.Warning, subject(p), "labeled %s=%s but has no matching taint, so other workloads can use its capacity", ClassLabel, label)
+	}
+}
+
+func (c *checker) checkClass(class string, members []Pool, shared bool, required Requirement) {
+	capacity := ClassCapacity{Class: class, Pools: []string{}, Shared: shared, Required: required}
+	first := true
+	for _, p := range members {
+		it, ok := c.catalog.Lookup(p.VMType)
+		if !ok {
+			c.add(ec2catalog.Warning, subject(p), "instance type %q is not in the catalog; its capacity is not counted", p.VMType)
+			continue
+		}
+		node := Allocatable(it, c.options.MaxPods)
+		capacity.Pools = append(capacity.Pools, p.Name)
+		capacity.Min = capacity.Min.add(node.times(p.MinNodes))
+		capacity.Max = capacity.Max.add(node.times(p.MaxNodes))
+		if first || node.CPU < capacity.Node.CPU {
+			capacity.Node.CPU = node.CPU
+		}
+		if first || node.MemoryGiB < capacity.Node.MemoryGiB {
+			capacity.Node.MemoryGiB = node.MemoryGiB
+		}
+		first = false
+
+		if node.CPU < required.NodeCPU || node.MemoryGiB < required.NodeMemoryGiB {
+			c.add(ec2catalog.Error, subject(p), "%s nodes allocate %s but %s pods need %s per node",
+				p.VMType, formatResources(node), class, formatResources(Resources{required.NodeCPU, required.NodeMemoryGiB}))
+		}
+		if required.LocalNVMe && !shared && !it.NVMeInstanceStorage {
+			c.add(ec2catalog.Warning, subject(p), "%s has no NVMe instance storage; CAS_DISK_CACHE will use the root volume", p.VMType)
+		}
+	}
+	c.report.Classes = append(c.report.Classes, capacity)
+
+	total := Resources{required.TotalCPU, required.TotalMemoryGiB}
+	if capacity.Max.CPU < total.CPU || capacity.Max.MemoryGiB < total.MemoryGiB {
+		c.add(ec2catalog.Error, class, "capacity at max_nodes is %s but the %s profile needs %s",
+			formatResources(capacity.Max), c.report.Profile, formatResources(total))
+		return
+	}
+	if capacity.Min.CPU < total.CPU || capacity.Min.MemoryGiB < total.MemoryGiB {
+		if c.options.Autoscaling {
+			c.add(ec2catalog.Warning, class, "capacity at min_nodes is %s, below the %s the %s profile needs until the cluster autoscaler adds nodes",
+				formatResources(capacity.Min), formatResources(total), c.report.Profile)
+		} else {
+			c.add(ec2catalog.Error, class, "capacity at min_nodes is %s but the %s profile needs %s, and autoscaling is disabled",
+				formatResources(capacity.Min), c.report.Profile, formatResources(total))
+		}
+	}
+}
+
+func formatResources(r Resources) string {
+	return fmt.Sprintf("%s CPU / %s GiB", trim(r.CPU), trim(r.MemoryGiB))
+}
+
+// trim formats a quantity with at most two decimals
+func trim(v float64) string {
+	return strconv.FormatFloat(math.Floor(v*100)/100, 'f', -1, 64)
+}
+
+func contains(list []string, s string) bool {
+	i := sort.SearchStrings(list, s)
+	return i < len(list) && list[i] == s
+}
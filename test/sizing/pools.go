@@ -0,0 +1,127 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sizing
+
+import (
+	"fmt"
+	"strings"
+
+	"test/nodepool"
+	"test/planfile"
+	"test/tfvars"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// ClassLabel is the node label and taint key SAS Viya schedules workloads by
+const ClassLabel = "workload.sas.com/class"
+
+// Pool is a node group with the attributes sizing depends on
+type Pool struct {
+	Name     string            `json:"name"`
+	VMType   string            `json:"vmType"`
+	MinNodes int               `json:"minNodes"`
+	MaxNodes int               `json:"maxNodes"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Taints   []nodepool.Taint  `json:"taints,omitempty"`
+	// TaintErrors holds the node_taints entries that could not be parsed
+	TaintErrors []string `json:"taintErrors,omitempty"`
+}
+
+// Class returns the workload class the pool is labeled for, or ""
+func (p Pool) Class() string {
+	return p.Labels[ClassLabel]
+}
+
+// Tainted reports whether pods need a toleration to run on the pool
+func (p Pool) Tainted() bool {
+	for _, t := range p.Taints {
+		if t.Effect == nodepool.NoSchedule || t.Effect == nodepool.NoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolsFromInputs returns the node pools configured by the inputs
+func PoolsFromInputs(in tfvars.Inputs) ([]Pool, error) {
+	nodePools, err := in.NodePools()
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]Pool, 0, len(nodePools))
+	for _, np := range nodePools {
+		p := Pool{
+			Name:     np.Name,
+			VMType:   np.VMType,
+			MinNodes: np.MinNodes,
+			MaxNodes: np.MaxNodes,
+			Labels:   np.NodeLabels,
+		}
+		for i, s := range np.NodeTaints {
+			t, err := nodepool.ParseTaint(s)
+			if err != nil {
+				p.TaintErrors = append(p.TaintErrors, fmt.Sprintf("node_taints[%d]: %v", i, err))
+				continue
+			}
+			p.Taints = append(p.Taints, t)
+		}
+		pools = append(pools, p)
+	}
+	return pools, nil
+}
+
+// PoolsFromPlan returns the planned managed node groups, named by their node_pools key
+func PoolsFromPlan(plan *terraform.PlanStruct) []Pool {
+	var pools []Pool
+	for _, r := range planfile.Resources(plan) {
+		if r.Type != "aws_eks_node_group" {
+			continue
+		}
+		p := Pool{Name: nodeGroupKey(r.Address), Labels: map[string]string{}}
+		if types := r.Values.Strings("instance_types"); len(types) > 0 {
+			p.VMType = types[0]
+		}
+		for _, scaling := range r.Values.Blocks("scaling_config") {
+			p.MinNodes = int(scaling.Number("min_size"))
+			p.MaxNodes = int(scaling.Number("max_size"))
+		}
+		if labels, ok := r.Values["labels"].(map[string]interface{}); ok {
+			for k, v := range labels {
+				p.Labels[k], _ = v.(string)
+			}
+		}
+		for _, t := range r.Values.Blocks("taint") {
+			taint, err := nodepool.EKSTaint(t.String("key"), t.String("value"), t.String("effect"))
+			if err != nil {
+				p.TaintErrors = append(p.TaintErrors, err.Error())
+				continue
+			}
+			p.Taints = append(p.Taints, taint)
+		}
+		pools = append(pools, p)
+	}
+	return pools
+}
+
+// nodeGroupKey returns the for_each key of the node group module in an address
+func nodeGroupKey(address string) string {
+	const marker = `eks_managed_node_group["`
+	i := strings.Index(address, marker)
+	if i < 0 {
+		return address
+	}
+	key, _, _ := strings.Cut(address[i+len(marker):], `"]`)
+	return key
+}
+
+// AutoscalingPlanned reports whether the plan deploys the cluster autoscaler role
+func AutoscalingPlanned(plan *terraform.PlanStruct) bool {
+	for address := range plan.ResourcePlannedValuesMap {
+		if strings.HasPrefix(address, "module.autoscaling[0].") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sizing
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+//go:embed profiles.json
+var embeddedProfiles []byte
+
+// Requirement is the schedulable capacity a profile needs for one workload class
+type Requirement struct {
+	// NodeCPU and NodeMemoryGiB are the allocatable resources every node of the class needs,
+	// so the largest pod of the class fits
+	NodeCPU       float64 `json:"nodeCPU"`
+	NodeMemoryGiB float64 `json:"nodeMemoryGiB"`
+	// TotalCPU and TotalMemoryGiB are the allocatable resources all nodes of the class need together
+	TotalCPU       float64 `json:"totalCPU"`
+	TotalMemoryGiB float64 `json:"totalMemoryGiB"`
+	// LocalNVMe asks for NVMe instance storage, which CAS uses for CAS_DISK_CACHE
+	LocalNVMe bool `json:"localNVMe,omitempty"`
+}
+
+// Profile is a named set of requirements per workload class
+type Profile struct {
+	Name        string                 `json:"-"`
+	Description string                 `json:"description"`
+	Classes     map[string]Requirement `json:"classes"`
+}
+
+// ClassNames returns the workload classes of the profile, sorted
+func (p *Profile) ClassNames() []string {
+	names := make([]string, 0, len(p.Classes))
+	for name := range p.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profiles is a versioned set of sizing profiles in the profiles.json format
+type Profiles struct {
+	Version        string              `json:"version"`
+	MaxPods        int                 `json:"maxPods"`
+	DefaultProfile string              `json:"defaultProfile"`
+	Profiles       map[string]*Profile `json:"profiles"`
+}
+
+// Get returns the profile with the given name, or the default profile for ""
+func (ps *Profiles) Get(name string) (*Profile, error) {
+	if name == "" {
+		name = ps.DefaultProfile
+	}
+	p, ok := ps.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown sizing profile %q, expected one of %v", name, ps.Names())
+	}
+	return p, nil
+}
+
+// Names returns the profile names, sorted
+func (ps *Profiles) Names() []string {
+	names := make([]string, 0, len(ps.Profiles))
+	for name := range ps.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadProfiles reads sizing profiles in the profiles.json format
+func LoadProfiles(r io.Reader) (*Profiles, error) {
+	var ps Profiles
+	if err := json.NewDecoder(r).Decode(&ps); err != nil {
+		return nil, fmt.Errorf("decoding sizing profiles: %w", err)
+	}
+	for name, p := range ps.Profiles {
+		p.Name = name
+	}
+	return &ps, nil
+}
+
+var (
+	defaultProfiles *Profiles
+	defaultOnce     sync.Once
+)
+
+// DefaultProfiles returns the sizing profiles embedded in this package
+func DefaultProfiles() *Profiles {
+	defaultOnce.Do(func() {
+		var ps Profiles
+		if err := json.Unmarshal(embeddedProfiles, &ps); err != nil {
+			panic(fmt.Sprintf("embedded sizing profiles are invalid: %v", err))
+		}
+		for name, p := range ps.Profiles {
+			p.Name = name
+		}
+		defaultProfiles = &ps
+	})
+	return defaultProfiles
+}
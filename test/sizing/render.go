@@ -0,0 +1,51 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sizing
+
+import (
+	"fmt"
+	"strings"
+
+	"test/report"
+)
+
+// Tables renders the capacity per class and the findings
+func (r *Report) Tables() []*report.Table {
+	capacity := &report.Table{
+		Title: fmt.Sprintf("Schedulable capacity per workload class (profile %s)", r.Profile),
+		Columns: []report.Column{
+			{Header: "Class"},
+			{Header: "Pools"},
+			{Header: "Node CPU", Right: true},
+			{Header: "Node GiB", Right: true},
+			{Header: "Min CPU", Right: true},
+			{Header: "Min GiB", Right: true},
+			{Header: "Max CPU", Right: true},
+			{Header: "Max GiB", Right: true},
+			{Header: "Needs CPU", Right: true},
+			{Header: "Needs GiB", Right: true},
+		},
+	}
+	for _, c := range r.Classes {
+		pools := strings.Join(c.Pools, ", ")
+		if c.Shared {
+			pools += " (shared)"
+		}
+		capacity.AddRow(c.Class, pools, trim(c.Node.CPU), trim(c.Node.MemoryGiB), trim(c.Min.CPU), trim(c.Min.MemoryGiB),
+			trim(c.Max.CPU), trim(c.Max.MemoryGiB), trim(c.Required.TotalCPU), trim(c.Required.TotalMemoryGiB))
+	}
+	tables := []*report.Table{capacity}
+
+	if len(r.Findings) > 0 {
+		findings := &report.Table{
+			Title:   "Findings",
+			Columns: []report.Column{{Header: "Severity"}, {Header: "Subject"}, {Header: "Message"}},
+		}
+		for _, f := range r.Findings {
+			findings.AddRow(string(f.Severity), f.Subject, f.Message)
+		}
+		tables = append(tables, findings)
+	}
+	return tables
+}
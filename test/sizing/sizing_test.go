@@ -0,0 +1,161 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sizing
+
+import (
+	"testing"
+
+	"test/ec2catalog"
+	"test/nodepool"
+	"test/tfvars"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func messages(findings []ec2catalog.Finding) []string {
+	list := []string{}
+	for _, f := range findings {
+		list = append(list, f.String())
+	}
+	return list
+}
+
+func TestAllocatable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		vmType   string
+		expected string
+	}{
+		"r6idn.2xlarge": {"r6idn.2xlarge", "7.91 CPU / 62.47 GiB"},
+		"m6in.xlarge":   {"m6in.xlarge", "3.92 CPU / 14.47 GiB"},
+		"m5.large":      {"m5.large", "1.93 CPU / 6.47 GiB"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			it, ok := ec2catalog.Default().Lookup(tc.vmType)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, formatResources(Allocatable(it, 110)))
+		})
+	}
+}
+
+func TestCheckSampleInput(t *testing.T) {
+	t.Parallel()
+
+	in, err := tfvars.LoadInputs("../..", "../../examples/sample-input.tfvars")
+	require.NoError(t, err)
+	pools, err := PoolsFromInputs(in)
+	require.NoError(t, err)
+
+	profile, err := DefaultProfiles().Get("")
+	require.NoError(t, err)
+	assert.Equal(t, "small", profile.Name)
+
+	r := Check(pools, ec2catalog.Default(), profile, Options{Autoscaling: true})
+	assert.False(t, r.HasErrors(), messages(r.Findings))
+
+	r = Check(pools, ec2catalog.Default(), profile, Options{Autoscaling: false})
+	assert.Equal(t, []string{
+		"error: stateful: capacity at min_nodes is 3.92 CPU / 14.47 GiB but the small profile needs 7 CPU / 26 GiB, and autoscaling is disabled",
+		"error: stateless: capacity at min_nodes is 3.92 CPU / 14.47 GiB but the small profile needs 14 CPU / 52 GiB, and autoscaling is disabled",
+	}, messages(r.Findings))
+
+	large, err := DefaultProfiles().Get("large")
+	require.NoError(t, err)
+	r = Check(pools, ec2catalog.Default(), large, Options{Autoscaling: true})
+	assert.Contains(t, messages(r.Findings), `error: node_pools["cas"]: r6idn.2xlarge nodes allocate 7.91 CPU / 62.47 GiB but cas pods need 31 CPU / 240 GiB per node`)
+	assert.Contains(t, messages(r.Findings), "error: cas: capacity at max_nodes is 39.54 CPU / 312.35 GiB but the large profile needs 124 CPU / 960 GiB")
+}
+
+func TestCheckLabels(t *testing.T) {
+	t.Parallel()
+
+	profile := &Profile{Name: "empty", Classes: map[string]Requirement{}}
+	pool := func(labels map[string]string, taints ...string) []Pool {
+		p := Pool{Name: "cas", VMType: "r6idn.2xlarge", MinNodes: 1, MaxNodes: 1, Labels: labels}
+		for _, s := range taints {
+			taint, err := nodepool.ParseTaint(s)
+			if err != nil {
+				p.TaintErrors = append(p.TaintErrors, err.Error())
+				continue
+			}
+			p.Taints = append(p.Taints, taint)
+		}
+		return []Pool{{Name: "default", VMType: "m6in.xlarge"}, p}
+	}
+
+	tests := map[string]struct {
+		pools    []Pool
+		expected []string
+	}{
+		"consistent": {
+			pools:    pool(map[string]string{ClassLabel: "cas"}, "workload.sas.com/class=cas:NoSchedule"),
+			expected: []string{},
+		},
+		"taintWithoutLabel": {
+			pools:    pool(nil, "workload.sas.com/class=cas:NoSchedule"),
+			expected: []string{`error: node_pools["cas"]: taint workload.sas.com/class=cas:NoSchedule has no matching workload.sas.com/class label, so pods that tolerate it are never scheduled here`},
+		},
+		"mismatch": {
+			pools:    pool(map[string]string{ClassLabel: "compute"}, "workload.sas.com/class=cas:NoSchedule"),
+			expected: []string{`error: node_pools["cas"]: taint workload.sas.com/class=cas:NoSchedule does not match label workload.sas.com/class=compute`},
+		},
+		"labelWithoutTaint": {
+			pools:    pool(map[string]string{ClassLabel: "cas"}),
+			expected: []string{`warning: node_pools["cas"]: labeled workload.sas.com/class=cas but has no matching taint, so other workloads can use its capacity`},
+		},
+		"unknownClass": {
+			pools: pool(map[string]string{ClassLabel: "gpu"}, "workload.sas.com/class=gpu:NoSchedule"),
+			expected: []string{
+				`warning: node_pools["cas"]: workload.sas.com/class=gpu is not a SAS Viya workload class, expected one of cas, compute, connect, stateful, stateless`,
+			},
+		},
+		"preferNoSchedule": {
+			pools:    pool(map[string]string{ClassLabel: "cas"}, "workload.sas.com/class=cas:PreferNoSchedule"),
+			expected: []string{`warning: node_pools["cas"]: taint workload.sas.com/class=cas:PreferNoSchedule should use the NoSchedule effect SAS Viya tolerates`},
+		},
+		"malformedTaint": {
+			pools:    pool(map[string]string{ClassLabel: "cas"}, "workload.sas.com/class=cas"),
+			expected: []string{`error: node_pools["cas"]: taint "workload.sas.com/class=cas" has no effect, expected key=value:Effect`, `warning: node_pools["cas"]: labeled workload.sas.com/class=cas but has no matching taint, so other workloads can use its capacity`},
+		},
+		"allTainted": {
+			pools:    pool(map[string]string{ClassLabel: "cas"}, "workload.sas.com/class=cas:NoSchedule")[1:],
+			expected: []string{"error: node pools: every pool is tainted, so cluster services without tolerations can not be scheduled"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := Check(tc.pools, ec2catalog.Default(), profile, Options{})
+			assert.Equal(t, tc.expected, messages(r.Findings))
+		})
+	}
+}
+
+func TestPoolsFromPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := &terraform.PlanStruct{ResourcePlannedValuesMap: map[string]*tfjson.StateResource{
+		`module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]`: {Type: "aws_eks_node_group", AttributeValues: map[string]interface{}{
+			"instance_types": []interface{}{"r6idn.2xlarge"},
+			"scaling_config": []interface{}{map[string]interface{}{"min_size": float64(1), "desired_size": float64(1), "max_size": float64(5)}},
+			"labels":         map[string]interface{}{ClassLabel: "cas"},
+			"taint":          []interface{}{map[string]interface{}{"key": ClassLabel, "value": "cas", "effect": "NO_SCHEDULE"}},
+		}},
+		"module.autoscaling[0].aws_iam_policy.worker_autoscaling": {Type: "aws_iam_policy"},
+	}}
+
+	assert.Equal(t, []Pool{{
+		Name:     "cas",
+		VMType:   "r6idn.2xlarge",
+		MinNodes: 1,
+		MaxNodes: 5,
+		Labels:   map[string]string{ClassLabel: "cas"},
+		Taints:   []nodepool.Taint{{Key: ClassLabel, Value: "cas", Effect: nodepool.NoSchedule}},
+	}}, PoolsFromPlan(plan))
+	assert.True(t, AutoscalingPlanned(plan))
+}
@@ -0,0 +1,195 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statefile reads terraform state, either a raw .tfstate file or the
+// output of `terraform show -json`, into a flat list of resource instances.
+package statefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"test/planfile"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Resource is a resource instance in the state
+type Resource struct {
+	Address string
+	// Module is the module path, empty in the root module
+	Module string
+	Mode   tfjson.ResourceMode
+	Type   string
+	Name   string
+	// Index is the count index or for_each key, nil without either
+	Index interface{}
+	// Values are the attribute values of the instance
+	Values planfile.Attributes
+	// Tainted is set for instances that will be replaced on the next apply
+	Tainted bool
+	// DependsOn are the resources the instance depended on when it was
+	// created, only recorded in raw state files
+	DependsOn []string
+}
+
+// Output is a root module output in the state
+type Output struct {
+	Value     interface{} `json:"value"`
+	Sensitive bool        `json:"sensitive"`
+}
+
+// State is the resources and root module outputs of a state
+type State struct {
+	TerraformVersion string
+	Resources        []Resource
+	Outputs          map[string]Output
+}
+
+// Managed returns the managed resources of a type
+func (s *State) Managed(resourceType string) []Resource {
+	var resources []Resource
+	for _, r := range s.Resources {
+		if r.Mode == tfjson.ManagedResourceMode && r.Type == resourceType {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+// Load reads a state from a file, see Parse
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Parse decodes a raw .tfstate file, recognized by its top level resources
+// key, or `terraform show -json` output
+func Parse(data []byte) (*State, error) {
+	var raw rawState
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("decoding state: %w", err)
+	}
+
+	var state *State
+	if raw.Resources != nil || raw.Version != 0 {
+		if raw.Version != 4 {
+			return nil, fmt.Errorf("unsupported state version %d, expected 4", raw.Version)
+		}
+		state = raw.state()
+	} else {
+		var shown tfjson.State
+		if err := json.Unmarshal(data, &shown); err != nil {
+			return nil, fmt.Errorf("decoding state: %w", err)
+		}
+		state = &State{TerraformVersion: shown.TerraformVersion, Outputs: map[string]Output{}}
+		if shown.Values != nil {
+			state.addModule(shown.Values.RootModule)
+			for name, o := range shown.Values.Outputs {
+				state.Outputs[name] = Output{Value: o.Value, Sensitive: o.Sensitive}
+			}
+		}
+	}
+	sort.SliceStable(state.Resources, func(i, j int) bool {
+		return state.Resources[i].Address < state.Resources[j].Address
+	})
+	return state, nil
+}
+
+func (s *State) addModule(m *tfjson.StateModule) {
+	if m == nil {
+		return
+	}
+	for _, r := range m.Resources {
+		s.Resources = append(s.Resources, Resource{
+			Address: r.Address,
+			Module:  m.Address,
+			Mode:    r.Mode,
+			Type:    r.Type,
+			Name:    r.Name,
+			Index:   r.Index,
+			Values:  r.AttributeValues,
+			Tainted: r.Tainted,
+		})
+	}
+	for _, child := range m.ChildModules {
+		s.addModule(child)
+	}
+}
+
+// rawState is the format terraform writes to .tfstate files
+type rawState struct {
+	Version          int               `json:"version"`
+	TerraformVersion string            `json:"terraform_version"`
+	Outputs          map[string]Output `json:"outputs"`
+	Resources        []struct {
+		Module    string              `json:"module"`
+		Mode      tfjson.ResourceMode `json:"mode"`
+		Type      string              `json:"type"`
+		Name      string              `json:"name"`
+		Instances []struct {
+			IndexKey     interface{}            `json:"index_key"`
+			Status       string                 `json:"status"`
+			Deposed      string                 `json:"deposed"`
+			Attributes   map[string]interface{} `json:"attributes"`
+			Dependencies []string               `json:"dependencies"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+func (raw *rawState) state() *State {
+	state := &State{TerraformVersion: raw.TerraformVersion, Outputs: raw.Outputs}
+	if state.Outputs == nil {
+		state.Outputs = map[string]Output{}
+	}
+	for _, r := range raw.Resources {
+		for _, inst := range r.Instances {
+			// Deposed objects are destroyed on the next apply and are not
+			// part of the current infrastructure
+			if inst.Deposed != "" {
+				continue
+			}
+			state.Resources = append(state.Resources, Resource{
+				Address:   address(r.Module, r.Mode, r.Type, r.Name, inst.IndexKey),
+				Module:    r.Module,
+				Mode:      r.Mode,
+				Type:      r.Type,
+				Name:      r.Name,
+				Index:     inst.IndexKey,
+				Values:    inst.Attributes,
+				Tainted:   inst.Status == "tainted",
+				DependsOn: inst.Dependencies,
+			})
+		}
+	}
+	return state
+}
+
+// address formats a resource instance address, like
+// module.eks.aws_eks_cluster.this[0] or data.aws_ami.ubuntu
+func address(module string, mode tfjson.ResourceMode, resourceType, name string, index interface{}) string {
+	a := resourceType + "." + name
+	if mode == tfjson.DataResourceMode {
+		a = "data." + a
+	}
+	if module != "" {
+		a = module + "." + a
+	}
+	switch key := index.(type) {
+	case float64:
+		a += "[" + strconv.FormatFloat(key, 'f', -1, 64) + "]"
+	case string:
+		a += "[" + strconv.Quote(key) + "]"
+	}
+	return a
+}
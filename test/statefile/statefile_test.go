@@ -0,0 +1,65 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package statefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A raw state file and `terraform show -json` of it read the same
+func TestLoadFormats(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"testdata/terraform.tfstate", "testdata/show.json"} {
+		t.Run(path, func(t *testing.T) {
+			state, err := Load(path)
+			require.NoError(t, err)
+			assert.Equal(t, "1.10.5", state.TerraformVersion)
+
+			addresses := make([]string, len(state.Resources))
+			for i, r := range state.Resources {
+				addresses[i] = r.Address
+			}
+			assert.Equal(t, []string{
+				"data.aws_caller_identity.terraform",
+				"module.eks.aws_eks_cluster.this[0]",
+				`module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]`,
+				`module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0]`,
+			}, addresses, "deposed objects are left out")
+
+			groups := state.Managed("aws_eks_node_group")
+			require.Len(t, groups, 2)
+			assert.Equal(t, `module.eks.module.eks_managed_node_group["cas"]`, groups[0].Module)
+			assert.Equal(t, "1.31", groups[0].Values.String("version"))
+			assert.True(t, groups[0].Tainted)
+			assert.False(t, groups[1].Tainted)
+			assert.Equal(t, float64(0), groups[1].Index)
+
+			assert.Equal(t, map[string]Output{
+				"cluster_name": {Value: "viya-eks"},
+				"kube_config":  {Value: "apiVersion: v1", Sensitive: true},
+			}, state.Outputs)
+		})
+	}
+}
+
+func TestParseRawDependencies(t *testing.T) {
+	t.Parallel()
+
+	state, err := Load("testdata/terraform.tfstate")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"module.eks.aws_iam_role.this"}, state.Managed("aws_eks_cluster")[0].DependsOn)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`{"version": 3, "resources": []}`))
+	assert.EqualError(t, err, "unsupported state version 3, expected 4")
+	_, err = Parse([]byte(`[`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package summary
+
+import "strings"
+
+// Component is a logical part of the infrastructure
+type Component string
+
+const (
+	Network    Component = "Network"
+	Cluster    Component = "Cluster"
+	NodePools  Component = "Node pools"
+	Storage    Component = "Storage"
+	Databases  Component = "Databases"
+	VMs        Component = "VMs"
+	Kubernetes Component = "Kubernetes"
+	Other      Component = "Other"
+)
+
+// Components lists the components in the order they are rendered
+var Components = []Component{Network, Cluster, NodePools, Storage, Databases, VMs, Kubernetes, Other}
+
+// componentRules assign resources to components, the first matching rule wins
+var componentRules = []struct {
+	component Component
+	// modules are module call prefixes of the resource address
+	modules []string
+	// types are resource types, or prefixes ending in *
+	types []string
+}{
+	{Databases, []string{"module.postgresql"}, []string{"aws_db_*"}},
+	{VMs, []string{"module.jump", "module.nfs"}, []string{"aws_instance", "aws_key_pair", "aws_volume_attachment"}},
+	{NodePools, []string{"module.eks.module.eks_managed_node_group"}, []string{"aws_autoscaling_group_tag", "aws_eks_node_group"}},
+	{Network, []string{"module.vpc"}, []string{"aws_vpc*", "aws_subnet", "aws_security_group*", "aws_route*", "aws_eip", "aws_nat_gateway", "aws_internet_gateway"}},
+	{Storage, []string{"module.ebs", "module.ontap"}, []string{"aws_efs_*", "aws_fsx_*", "aws_ebs_volume", "kubernetes_storage_class_v1"}},
+	{Kubernetes, []string{"module.kubeconfig"}, []string{"kubernetes_*", "local_file", "terraform_data"}},
+	{Cluster, []string{"module.eks", "module.autoscaling"}, []string{"aws_eks_*", "aws_iam_*", "aws_cloudwatch_log_group", "aws_kms_*"}},
+}
+
+// ComponentOf returns the component of a resource
+func ComponentOf(address, resourceType string) Component {
+	for _, rule := range componentRules {
+		for _, m := range rule.modules {
+			if strings.HasPrefix(address, m+".") || strings.HasPrefix(address, m+"[") {
+				return rule.component
+			}
+		}
+		for _, t := range rule.types {
+			if prefix, ok := strings.CutSuffix(t, "*"); (ok && strings.HasPrefix(resourceType, prefix)) || t == resourceType {
+				return rule.component
+			}
+		}
+	}
+	return Other
+}
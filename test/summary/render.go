@@ -0,0 +1,190 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package summary
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"test/report"
+)
+
+// Format is an output format of the summary
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+	JSON     Format = "json"
+)
+
+// Formats lists the supported formats, for flag help
+var Formats = []Format{Markdown, HTML, JSON}
+
+// ParseFormat returns the Format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported format %q, expected one of %v", name, Formats)
+}
+
+// Write renders the summary in the given format
+func (s *Summary) Write(w io.Writer, format Format) error {
+	switch format {
+	case HTML:
+		return s.WriteHTML(w)
+	case JSON:
+		return report.WriteJSON(w, s)
+	}
+	return s.WriteMarkdown(w)
+}
+
+// Details joins the attributes of a change
+func (c Change) Details() string {
+	attrs := make([]string, len(c.Attributes))
+	for i, a := range c.Attributes {
+		attrs[i] = a.String()
+	}
+	return strings.Join(attrs, "; ")
+}
+
+// CountsTable renders the counts of every component with the totals as footer
+func (s *Summary) CountsTable() *report.Table {
+	t := &report.Table{
+		Columns: []report.Column{
+			{Header: "Component"},
+			{Header: "Add", Right: true},
+			{Header: "Change", Right: true},
+			{Header: "Replace", Right: true},
+			{Header: "Destroy", Right: true},
+			{Header: "Unchanged", Right: true},
+		},
+		Footer: countCells("Total", s.Totals),
+	}
+	for _, g := range s.Groups {
+		t.AddRow(countCells(string(g.Component), g.Counts)...)
+	}
+	return t
+}
+
+func countCells(name string, c Counts) []string {
+	return []string{
+		name,
+		strconv.Itoa(c.Create),
+		strconv.Itoa(c.Update),
+		strconv.Itoa(c.Replace),
+		strconv.Itoa(c.Delete),
+		strconv.Itoa(c.Unchanged),
+	}
+}
+
+// RiskyTable renders the changes that delete state
+func (s *Summary) RiskyTable() *report.Table {
+	t := &report.Table{
+		Title: "Risky changes",
+		Columns: []report.Column{
+			{Header: "Address"},
+			{Header: "Action"},
+			{Header: "Class"},
+			{Header: "Forced by"},
+			{Header: "Note"},
+		},
+	}
+	for _, c := range s.Risky {
+		t.AddRow(c.Address, string(c.Action), string(c.Risk), c.Details(), c.Note)
+	}
+	return t
+}
+
+// ChangesTable renders the changes of a component, marking risky ones
+func (g Group) ChangesTable() *report.Table {
+	t := &report.Table{
+		Columns: []report.Column{{Header: "Action"}, {Header: "Address"}, {Header: "Details"}},
+	}
+	for _, c := range g.Changes {
+		a := string(c.Action)
+		if c.Risky() {
+			a += " (" + string(c.Risk) + ")"
+		}
+		t.AddRow(a, c.Address, c.Details())
+	}
+	return t
+}
+
+// WriteMarkdown writes the summary as GitHub flavored Markdown, with every
+// component folded into a details element
+func (s *Summary) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Terraform plan summary\n\n**%s**, %d unchanged\n\n", s.Totals.Headline(), s.Totals.Unchanged)
+	if err := s.CountsTable().WriteMarkdown(&b); err != nil {
+		return err
+	}
+	if len(s.Risky) > 0 {
+		b.WriteString("\n")
+		if err := s.RiskyTable().WriteMarkdown(&b); err != nil {
+			return err
+		}
+	}
+	for _, g := range s.Groups {
+		if len(g.Changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n<details>\n<summary>%s: %s</summary>\n\n", g.Component, g.Counts.Headline())
+		if err := g.ChangesTable().WriteMarkdown(&b); err != nil {
+			return err
+		}
+		b.WriteString("\n</details>\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("summary").Parse(`<h3>Terraform plan summary</h3>
+<p><strong>{{.Totals.Headline}}</strong>, {{.Totals.Unchanged}} unchanged</p>
+<table>
+<thead><tr><th>Component</th><th>Add</th><th>Change</th><th>Replace</th><th>Destroy</th><th>Unchanged</th></tr></thead>
+<tbody>
+{{- range .Groups}}
+<tr><td>{{.Component}}</td><td>{{.Counts.Create}}</td><td>{{.Counts.Update}}</td><td>{{.Counts.Replace}}</td><td>{{.Counts.Delete}}</td><td>{{.Counts.Unchanged}}</td></tr>
+{{- end}}
+<tr><th>Total</th><th>{{.Totals.Create}}</th><th>{{.Totals.Update}}</th><th>{{.Totals.Replace}}</th><th>{{.Totals.Delete}}</th><th>{{.Totals.Unchanged}}</th></tr>
+</tbody>
+</table>
+{{- if .Risky}}
+<h4>Risky changes</h4>
+<table>
+<thead><tr><th>Address</th><th>Action</th><th>Class</th><th>Forced by</th><th>Note</th></tr></thead>
+<tbody>
+{{- range .Risky}}
+<tr class="{{.Risk}}"><td><code>{{.Address}}</code></td><td>{{.Action}}</td><td><strong>{{.Risk}}</strong></td><td>{{.Details}}</td><td>{{.Note}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- range .Groups}}{{if .Changes}}
+<details>
+<summary>{{.Component}}: {{.Counts.Headline}}</summary>
+<table>
+<thead><tr><th>Action</th><th>Address</th><th>Details</th></tr></thead>
+<tbody>
+{{- range .Changes}}
+<tr{{if .Risky}} class="{{.Risk}}"{{end}}><td>{{.Action}}{{if .Risky}} ({{.Risk}}){{end}}</td><td><code>{{.Address}}</code></td><td>{{.Details}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</details>
+{{- end}}{{end}}
+`))
+
+// WriteHTML writes the summary as an HTML fragment, with every component
+// folded into a details element
+func (s *Summary) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, s)
+}
@@ -0,0 +1,210 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package summary condenses a terraform plan into a Markdown or HTML summary
+// for pull request comments.
+//
+// Resource changes are grouped by component, counted by action and listed in
+// folded sections with the attributes that change. Deletes and replacements
+// that guard classifies as stateful are repeated at the top. Sensitive values
+// are masked.
+package summary
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"test/guard"
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Action of a resource change
+type Action string
+
+const (
+	Create    Action = "create"
+	Update    Action = "update"
+	Replace   Action = "replace"
+	Delete    Action = "delete"
+	Unchanged Action = "no-op"
+)
+
+func action(actions tfjson.Actions) Action {
+	switch {
+	case actions.Replace():
+		return Replace
+	case actions.Create():
+		return Create
+	case actions.Update():
+		return Update
+	case actions.Delete():
+		return Delete
+	}
+	return Unchanged
+}
+
+// Counts counts the resource changes by action
+type Counts struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Replace   int `json:"replace"`
+	Delete    int `json:"delete"`
+	Unchanged int `json:"unchanged"`
+}
+
+func (c *Counts) add(a Action) {
+	switch a {
+	case Create:
+		c.Create++
+	case Update:
+		c.Update++
+	case Replace:
+		c.Replace++
+	case Delete:
+		c.Delete++
+	default:
+		c.Unchanged++
+	}
+}
+
+// Changed returns the number of resources that change
+func (c Counts) Changed() int {
+	return c.Create + c.Update + c.Replace + c.Delete
+}
+
+// Change is a resource that changes
+type Change struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Action  Action `json:"action"`
+	// Risk is the guard class of a stateful delete or replace
+	Risk guard.Class `json:"risk,omitempty"`
+	Note string      `json:"note,omitempty"`
+	// Attributes are the attributes an update changes, or that force a
+	// replacement
+	Attributes []guard.Attribute `json:"attributes,omitempty"`
+}
+
+// Risky reports whether the change deletes state
+func (c Change) Risky() bool {
+	return c.Risk != "" && c.Risk != guard.Stateless
+}
+
+// Group is the changes of one component
+type Group struct {
+	Component Component `json:"component"`
+	Counts    Counts    `json:"counts"`
+	Changes   []Change  `json:"changes"`
+}
+
+// Summary is the condensed plan
+type Summary struct {
+	TerraformVersion string  `json:"terraformVersion"`
+	Totals           Counts  `json:"totals"`
+	Groups           []Group `json:"groups"`
+	// Risky repeats the changes that delete state, data-destroying first
+	Risky []Change `json:"risky"`
+}
+
+// Summarize groups the managed resource changes of a plan by component
+func Summarize(plan *terraform.PlanStruct) *Summary {
+	risks := map[string]guard.Change{}
+	for _, c := range guard.Check(plan).Changes {
+		risks[c.Address] = c
+	}
+
+	s := &Summary{TerraformVersion: plan.RawPlan.TerraformVersion, Groups: []Group{}, Risky: []Change{}}
+	groups := map[Component]*Group{}
+	for _, rc := range plan.RawPlan.ResourceChanges {
+		if rc.Change == nil || rc.Mode == tfjson.DataResourceMode {
+			continue
+		}
+		component := ComponentOf(rc.Address, rc.Type)
+		g, ok := groups[component]
+		if !ok {
+			g = &Group{Component: component, Changes: []Change{}}
+			groups[component] = g
+		}
+		c := Change{Address: rc.Address, Type: rc.Type, Action: action(rc.Change.Actions)}
+		g.Counts.add(c.Action)
+		s.Totals.add(c.Action)
+		switch c.Action {
+		case Unchanged:
+			continue
+		case Update:
+			c.Attributes = updated(rc.Change)
+		case Replace, Delete:
+			if r, ok := risks[rc.Address]; ok {
+				c.Risk, c.Note, c.Attributes = r.Class, r.Note, r.Attributes
+			}
+		}
+		g.Changes = append(g.Changes, c)
+		if c.Risky() {
+			s.Risky = append(s.Risky, c)
+		}
+	}
+
+	for _, component := range Components {
+		if g, ok := groups[component]; ok {
+			sort.Slice(g.Changes, func(i, j int) bool {
+				return g.Changes[i].Address < g.Changes[j].Address
+			})
+			s.Groups = append(s.Groups, *g)
+		}
+	}
+	sort.SliceStable(s.Risky, func(i, j int) bool {
+		a, b := s.Risky[i], s.Risky[j]
+		if (a.Risk == guard.DataDestroying) != (b.Risk == guard.DataDestroying) {
+			return a.Risk == guard.DataDestroying
+		}
+		return a.Address < b.Address
+	})
+	return s
+}
+
+// updated returns the top level attributes an update changes
+func updated(change *tfjson.Change) []guard.Attribute {
+	before, _ := change.Before.(map[string]interface{})
+	after, _ := change.After.(map[string]interface{})
+	unknown, _ := change.AfterUnknown.(map[string]interface{})
+	keys := map[string]bool{}
+	for _, m := range []map[string]interface{}{before, after, unknown} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+
+	var attrs []guard.Attribute
+	for k := range keys {
+		if isUnknown, _ := unknown[k].(bool); !isUnknown && reflect.DeepEqual(before[k], after[k]) {
+			continue
+		}
+		// tags_all repeats tags with the provider default tags merged in
+		if k == "tags_all" {
+			continue
+		}
+		path := []interface{}{k}
+		b, a := planfile.ChangeValues(change, path)
+		attrs = append(attrs, guard.Attribute{Path: planfile.FormatPath(path), Before: b, After: a})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Path < attrs[j].Path
+	})
+	return attrs
+}
+
+// Headline returns the counts as "2 to add, 1 to change, 0 to replace, 1 to destroy"
+func (c Counts) Headline() string {
+	parts := []string{
+		strconv.Itoa(c.Create) + " to add",
+		strconv.Itoa(c.Update) + " to change",
+		strconv.Itoa(c.Replace) + " to replace",
+		strconv.Itoa(c.Delete) + " to destroy",
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,147 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package summary
+
+import (
+	"strings"
+	"testing"
+
+	"test/guard"
+	"test/planfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSummary(t *testing.T) *Summary {
+	plan, err := planfile.Load("testdata/plan.json")
+	require.NoError(t, err)
+	return Summarize(plan)
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	s := testSummary(t)
+	assert.Equal(t, Counts{Create: 1, Update: 4, Replace: 5, Delete: 2, Unchanged: 2}, s.Totals)
+
+	counts := map[Component]Counts{}
+	for _, g := range s.Groups {
+		counts[g.Component] = g.Counts
+	}
+	assert.Equal(t, map[Component]Counts{
+		Network:    {Create: 1, Update: 1, Replace: 1, Unchanged: 1},
+		Cluster:    {Update: 1},
+		NodePools:  {Update: 1, Delete: 1},
+		Storage:    {Delete: 1},
+		Databases:  {Replace: 2},
+		VMs:        {Replace: 2},
+		Kubernetes: {Update: 1, Unchanged: 1},
+	}, counts)
+
+	var risky []string
+	for _, c := range s.Risky {
+		risky = append(risky, string(c.Risk)+" "+c.Address)
+	}
+	assert.Equal(t, []string{
+		"data-destroying aws_efs_file_system.efs-fs[0]",
+		"data-destroying module.nfs[0].aws_ebs_volume.raid_disk[0]",
+		`data-destroying module.postgresql["default"].module.db_instance.aws_db_instance.this[0]`,
+		`data-destroying module.postgresql["other"].module.db_instance.aws_db_instance.this[0]`,
+		"stateful-recoverable module.jump[0].aws_instance.vm",
+	}, risky)
+	for _, c := range s.Risky {
+		if c.Address == `module.postgresql["default"].module.db_instance.aws_db_instance.this[0]` {
+			assert.Contains(t, c.Note, "a final snapshot is taken, restoring it is manual", "the final snapshot is only noted")
+		}
+	}
+}
+
+func TestSummarizeUpdates(t *testing.T) {
+	t.Parallel()
+
+	changes := map[string]Change{}
+	for _, g := range testSummary(t).Groups {
+		for _, c := range g.Changes {
+			changes[c.Address] = c
+		}
+	}
+	assert.NotContains(t, changes, "aws_security_group.sg[0]", "unchanged resources are only counted")
+	assert.Equal(t, []guard.Attribute{
+		{Path: "tags", Before: planfile.Changed, After: planfile.Changed},
+		{Path: "version", Before: `"1.31"`, After: `"1.32"`},
+	}, changes[`module.eks.module.eks_managed_node_group["default"].aws_eks_node_group.this[0]`].Attributes, "tags_all and unchanged attributes are left out")
+	assert.Equal(t, []guard.Attribute{
+		{Path: "data", Before: planfile.Sensitive, After: planfile.Sensitive},
+	}, changes["module.kubeconfig.kubernetes_secret.sa_cluster_admin_token[0]"].Attributes)
+}
+
+func TestWriteMasksSensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	s := testSummary(t)
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var b strings.Builder
+			require.NoError(t, s.Write(&b, format))
+			out := b.String()
+			assert.Contains(t, out, "sa_cluster_admin_token")
+			for _, secret := range []string{"old-token", "new-token", `"secret"`, `\"secret\"`, "&#34;secret&#34;"} {
+				assert.NotContains(t, out, secret)
+			}
+		})
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, testSummary(t).WriteMarkdown(&b))
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "### Terraform plan summary\n\n**1 to add, 4 to change, 5 to replace, 2 to destroy**, 2 unchanged\n"))
+	assert.Contains(t, out, "| **Total** | **1** | **4** | **5** | **2** | **2** |")
+	assert.Contains(t, out, "<summary>Storage: 0 to add, 0 to change, 0 to replace, 1 to destroy</summary>")
+	assert.Contains(t, out, "| delete (data-destroying) | aws_efs_file_system.efs-fs[0] |  |")
+	assert.NotContains(t, out, "<summary>Other", "components without resources are left out")
+}
+
+func TestWriteHTML(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, testSummary(t).WriteHTML(&b))
+	out := b.String()
+	assert.Contains(t, out, `<tr class="data-destroying"><td><code>module.postgresql[&#34;other&#34;].module.db_instance.aws_db_instance.this[0]</code></td>`)
+	assert.Equal(t, 7, strings.Count(out, "<details>"))
+}
+
+func TestComponentOf(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		address, resourceType string
+		expected              Component
+	}{
+		"vpcModule":           {"module.vpc.aws_subnet.private[0]", "aws_subnet", Network},
+		"securityGroup":       {"aws_security_group.sg[0]", "aws_security_group", Network},
+		"cluster":             {"module.eks.aws_eks_cluster.this[0]", "aws_eks_cluster", Cluster},
+		"clusterRole":         {"module.eks.aws_iam_role.this[0]", "aws_iam_role", Cluster},
+		"nodeGroup":           {`module.eks.module.eks_managed_node_group["cas"].aws_iam_role.this[0]`, "aws_iam_role", NodePools},
+		"asgTag":              {`aws_autoscaling_group_tag.node_group_tags["default-project_name"]`, "aws_autoscaling_group_tag", NodePools},
+		"ebsCSI":              {"module.ebs.module.iam_assumable_role_with_oidc.aws_iam_role.this[0]", "aws_iam_role", Storage},
+		"fsx":                 {"aws_fsx_ontap_file_system.ontap-fs[0]", "aws_fsx_ontap_file_system", Storage},
+		"postgres":            {`module.postgresql["default"].module.db_subnet_group.aws_db_subnet_group.this[0]`, "aws_db_subnet_group", Databases},
+		"nfsDisk":             {"module.nfs[0].aws_ebs_volume.raid_disk[0]", "aws_ebs_volume", VMs},
+		"kubeconfig":          {"module.kubeconfig.local_file.kubeconfig", "local_file", Kubernetes},
+		"configMap":           {"kubernetes_config_map.sas_iac_buildinfo", "kubernetes_config_map", Kubernetes},
+		"vpcModulePrefixOnly": {"module.vpcx.aws_iam_role.this", "aws_iam_role", Cluster},
+		"resourceGroup":       {"aws_resourcegroups_group.aws_rg", "aws_resourcegroups_group", Other},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ComponentOf(tc.address, tc.resourceType))
+		})
+	}
+}
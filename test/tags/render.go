@@ -0,0 +1,28 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tags
+
+import (
+	"fmt"
+	"strings"
+
+	"test/report"
+)
+
+// Table renders the findings
+func (r *Result) Table() *report.Table {
+	t := &report.Table{
+		Title: fmt.Sprintf("Tag compliance (%d checked, required: %s)", r.Checked, strings.Join(r.Required, ", ")),
+		Columns: []report.Column{
+			{Header: "Address"},
+			{Header: "Target"},
+			{Header: "Kind"},
+			{Header: "Message"},
+		},
+	}
+	for _, f := range r.Findings {
+		t.AddRow(f.Address, f.Target, string(f.Kind), f.Message)
+	}
+	return t
+}
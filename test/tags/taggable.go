@@ -0,0 +1,43 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tags
+
+// Taggable lists the aws resource types this configuration and the modules it
+// calls create that accept tags, and so receive the provider default_tags.
+// Types such as aws_route or aws_security_group_rule have no tags argument.
+var Taggable = map[string]bool{
+	"aws_cloudwatch_log_group":              true,
+	"aws_db_instance":                       true,
+	"aws_db_option_group":                   true,
+	"aws_db_parameter_group":                true,
+	"aws_db_subnet_group":                   true,
+	"aws_ebs_volume":                        true,
+	"aws_efs_file_system":                   true,
+	"aws_eip":                               true,
+	"aws_eks_access_entry":                  true,
+	"aws_eks_addon":                         true,
+	"aws_eks_cluster":                       true,
+	"aws_eks_node_group":                    true,
+	"aws_fsx_ontap_file_system":             true,
+	"aws_fsx_ontap_storage_virtual_machine": true,
+	"aws_fsx_ontap_volume":                  true,
+	"aws_iam_instance_profile":              true,
+	"aws_iam_openid_connect_provider":       true,
+	"aws_iam_policy":                        true,
+	"aws_iam_role":                          true,
+	"aws_instance":                          true,
+	"aws_internet_gateway":                  true,
+	"aws_key_pair":                          true,
+	"aws_kms_key":                           true,
+	"aws_launch_template":                   true,
+	"aws_nat_gateway":                       true,
+	"aws_resourcegroups_group":              true,
+	"aws_route_table":                       true,
+	"aws_security_group":                    true,
+	"aws_subnet":                            true,
+	"aws_vpc":                               true,
+	"aws_vpc_endpoint":                      true,
+	"aws_vpc_security_group_egress_rule":    true,
+	"aws_vpc_security_group_ingress_rule":   true,
+}
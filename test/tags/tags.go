@@ -0,0 +1,283 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tags checks that the resources of a plan carry the required tag
+// keys, wherever local.tags is applied:
+//
+//   - taggable resources, through the provider default_tags and their own tags
+//   - launch template tag_specifications, which default_tags do not reach
+//   - the aws_autoscaling_group_tag resources of each node group's ASG, which
+//     also carry the cluster-autoscaler tags when autoscaling_enabled is set
+//   - the tagSpecification_N parameters of the tagged default StorageClass
+//
+// A tag conflicts when a resource sets a key of the default tags to another
+// value.
+package tags
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// ProjectName is the tag key locals.tf always applies
+const ProjectName = "project_name"
+
+// Kind of a finding
+type Kind string
+
+const (
+	Missing    Kind = "missing"
+	Conflict   Kind = "conflict"
+	Autoscaler Kind = "autoscaler"
+)
+
+// Finding is a missing, conflicting or absent autoscaler tag
+type Finding struct {
+	Address string `json:"address"`
+	// Target names the tagged object when it is not the resource itself,
+	// for example tag_specifications[volume]
+	Target  string `json:"target,omitempty"`
+	Kind    Kind   `json:"kind"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Target != "" {
+		return fmt.Sprintf("%s %s: %s", f.Address, f.Target, f.Message)
+	}
+	return fmt.Sprintf("%s: %s", f.Address, f.Message)
+}
+
+// Result is the outcome of a tag check
+type Result struct {
+	Required    []string          `json:"required"`
+	DefaultTags map[string]string `json:"defaultTags"`
+	// Checked counts the resources and tagged objects that were checked
+	Checked  int       `json:"checked"`
+	Findings []Finding `json:"findings"`
+}
+
+// Passed reports whether there are no findings
+func (r *Result) Passed() bool {
+	return len(r.Findings) == 0
+}
+
+// DefaultTags returns local.tags, the provider default_tags, from the tags
+// variable of the plan the way locals.tf computes it
+func DefaultTags(plan *terraform.PlanStruct) map[string]string {
+	tags := map[string]string{ProjectName: "viya"}
+	if v, ok := plan.RawPlan.Variables["tags"]; ok && v != nil {
+		values, _ := v.Value.(map[string]interface{})
+		for k, value := range values {
+			tags[k] = fmt.Sprint(value)
+		}
+	}
+	return tags
+}
+
+// AutoscalerTags returns the tags the cluster-autoscaler discovers node group
+// ASGs by
+func AutoscalerTags(clusterName string) map[string]string {
+	return map[string]string{
+		"k8s.io/cluster-autoscaler/" + clusterName: "owned",
+		"k8s.io/cluster-autoscaler/enabled":        "true",
+	}
+}
+
+var nodeGroupAddress = regexp.MustCompile(`eks_managed_node_group\["([^"]+)"\]`)
+
+type checker struct {
+	required []string
+	defaults map[string]string
+	result   *Result
+}
+
+// Check verifies the required tag keys on the plan. ProjectName is always
+// required.
+func Check(plan *terraform.PlanStruct, required ...string) *Result {
+	keys := []string{ProjectName}
+	for _, k := range required {
+		if k != ProjectName {
+			keys = append(keys, k)
+		}
+	}
+	c := &checker{
+		required: keys,
+		defaults: DefaultTags(plan),
+		result:   &Result{Required: keys, Findings: []Finding{}},
+	}
+	c.result.DefaultTags = c.defaults
+
+	var clusterName string
+	nodeGroups := map[string]string{}
+	asgTags := map[string]map[string]string{}
+	for _, r := range planfile.Resources(plan) {
+		switch {
+		case Taggable[r.Type]:
+			c.resource(r)
+		case r.Type == "aws_autoscaling_group_tag":
+			nodeGroup, key, value := asgTag(r)
+			if asgTags[nodeGroup] == nil {
+				asgTags[nodeGroup] = map[string]string{}
+			}
+			asgTags[nodeGroup][key] = value
+		case r.Type == "kubernetes_storage_class_v1":
+			if tags, ok := storageClassTags(r.Values); ok {
+				c.check(r.Address, "parameters", tags, tags)
+			}
+		}
+		switch r.Type {
+		case "aws_eks_cluster":
+			clusterName = r.Values.String("name")
+		case "aws_eks_node_group":
+			if m := nodeGroupAddress.FindStringSubmatch(r.Address); m != nil {
+				nodeGroups[m[1]] = r.Address
+			}
+		}
+	}
+
+	autoscaling, _ := variable(plan, "autoscaling_enabled").(bool)
+	names := make([]string, 0, len(nodeGroups))
+	for name := range nodeGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		address := nodeGroups[name]
+		tags := asgTags[name]
+		c.check(address, "ASG", tags, tags)
+		if !autoscaling || clusterName == "" {
+			continue
+		}
+		for key, value := range AutoscalerTags(clusterName) {
+			if tags[key] != value {
+				c.add(address, "ASG", Autoscaler, key, fmt.Sprintf("autoscaling_enabled is set but tag %s is not %q", key, value))
+			}
+		}
+	}
+
+	sort.SliceStable(c.result.Findings, func(i, j int) bool {
+		a, b := c.result.Findings[i], c.result.Findings[j]
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Key < b.Key
+	})
+	return c.result
+}
+
+func (c *checker) add(address, target string, kind Kind, key, message string) {
+	c.result.Findings = append(c.result.Findings, Finding{
+		Address: address,
+		Target:  target,
+		Kind:    kind,
+		Key:     key,
+		Message: message,
+	})
+}
+
+// check reports the required keys missing from the effective tags, and the
+// keys of explicit tags whose value differs from the default tags
+func (c *checker) check(address, target string, effective, explicit map[string]string) {
+	c.result.Checked++
+	for _, key := range c.required {
+		if _, ok := effective[key]; !ok {
+			c.add(address, target, Missing, key, "missing required tag "+key)
+		}
+	}
+	keys := make([]string, 0, len(explicit))
+	for key := range explicit {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if d, ok := c.defaults[key]; ok && explicit[key] != d {
+			c.add(address, target, Conflict, key, fmt.Sprintf("tag %s is %q but the default tags set %q", key, explicit[key], d))
+		}
+	}
+}
+
+func (c *checker) resource(r planfile.Resource) {
+	explicit := stringMap(r.Values["tags"])
+	effective, known := r.Values["tags_all"].(map[string]interface{})
+	if known {
+		c.check(r.Address, "", stringMap(effective), explicit)
+	} else {
+		c.check(r.Address, "", merge(c.defaults, explicit), explicit)
+	}
+
+	if r.Type != "aws_launch_template" {
+		return
+	}
+	for _, spec := range r.Values.Blocks("tag_specifications") {
+		tags := stringMap(spec["tags"])
+		c.check(r.Address, fmt.Sprintf("tag_specifications[%s]", spec.String("resource_type")), tags, tags)
+	}
+}
+
+// asgTag returns the node group, key and value of an aws_autoscaling_group_tag,
+// whose for_each key is "<node group>-<tag key>"
+func asgTag(r planfile.Resource) (nodeGroup, key, value string) {
+	if tag := r.Values.Blocks("tag"); len(tag) > 0 {
+		key, value = tag[0].String("key"), tag[0].String("value")
+	}
+	index := r.Address
+	if i := strings.Index(index, `["`); i >= 0 {
+		index = strings.TrimSuffix(index[i+2:], `"]`)
+	}
+	return strings.TrimSuffix(index, "-"+key), key, value
+}
+
+// storageClassTags parses the tagSpecification_N parameters of an EBS CSI
+// StorageClass, and reports false when it has none
+func storageClassTags(values planfile.Attributes) (map[string]string, bool) {
+	params, _ := values["parameters"].(map[string]interface{})
+	tags := map[string]string{}
+	for name, v := range params {
+		if !strings.HasPrefix(name, "tagSpecification_") {
+			continue
+		}
+		s, _ := v.(string)
+		key, value, _ := strings.Cut(s, "=")
+		tags[key] = value
+	}
+	return tags, len(tags) > 0
+}
+
+func stringMap(v interface{}) map[string]string {
+	values, _ := v.(map[string]interface{})
+	m := make(map[string]string, len(values))
+	for k, value := range values {
+		if s, ok := value.(string); ok {
+			m[k] = s
+		}
+	}
+	return m
+}
+
+func merge(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func variable(plan *terraform.PlanStruct, name string) interface{} {
+	if v, ok := plan.RawPlan.Variables[name]; ok && v != nil {
+		return v.Value
+	}
+	return nil
+}
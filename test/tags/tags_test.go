@@ -0,0 +1,90 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tags
+
+import (
+	"testing"
+
+	"test/planfile"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storageClass = "kubernetes_storage_class_v1.ebs_csi_tagged_default[0]"
+	casNodes     = `module.eks.module.eks_managed_node_group["cas"].aws_eks_node_group.this[0]`
+	casTemplate  = `module.eks.module.eks_managed_node_group["cas"].aws_launch_template.this[0]`
+	raidDisk     = "module.nfs[0].aws_ebs_volume.raid_disk[0]"
+)
+
+func testPlan(t *testing.T) *terraform.PlanStruct {
+	plan, err := planfile.Load("testdata/plan.json")
+	require.NoError(t, err)
+	return plan
+}
+
+func findings(r *Result) []string {
+	var list []string
+	for _, f := range r.Findings {
+		list = append(list, f.String())
+	}
+	return list
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	result := Check(testPlan(t), "cost_center")
+	assert.False(t, result.Passed())
+	assert.Equal(t, []string{ProjectName, "cost_center"}, result.Required)
+	assert.Equal(t, map[string]string{ProjectName: "viya", "cost_center": "1234"}, result.DefaultTags)
+	assert.Equal(t, []string{
+		storageClass + " parameters: missing required tag cost_center",
+		casNodes + ` ASG: autoscaling_enabled is set but tag k8s.io/cluster-autoscaler/viya-eks is not "owned"`,
+		casTemplate + " tag_specifications[volume]: missing required tag cost_center",
+		raidDisk + `: tag project_name is "other" but the default tags set "viya"`,
+	}, findings(result))
+}
+
+func TestCheckMissingKey(t *testing.T) {
+	t.Parallel()
+
+	result := Check(testPlan(t), "owner")
+	missing := map[string]bool{}
+	for _, f := range result.Findings {
+		if f.Kind == Missing && f.Key == "owner" && f.Target == "" {
+			missing[f.Address] = true
+		}
+	}
+	assert.True(t, missing["module.vpc.aws_vpc.vpc[0]"])
+	assert.True(t, missing["module.nfs[0].aws_instance.vm"], "tags_all unknown, falls back to tags and default tags")
+	assert.False(t, missing["module.vpc.aws_route.public_internet_gateway[0]"], "aws_route is not taggable")
+	for _, f := range result.Findings {
+		assert.NotEqual(t, "cost_center", f.Key, "cost_center is only required when asked for")
+	}
+}
+
+func TestCheckAutoscalingDisabled(t *testing.T) {
+	t.Parallel()
+
+	plan := testPlan(t)
+	plan.RawPlan.Variables["autoscaling_enabled"] = &tfjson.PlanVariable{Value: false}
+	for _, f := range Check(plan).Findings {
+		assert.NotEqual(t, Autoscaler, f.Kind, f.String())
+	}
+}
+
+func TestDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	plan := testPlan(t)
+	plan.RawPlan.Variables["tags"] = &tfjson.PlanVariable{Value: nil}
+	assert.Equal(t, map[string]string{ProjectName: "viya"}, DefaultTags(plan))
+
+	plan.RawPlan.Variables["tags"] = &tfjson.PlanVariable{Value: map[string]interface{}{ProjectName: "sas", "env": "dev"}}
+	assert.Equal(t, map[string]string{ProjectName: "sas", "env": "dev"}, DefaultTags(plan))
+}
@@ -0,0 +1,259 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Problem describes a single value that does not match its variable's type constraint
+type Problem struct {
+	Path    string
+	Message string
+	Range   hcl.Range
+}
+
+func (p Problem) String() string {
+	if p.Range.Filename == "" {
+		return fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", p.Range.Filename, p.Range.Start.Line, p.Path, p.Message)
+}
+
+// Check validates the values of each var file against the declared variables.
+// Conversions terraform would make silently from number or bool to string are
+// accepted, every other mismatch is reported with the path to the offending value.
+// Variables typed `any` are checked against the schema in anySchemas when one exists.
+func Check(variables map[string]*Variable, files ...*VarFile) []Problem {
+	var problems []Problem
+	assigned := make(map[string]bool)
+
+	for _, vf := range files {
+		for _, name := range sortedKeys(vf.Values) {
+			assigned[name] = true
+			rng := vf.Ranges[name]
+			v, ok := variables[name]
+			if !ok {
+				problems = append(problems, Problem{
+					Path:    name,
+					Message: "value for undeclared variable" + suggest(name, sortedKeys(variables)),
+					Range:   rng,
+				})
+				continue
+			}
+			want := v.Type
+			if schema, ok := anySchemas[name]; ok && want == cty.DynamicPseudoType {
+				want = schema
+			}
+			c := &checker{rng: rng}
+			c.check(name, want, vf.Values[name])
+			problems = append(problems, c.problems...)
+		}
+	}
+
+	for _, name := range sortedKeys(variables) {
+		if variables[name].Required && !assigned[name] {
+			problems = append(problems, Problem{
+				Path:    name,
+				Message: "no value given for required variable",
+				Range:   variables[name].Range,
+			})
+		}
+	}
+	return problems
+}
+
+type checker struct {
+	rng      hcl.Range
+	problems []Problem
+}
+
+func (c *checker) report(path string, format string, args ...interface{}) {
+	c.problems = append(c.problems, Problem{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+		Range:   c.rng,
+	})
+}
+
+func (c *checker) mismatch(path string, want cty.Type, got cty.Value) {
+	c.report(path, "expected %s, got %s", typeName(want), valueTypeName(got))
+}
+
+func (c *checker) check(path string, want cty.Type, got cty.Value) {
+	if !got.IsKnown() || got.IsNull() || want == cty.DynamicPseudoType {
+		return
+	}
+	ty := got.Type()
+
+	switch {
+	case want.IsPrimitiveType():
+		if ty == want {
+			return
+		}
+		// terraform converts numbers and bools to strings without loss
+		if want == cty.String && (ty == cty.Number || ty == cty.Bool) {
+			return
+		}
+		c.mismatch(path, want, got)
+
+	case want.IsListType() || want.IsSetType():
+		if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+			c.mismatch(path, want, got)
+			return
+		}
+		i := 0
+		for it := got.ElementIterator(); it.Next(); i++ {
+			_, ev := it.Element()
+			c.check(fmt.Sprintf("%s[%d]", path, i), want.ElementType(), ev)
+		}
+
+	case want.IsMapType():
+		if !(ty.IsMapType() || ty.IsObjectType()) {
+			c.mismatch(path, want, got)
+			return
+		}
+		values := got.AsValueMap()
+		for _, key := range sortedKeys(values) {
+			c.check(attrPath(path, key), want.ElementType(), values[key])
+		}
+
+	case want.IsObjectType():
+		if !(ty.IsMapType() || ty.IsObjectType()) {
+			c.mismatch(path, want, got)
+			return
+		}
+		values := got.AsValueMap()
+		attrTypes := want.AttributeTypes()
+		for _, name := range sortedKeys(attrTypes) {
+			val, ok := values[name]
+			if !ok {
+				if !want.AttributeOptional(name) {
+					c.report(path, "missing required attribute %q", name)
+				}
+				continue
+			}
+			c.check(attrPath(path, name), attrTypes[name], val)
+		}
+		for _, name := range sortedKeys(values) {
+			if _, ok := attrTypes[name]; !ok {
+				c.report(attrPath(path, name), "unexpected attribute%s", suggest(name, sortedKeys(attrTypes)))
+			}
+		}
+
+	case want.IsTupleType():
+		if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+			c.mismatch(path, want, got)
+			return
+		}
+		elems := want.TupleElementTypes()
+		if got.LengthInt() != len(elems) {
+			c.report(path, "expected %d elements, got %d", len(elems), got.LengthInt())
+			return
+		}
+		i := 0
+		for it := got.ElementIterator(); it.Next(); i++ {
+			_, ev := it.Element()
+			c.check(fmt.Sprintf("%s[%d]", path, i), elems[i], ev)
+		}
+	}
+}
+
+var identifierRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// attrPath appends a key to a path, quoting keys that are not plain identifiers
+// such as node label names.
+func attrPath(path, key string) string {
+	if identifierRe.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+// typeName renders a type constraint the way it is written in variables.tf,
+// abbreviating object types.
+func typeName(ty cty.Type) string {
+	switch {
+	case ty == cty.DynamicPseudoType:
+		return "any"
+	case ty.IsPrimitiveType():
+		return ty.FriendlyName()
+	case ty.IsListType():
+		return "list(" + typeName(ty.ElementType()) + ")"
+	case ty.IsSetType():
+		return "set(" + typeName(ty.ElementType()) + ")"
+	case ty.IsMapType():
+		return "map(" + typeName(ty.ElementType()) + ")"
+	case ty.IsObjectType():
+		return "object"
+	case ty.IsTupleType():
+		return "tuple"
+	}
+	return ty.FriendlyName()
+}
+
+// valueTypeName describes the type of a literal value from a var file
+func valueTypeName(v cty.Value) string {
+	ty := v.Type()
+	switch {
+	case ty.IsPrimitiveType():
+		return ty.FriendlyName()
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		return "list"
+	case ty.IsMapType() || ty.IsObjectType():
+		return "object"
+	}
+	return ty.FriendlyName()
+}
+
+// suggest returns a "did you mean" hint for a close match among candidates
+func suggest(name string, candidates []string) string {
+	best := ""
+	bestDist := len(name)/3 + 1
+	for _, c := range candidates {
+		if d := levenshtein(name, c); d <= bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func levenshtein(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The sample inputs shipped in examples/ must always pass the checker
+func TestCheckExamples(t *testing.T) {
+	t.Parallel()
+
+	variables, err := LoadVariables("../..")
+	require.NoError(t, err)
+
+	examples, err := filepath.Glob("../../examples/*.tfvars")
+	require.NoError(t, err)
+	require.NotEmpty(t, examples)
+
+	for _, example := range examples {
+		t.Run(filepath.Base(example), func(t *testing.T) {
+			vf, err := ParseVarFile(example)
+			require.NoError(t, err)
+			assert.Empty(t, Check(variables, vf))
+		})
+	}
+}
+
+func TestCheckProblems(t *testing.T) {
+	t.Parallel()
+
+	variables, err := LoadVariables("../..")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "bad.tfvars")
+	require.NoError(t, os.WriteFile(path, []byte(`
+prefix          = "bad"
+create_jump_vmm = true
+default_nodepool_node_count = "two"
+node_pools = {
+  gpu = {
+    vm_type      = "g4dn.xlarge"
+    cpu_type     = "AL2023_x86_64_NVIDIA"
+    os_disk_type = "gp3"
+    os_disk_size = 200
+    os_disk_iops = "fast"
+    min_nodes    = 0
+    max_nodes    = 2
+    node_taints  = "nvidia.com/gpu=present:NoSchedule"
+    node_labels  = { "workload.sas.com/class" = ["cas"] }
+    custom_data  = ""
+    metadata_http_endpoint = "enabled"
+    metadata_http_tokens   = "required"
+  }
+}
+postgres_servers = {
+  default = {
+    backup_retention_day = 7
+    storage_size         = "big"
+    server_port          = 5432
+  }
+}
+`), 0600))
+
+	vf, err := ParseVarFile(path)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, p := range Check(variables, vf) {
+		messages = append(messages, p.Path+": "+p.Message)
+	}
+
+	assert.ElementsMatch(t, []string{
+		`create_jump_vmm: value for undeclared variable (did you mean "create_jump_vm"?)`,
+		`default_nodepool_node_count: expected number, got string`,
+		`node_pools.gpu: missing required attribute "metadata_http_put_response_hop_limit"`,
+		`node_pools.gpu.os_disk_iops: expected number, got string`,
+		`node_pools.gpu.node_taints: expected list(string), got string`,
+		`node_pools.gpu.node_labels["workload.sas.com/class"]: expected string, got list`,
+		`postgres_servers.default.backup_retention_day: unexpected attribute (did you mean "backup_retention_days"?)`,
+		`postgres_servers.default.storage_size: expected number, got string`,
+	}, messages)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Inputs holds the effective value of every variable: the default from
+// variables.tf overridden by each var file in turn, converted to the
+// variable's type the same way terraform does.
+type Inputs map[string]cty.Value
+
+// Resolve computes the effective inputs for the given var files. Values that
+// can not be converted to the variable's type are kept as written; run Check
+// to report them.
+func Resolve(variables map[string]*Variable, files ...*VarFile) Inputs {
+	in := make(Inputs, len(variables))
+	for name, v := range variables {
+		if v.Default != cty.NilVal {
+			in[name] = convertValue(v.Default, v.Type)
+		}
+	}
+	for _, vf := range files {
+		for name, val := range vf.Values {
+			if v, ok := variables[name]; ok {
+				in[name] = convertValue(val, v.Type)
+			}
+		}
+	}
+	return in
+}
+
+// LoadInputs loads the variables declared in dir and resolves them against the var files at paths
+func LoadInputs(dir string, paths ...string) (Inputs, error) {
+	variables, err := LoadVariables(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*VarFile
+	for _, path := range paths {
+		vf, err := ParseVarFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, vf)
+	}
+	return Resolve(variables, files...), nil
+}
+
+func convertValue(val cty.Value, ty cty.Type) cty.Value {
+	converted, err := convert.Convert(val, ty)
+	if err != nil {
+		return val
+	}
+	return converted
+}
+
+// Get returns the value of a variable, or a null value if it is not set
+func (in Inputs) Get(name string) cty.Value {
+	val, ok := in[name]
+	if !ok {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return val
+}
+
+// String returns a string variable, or "" if it is null
+func (in Inputs) String(name string) string {
+	var s string
+	if err := in.Decode(name, &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// Bool returns a bool variable, or false if it is null
+func (in Inputs) Bool(name string) bool {
+	var b bool
+	if err := in.Decode(name, &b); err != nil {
+		return false
+	}
+	return b
+}
+
+// Number returns a number variable, or 0 if it is null
+func (in Inputs) Number(name string) float64 {
+	var n float64
+	if err := in.Decode(name, &n); err != nil {
+		return 0
+	}
+	return n
+}
+
+// Decode unmarshals a variable into target using its JSON representation,
+// so structs can use `json` tags matching the terraform attribute names.
+func (in Inputs) Decode(name string, target interface{}) error {
+	val := in.Get(name)
+	if val.IsNull() {
+		return nil
+	}
+	if !val.IsWhollyKnown() {
+		return fmt.Errorf("%s: value is not known", name)
+	}
+	buf, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := json.Unmarshal(buf, target); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"sort"
+)
+
+// DefaultNodePoolName is the key locals.tf gives the node group built from the default_nodepool_* variables
+const DefaultNodePoolName = "default"
+
+// NodePool mirrors one entry of the node_pools variable
+type NodePool struct {
+	Name                            string            `json:"-"`
+	VMType                          string            `json:"vm_type"`
+	CPUType                         string            `json:"cpu_type"`
+	OSDiskType                      string            `json:"os_disk_type"`
+	OSDiskSize                      int               `json:"os_disk_size"`
+	OSDiskIOPS                      int               `json:"os_disk_iops"`
+	MinNodes                        int               `json:"min_nodes"`
+	MaxNodes                        int               `json:"max_nodes"`
+	NodeTaints                      []string          `json:"node_taints"`
+	NodeLabels                      map[string]string `json:"node_labels"`
+	CustomData                      string            `json:"custom_data"`
+	MetadataHTTPEndpoint            string            `json:"metadata_http_endpoint"`
+	MetadataHTTPTokens              string            `json:"metadata_http_tokens"`
+	MetadataHTTPPutResponseHopLimit int               `json:"metadata_http_put_response_hop_limit"`
+}
+
+// NodePools returns every node group locals.tf builds: the default node pool
+// first, followed by the entries of node_pools sorted by key. The default
+// pool has no cpu_type since it uses the EKS default AMI type.
+func (in Inputs) NodePools() ([]NodePool, error) {
+	def := NodePool{
+		Name:                            DefaultNodePoolName,
+		VMType:                          in.String("default_nodepool_vm_type"),
+		OSDiskType:                      in.String("default_nodepool_os_disk_type"),
+		OSDiskSize:                      int(in.Number("default_nodepool_os_disk_size")),
+		OSDiskIOPS:                      int(in.Number("default_nodepool_os_disk_iops")),
+		MinNodes:                        int(in.Number("default_nodepool_min_nodes")),
+		MaxNodes:                        int(in.Number("default_nodepool_max_nodes")),
+		CustomData:                      in.String("default_nodepool_custom_data"),
+		MetadataHTTPEndpoint:            in.String("default_nodepool_metadata_http_endpoint"),
+		MetadataHTTPTokens:              in.String("default_nodepool_metadata_http_tokens"),
+		MetadataHTTPPutResponseHopLimit: int(in.Number("default_nodepool_metadata_http_put_response_hop_limit")),
+	}
+	if err := in.Decode("default_nodepool_taints", &def.NodeTaints); err != nil {
+		return nil, err
+	}
+	if err := in.Decode("default_nodepool_labels", &def.NodeLabels); err != nil {
+		return nil, err
+	}
+
+	var pools map[string]NodePool
+	if err := in.Decode("node_pools", &pools); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(pools))
+	for k := range pools {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// merge() in locals.tf lets a node_pools entry named "default" replace the default pool
+	var result []NodePool
+	if _, ok := pools[DefaultNodePoolName]; !ok {
+		result = append(result, def)
+	}
+	for _, k := range keys {
+		np := pools[k]
+		np.Name = k
+		result = append(result, np)
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"github.com/zclconf/go-cty/cty"
+)
+
+// postgresServerType documents the keys read from each PostgreSQL server object in
+// main.tf. Every key is optional since missing keys are merged in from
+// postgres_server_defaults. See the PostgreSQL Server section of docs/CONFIG-VARS.md.
+var postgresServerType = cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+	"server_version":          cty.String,
+	"instance_type":           cty.String,
+	"storage_size":            cty.Number,
+	"backup_retention_days":   cty.Number,
+	"storage_encrypted":       cty.Bool,
+	"administrator_login":     cty.String,
+	"administrator_password":  cty.String,
+	"multi_az":                cty.Bool,
+	"deletion_protection":     cty.Bool,
+	"server_port":             cty.String,
+	"ssl_enforcement_enabled": cty.Bool,
+	"parameters":              cty.List(cty.Map(cty.String)),
+	"options":                 cty.DynamicPseudoType,
+}, []string{
+	"server_version",
+	"instance_type",
+	"storage_size",
+	"backup_retention_days",
+	"storage_encrypted",
+	"administrator_login",
+	"administrator_password",
+	"multi_az",
+	"deletion_protection",
+	"server_port",
+	"ssl_enforcement_enabled",
+	"parameters",
+	"options",
+})
+
+// anySchemas replaces the `any` type constraint of a variable with the shape
+// the configuration actually expects, so typos in keys are caught.
+var anySchemas = map[string]cty.Type{
+	"postgres_server_defaults": postgresServerType,
+	"postgres_servers":         cty.Map(postgresServerType),
+}
@@ -0,0 +1,76 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// conditionFunctions are the Terraform functions the validation conditions of
+// variables.tf call
+var conditionFunctions = map[string]function.Function{
+	"alltrue": function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "list", Type: cty.List(cty.Bool)}},
+		Type:   function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			for _, v := range args[0].AsValueSlice() {
+				if v.False() {
+					return cty.False, nil
+				}
+			}
+			return cty.True, nil
+		},
+	}),
+	"can":      tryfunc.CanFunc,
+	"contains": stdlib.ContainsFunc,
+	"floor":    stdlib.FloorFunc,
+	"keys":     stdlib.KeysFunc,
+	// length in Terraform counts the characters of a string too
+	"length": function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "value", Type: cty.DynamicPseudoType}},
+		Type:   function.StaticReturnType(cty.Number),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			if args[0].Type() == cty.String {
+				return stdlib.Strlen(args[0])
+			}
+			return stdlib.Length(args[0])
+		},
+	}),
+	"lower":  stdlib.LowerFunc,
+	"regex":  stdlib.RegexFunc,
+	"split":  stdlib.SplitFunc,
+	"values": stdlib.ValuesFunc,
+}
+
+// Valid reports whether a value passes the validation conditions of the
+// variable. The conditions may only refer to the variable itself and to the
+// locals that are constants, like the regular expressions of locals.tf.
+func (v *Variable) Valid(value cty.Value) (bool, error) {
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":   cty.ObjectVal(map[string]cty.Value{v.Name: value}),
+			"local": cty.ObjectVal(v.locals),
+		},
+		Functions: conditionFunctions,
+	}
+	for _, condition := range v.Conditions {
+		result, diags := condition.Value(ctx)
+		if diags.HasErrors() {
+			return false, diags
+		}
+		if result.Type() != cty.Bool || result.IsNull() || !result.IsKnown() {
+			return false, fmt.Errorf("%s: condition of %s is not a known bool", condition.Range(), v.Name)
+		}
+		if result.False() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tfvars
+
+import (
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// VarFile holds the values assigned in a single .tfvars or .tfvars.json file
+type VarFile struct {
+	Path   string
+	Values map[string]cty.Value
+	Ranges map[string]hcl.Range
+}
+
+// ParseVarFile reads a .tfvars or .tfvars.json file. Values are evaluated without
+// any variables or functions in scope, the same way terraform reads them.
+func ParseVarFile(path string) (*VarFile, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(path, ".json") {
+		f, diags = parser.ParseJSON(src, path)
+	} else {
+		f, diags = parser.ParseHCL(src, path)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	vf := &VarFile{
+		Path:   path,
+		Values: make(map[string]cty.Value, len(attrs)),
+		Ranges: make(map[string]hcl.Range, len(attrs)),
+	}
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		vf.Values[name] = val
+		vf.Ranges[name] = attr.NameRange
+	}
+	return vf, nil
+}
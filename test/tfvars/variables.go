@@ -0,0 +1,145 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tfvars loads the variable declarations of a Terraform module and
+// checks tfvars values against their type constraints without running terraform.
+package tfvars
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Variable is a single `variable` block declared in a module
+type Variable struct {
+	Name     string
+	Type     cty.Type
+	Default  cty.Value
+	Required bool
+	Range    hcl.Range
+	// Conditions are the conditions of the validation blocks
+	Conditions []hcl.Expression
+	// locals are the locals of the module that are constants, which the
+	// conditions may refer to
+	locals map[string]cty.Value
+}
+
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "default"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "validation"},
+	},
+}
+
+var validationSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "condition", Required: true},
+	},
+}
+
+// LoadVariables parses every *.tf file in dir and returns the declared variables keyed by name
+func LoadVariables(dir string) (map[string]*Variable, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no terraform files found in %s", dir)
+	}
+	sort.Strings(files)
+
+	parser := hclparse.NewParser()
+	variables := make(map[string]*Variable)
+	locals := make(map[string]cty.Value)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		f, diags := parser.ParseHCL(src, file)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		content, _, diags := f.Body.PartialContent(moduleSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, block := range content.Blocks {
+			if block.Type == "locals" {
+				attrs, diags := block.Body.JustAttributes()
+				if diags.HasErrors() {
+					return nil, diags
+				}
+				for name, attr := range attrs {
+					// locals that refer to anything are not constants
+					if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.IsWhollyKnown() {
+						locals[name] = val
+					}
+				}
+				continue
+			}
+			v, err := decodeVariable(block)
+			if err != nil {
+				return nil, err
+			}
+			variables[v.Name] = v
+		}
+	}
+	for _, v := range variables {
+		v.locals = locals
+	}
+	return variables, nil
+}
+
+func decodeVariable(block *hcl.Block) (*Variable, error) {
+	v := &Variable{
+		Name:     block.Labels[0],
+		Type:     cty.DynamicPseudoType,
+		Required: true,
+		Range:    block.DefRange,
+	}
+	content, _, diags := block.Body.PartialContent(variableSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if attr, ok := content.Attributes["type"]; ok {
+		ty, _, diags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		v.Type = ty
+	}
+	if attr, ok := content.Attributes["default"]; ok {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		v.Default = val
+		v.Required = false
+	}
+	for _, block := range content.Blocks {
+		validation, _, diags := block.Body.PartialContent(validationSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		v.Conditions = append(v.Conditions, validation.Attributes["condition"].Expr)
+	}
+	return v, nil
+}
@@ -0,0 +1,286 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package upgrade plans a Kubernetes version upgrade of the EKS cluster as a
+// series of one minor version steps, since EKS upgrades the control plane one
+// minor version at a time.
+//
+// Every step lists the release notes that affect the configuration, the
+// kubectl versions that can talk to the cluster before and after it, and the
+// node groups whose cpu_type has no AMIs for the new version.
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"test/ec2catalog"
+	"test/statefile"
+	"test/tfvars"
+	"test/versions"
+)
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Info    Severity = "info"
+)
+
+// Finding is a problem or a notice for the upgrade, or one of its steps
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Step is the step the finding applies to, like "1.32 -> 1.33", empty for
+	// the whole upgrade
+	Step    string `json:"step,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Step == "" {
+		return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Step, f.Message)
+}
+
+// NodeGroup is a node group in the state, the node pools of the tfvars, or
+// both
+type NodeGroup struct {
+	Name string `json:"name"`
+	// Version and AMIType are the values in the state, empty for new node
+	// groups
+	Version string `json:"version,omitempty"`
+	AMIType string `json:"amiType,omitempty"`
+	// CPUType is the requested cpu_type, empty when the node group is not in
+	// the tfvars
+	CPUType string `json:"cpuType,omitempty"`
+}
+
+// effectiveAMI is the AMI type the node group has after the next apply
+func (ng NodeGroup) effectiveAMI() string {
+	if ng.CPUType != "" {
+		return ng.CPUType
+	}
+	return ng.AMIType
+}
+
+// Input is the current and the requested state of the cluster
+type Input struct {
+	// Current is the cluster version in the state, nil for a new cluster
+	Current *Version
+	Target  Version
+	// NodeGroups are sorted by name
+	NodeGroups []NodeGroup
+	// Kubectl is the pinned kubectl, nil when unknown
+	Kubectl *versions.Pin
+}
+
+// Step is one minor version upgrade of the control plane
+type Step struct {
+	From  Version  `json:"from"`
+	To    Version  `json:"to"`
+	Notes []string `json:"notes"`
+	// Kubectl are the kubectl minor versions that work before and after the
+	// step
+	Kubectl []Version `json:"kubectl"`
+}
+
+// Name returns the step as "1.32 -> 1.33"
+func (s Step) Name() string {
+	return s.From.String() + " -> " + s.To.String()
+}
+
+// Plan is the stepwise upgrade
+type Plan struct {
+	Current  string    `json:"current,omitempty"`
+	Target   string    `json:"target"`
+	Steps    []Step    `json:"steps"`
+	Findings []Finding `json:"findings"`
+}
+
+// Passed reports whether no finding is an error
+func (p *Plan) Passed() bool {
+	for _, f := range p.Findings {
+		if f.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Plan) add(severity Severity, step, format string, args ...interface{}) {
+	p.Findings = append(p.Findings, Finding{Severity: severity, Step: step, Message: fmt.Sprintf(format, args...)})
+}
+
+// Build plans the upgrade from the current to the target version
+func Build(in Input) *Plan {
+	p := &Plan{Target: in.Target.String(), Steps: []Step{}, Findings: []Finding{}}
+	if in.Current == nil {
+		p.add(Info, "", "there is no cluster in the state, it is created at %s", in.Target)
+		checkAMIs(p, "", in.NodeGroups, in.Target)
+		return p
+	}
+	current := *in.Current
+	p.Current = current.String()
+
+	switch {
+	case in.Target.Compare(current) < 0:
+		p.add(Error, "", "EKS can not downgrade the cluster from %s to %s", current, in.Target)
+		return p
+	case in.Target.Major != current.Major:
+		p.add(Error, "", "no upgrade path from %s to %s", current, in.Target)
+		return p
+	}
+
+	checkNodeSkew(p, in.NodeGroups, current)
+	for _, ng := range in.NodeGroups {
+		if ng.AMIType != "" && ng.CPUType != "" && ng.AMIType != ng.CPUType {
+			p.add(Info, "", "node group %s changes from %s to %s with the first apply, which replaces its nodes", ng.Name, ng.AMIType, ng.CPUType)
+		}
+	}
+
+	for from := current; from.Compare(in.Target) < 0; from = from.Next() {
+		step := Step{From: from, To: from.Next(), Notes: ReleaseNotes[from.Next()], Kubectl: []Version{from, from.Next()}}
+		if step.Notes == nil {
+			step.Notes = []string{}
+		}
+		for _, g := range Gates {
+			if step.To == g.Since {
+				step.Notes = append(step.Notes, fmt.Sprintf("%s is created, it %s", g.Address, g.Description))
+			}
+		}
+		p.Steps = append(p.Steps, step)
+
+		checkAMIs(p, step.Name(), in.NodeGroups, step.To)
+		if in.Kubectl != nil {
+			checkKubectl(p, step, *in.Kubectl)
+		}
+	}
+	return p
+}
+
+// checkAMIs reports node groups whose AMI type has no AMIs for the version
+func checkAMIs(p *Plan, step string, groups []NodeGroup, v Version) {
+	for _, ng := range groups {
+		ami := ng.effectiveAMI()
+		if last, ok := AMILastVersion(ami); ok && !last.AtLeast(v) {
+			p.add(Error, step, "node group %s uses cpu_type %s, which has no AMIs after %s, change it to %s first",
+				ng.Name, ami, last, ec2catalog.DefaultAMIType)
+		}
+	}
+}
+
+// checkNodeSkew reports node groups too far behind the control plane to
+// upgrade it
+func checkNodeSkew(p *Plan, groups []NodeGroup, current Version) {
+	for _, ng := range groups {
+		if ng.Version == "" {
+			continue
+		}
+		v, err := ParseVersion(ng.Version)
+		if err != nil {
+			p.add(Warning, "", "node group %s: %v", ng.Name, err)
+			continue
+		}
+		if skew, ok := current.Skew(v); !ok || skew >= MaxNodeSkew {
+			p.add(Error, "", "node group %s runs %s, upgrade it to %s before upgrading the control plane past %s",
+				ng.Name, v, current, current)
+		}
+	}
+}
+
+// checkKubectl reports a pinned kubectl that can not talk to the cluster
+// before or after a step
+func checkKubectl(p *Plan, step Step, kubectl versions.Pin) {
+	v, err := ParseVersion(kubectl.Version)
+	if err != nil {
+		return
+	}
+	for _, cluster := range []Version{step.From, step.To} {
+		if skew, ok := v.Skew(cluster); !ok || skew > versions.MaxKubectlSkew || skew < -versions.MaxKubectlSkew {
+			p.add(Warning, step.Name(), "kubectl %s from %s does not support a %s cluster, use kubectl %s or %s",
+				kubectl.Version, kubectl.Source, cluster, step.From, step.To)
+			return
+		}
+	}
+}
+
+// NodeGroupsFromState returns the EKS node groups of a state
+func NodeGroupsFromState(state *statefile.State) []NodeGroup {
+	var groups []NodeGroup
+	for _, r := range state.Managed("aws_eks_node_group") {
+		groups = append(groups, NodeGroup{
+			Name:    r.Values.String("node_group_name"),
+			Version: r.Values.String("version"),
+			AMIType: r.Values.String("ami_type"),
+		})
+	}
+	return groups
+}
+
+// ClusterVersion returns the version of the EKS cluster in a state, nil when
+// the state has no cluster
+func ClusterVersion(state *statefile.State) (*Version, error) {
+	for _, r := range state.Managed("aws_eks_cluster") {
+		v, err := ParseVersion(r.Values.String("version"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Address, err)
+		}
+		return &v, nil
+	}
+	return nil, nil
+}
+
+// MergeNodeGroups adds the requested cpu_type of the node pools to the node
+// groups of the state. Node groups are matched by the node pool name, which
+// EKS prefixes in the generated node group names.
+func MergeNodeGroups(state []NodeGroup, pools []tfvars.NodePool) []NodeGroup {
+	byName := map[string]*NodeGroup{}
+	var names []string
+	get := func(name string) *NodeGroup {
+		if ng, ok := byName[name]; ok {
+			return ng
+		}
+		byName[name] = &NodeGroup{Name: name}
+		names = append(names, name)
+		return byName[name]
+	}
+	for _, ng := range state {
+		name := poolName(ng.Name, pools)
+		g := get(name)
+		*g = ng
+		g.Name = name
+	}
+	for _, np := range pools {
+		cpuType := np.CPUType
+		if cpuType == "" {
+			cpuType = ec2catalog.DefaultAMIType
+		}
+		get(np.Name).CPUType = cpuType
+	}
+
+	sort.Strings(names)
+	groups := make([]NodeGroup, len(names))
+	for i, name := range names {
+		groups[i] = *byName[name]
+	}
+	return groups
+}
+
+// poolName returns the node pool a node group name belongs to. The eks module
+// names node groups after the node pool, optionally with a generated suffix.
+func poolName(nodeGroup string, pools []tfvars.NodePool) string {
+	best := ""
+	for _, np := range pools {
+		if (nodeGroup == np.Name || strings.HasPrefix(nodeGroup, np.Name+"-")) && len(np.Name) > len(best) {
+			best = np.Name
+		}
+	}
+	if best == "" {
+		return nodeGroup
+	}
+	return best
+}
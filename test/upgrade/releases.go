@@ -0,0 +1,54 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import "strings"
+
+// ReleaseNotes are the changes of a Kubernetes or EKS minor version that
+// affect this configuration or the workloads on it, keyed by version
+var ReleaseNotes = map[Version][]string{
+	{1, 23}: {"the in-tree EBS volume plugin is migrated to the EBS CSI driver, which module.ebs installs"},
+	{1, 24}: {"dockershim is removed, nodes run containerd only"},
+	{1, 25}: {"PodSecurityPolicy is removed, use Pod Security Admission"},
+	{1, 26}: {"the flowcontrol.apiserver.k8s.io/v1beta1 and autoscaling/v2beta2 APIs are removed"},
+	{1, 27}: {"the kubelet --container-runtime flag is removed, custom node user data must not pass it"},
+	{1, 29}: {"the flowcontrol.apiserver.k8s.io/v1beta2 API is removed"},
+	{1, 30}: {"new EKS clusters no longer mark gp2 as the default StorageClass, terraform_data.run_command patches it"},
+	{1, 32}: {"the last version EKS publishes AL2 AMIs for"},
+	{1, 33}: {"EKS publishes no AL2 AMIs, node groups must use an AL2023 or Bottlerocket cpu_type"},
+}
+
+// amiLastVersions are the newest versions EKS publishes AMIs for, keyed by
+// cpu_type prefix
+var amiLastVersions = map[string]Version{
+	"AL2_": {1, 32},
+}
+
+// AMILastVersion returns the newest version EKS publishes AMIs of the
+// cpu_type for, false when it is still published
+func AMILastVersion(amiType string) (Version, bool) {
+	for prefix, v := range amiLastVersions {
+		if strings.HasPrefix(amiType, prefix) {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// Gate is a resource the configuration only creates from a Kubernetes
+// version on
+type Gate struct {
+	Address     string
+	Since       Version
+	Description string
+}
+
+// Gates are the version gated resources of the root module
+var Gates = []Gate{
+	{Address: "terraform_data.run_command[0]", Since: Version{1, 30}, Description: "patches gp2 as the default StorageClass"},
+}
+
+// MaxNodeSkew is the number of minor versions nodes may be older than the
+// control plane
+const MaxNodeSkew = 3
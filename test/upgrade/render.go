@@ -0,0 +1,43 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"test/report"
+)
+
+// Tables renders the steps and the findings
+func (p *Plan) Tables() []*report.Table {
+	current := p.Current
+	if current == "" {
+		current = "none"
+	}
+	steps := &report.Table{
+		Title:   fmt.Sprintf("Upgrade from %s to %s", current, p.Target),
+		Columns: []report.Column{{Header: "Step"}, {Header: "kubectl"}, {Header: "Notes"}},
+	}
+	for _, s := range p.Steps {
+		kubectl := make([]string, len(s.Kubectl))
+		for i, v := range s.Kubectl {
+			kubectl[i] = v.String()
+		}
+		steps.AddRow(s.Name(), strings.Join(kubectl, " or "), strings.Join(s.Notes, "; "))
+	}
+	tables := []*report.Table{steps}
+
+	if len(p.Findings) > 0 {
+		findings := &report.Table{
+			Title:   "Findings",
+			Columns: []report.Column{{Header: "Severity"}, {Header: "Step"}, {Header: "Message"}},
+		}
+		for _, f := range p.Findings {
+			findings.AddRow(string(f.Severity), f.Step, f.Message)
+		}
+		tables = append(tables, findings)
+	}
+	return tables
+}
@@ -0,0 +1,236 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"testing"
+
+	"test/statefile"
+	"test/tfvars"
+	"test/versions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected Version
+		err      bool
+	}{
+		"minor":      {input: "1.35", expected: Version{1, 35}},
+		"prefixed":   {input: "v1.35", expected: Version{1, 35}},
+		"patch":      {input: "1.35.2", expected: Version{1, 35}},
+		"major only": {input: "1", err: true},
+		"empty":      {input: "", err: true},
+		"text":       {input: "latest", err: true},
+		"negative":   {input: "1.-1", err: true},
+		"too long":   {input: "1.2.3.4", err: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := ParseVersion(tc.input)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MustParseVersion("1.30").AtLeast(MustParseVersion("1.4")), "versions compare by number")
+	assert.False(t, MustParseVersion("1.4").AtLeast(MustParseVersion("1.30")))
+	assert.Equal(t, 0, MustParseVersion("1.30").Compare(MustParseVersion("v1.30.1")))
+	assert.Equal(t, 1, MustParseVersion("2.0").Compare(MustParseVersion("1.35")))
+
+	skew, ok := MustParseVersion("1.33").Skew(MustParseVersion("1.35"))
+	assert.True(t, ok)
+	assert.Equal(t, -2, skew)
+	_, ok = MustParseVersion("2.0").Skew(MustParseVersion("1.35"))
+	assert.False(t, ok)
+}
+
+func TestBuildSteps(t *testing.T) {
+	t.Parallel()
+
+	current := MustParseVersion("1.32")
+	plan := Build(Input{
+		Current:    &current,
+		Target:     MustParseVersion("1.35"),
+		NodeGroups: []NodeGroup{{Name: "default", Version: "1.32", AMIType: "AL2023_x86_64_STANDARD", CPUType: "AL2023_x86_64_STANDARD"}},
+		Kubectl:    &versions.Pin{Source: "Dockerfile:5", Version: "1.33.4"},
+	})
+
+	names := make([]string, len(plan.Steps))
+	for i, s := range plan.Steps {
+		names[i] = s.Name()
+	}
+	assert.Equal(t, []string{"1.32 -> 1.33", "1.33 -> 1.34", "1.34 -> 1.35"}, names)
+	assert.Equal(t, ReleaseNotes[Version{1, 33}], plan.Steps[0].Notes)
+	assert.Empty(t, plan.Steps[1].Notes)
+	assert.Equal(t, []Version{{1, 34}, {1, 35}}, plan.Steps[2].Kubectl)
+
+	assert.True(t, plan.Passed())
+	assert.Equal(t, []Finding{{
+		Severity: Warning,
+		Step:     "1.34 -> 1.35",
+		Message:  "kubectl 1.33.4 from Dockerfile:5 does not support a 1.35 cluster, use kubectl 1.34 or 1.35",
+	}}, plan.Findings)
+}
+
+func TestBuildGateNotes(t *testing.T) {
+	t.Parallel()
+
+	current := MustParseVersion("1.29")
+	plan := Build(Input{Current: &current, Target: MustParseVersion("1.30")})
+	require.Len(t, plan.Steps, 1)
+	assert.Contains(t, plan.Steps[0].Notes, "terraform_data.run_command[0] is created, it patches gp2 as the default StorageClass")
+}
+
+func TestBuildFindings(t *testing.T) {
+	t.Parallel()
+
+	v := func(s string) *Version {
+		v := MustParseVersion(s)
+		return &v
+	}
+	tests := map[string]struct {
+		input    Input
+		expected []Finding
+	}{
+		"AL2 past 1.32": {
+			input: Input{
+				Current:    v("1.32"),
+				Target:     MustParseVersion("1.33"),
+				NodeGroups: []NodeGroup{{Name: "default", Version: "1.32", AMIType: "AL2_x86_64"}},
+			},
+			expected: []Finding{{
+				Severity: Error,
+				Step:     "1.32 -> 1.33",
+				Message:  "node group default uses cpu_type AL2_x86_64, which has no AMIs after 1.32, change it to AL2023_x86_64_STANDARD first",
+			}},
+		},
+		"AL2 replaced": {
+			input: Input{
+				Current:    v("1.32"),
+				Target:     MustParseVersion("1.33"),
+				NodeGroups: []NodeGroup{{Name: "default", Version: "1.32", AMIType: "AL2_x86_64", CPUType: "AL2023_x86_64_STANDARD"}},
+			},
+			expected: []Finding{{
+				Severity: Info,
+				Message:  "node group default changes from AL2_x86_64 to AL2023_x86_64_STANDARD with the first apply, which replaces its nodes",
+			}},
+		},
+		"downgrade": {
+			input:    Input{Current: v("1.33"), Target: MustParseVersion("1.32")},
+			expected: []Finding{{Severity: Error, Message: "EKS can not downgrade the cluster from 1.33 to 1.32"}},
+		},
+		"major": {
+			input:    Input{Current: v("1.35"), Target: MustParseVersion("2.0")},
+			expected: []Finding{{Severity: Error, Message: "no upgrade path from 1.35 to 2.0"}},
+		},
+		"node skew": {
+			input: Input{
+				Current:    v("1.33"),
+				Target:     MustParseVersion("1.34"),
+				NodeGroups: []NodeGroup{{Name: "cas", Version: "1.30"}, {Name: "stateless", Version: "1.31"}},
+			},
+			expected: []Finding{{Severity: Error, Message: "node group cas runs 1.30, upgrade it to 1.33 before upgrading the control plane past 1.33"}},
+		},
+		"new cluster": {
+			input: Input{
+				Target:     MustParseVersion("1.35"),
+				NodeGroups: []NodeGroup{{Name: "default", CPUType: "AL2_x86_64"}},
+			},
+			expected: []Finding{
+				{Severity: Info, Message: "there is no cluster in the state, it is created at 1.35"},
+				{Severity: Error, Message: "node group default uses cpu_type AL2_x86_64, which has no AMIs after 1.32, change it to AL2023_x86_64_STANDARD first"},
+			},
+		},
+		"up to date": {
+			input:    Input{Current: v("1.35"), Target: MustParseVersion("1.35")},
+			expected: []Finding{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Build(tc.input).Findings)
+		})
+	}
+}
+
+func TestMergeNodeGroups(t *testing.T) {
+	t.Parallel()
+
+	state := []NodeGroup{
+		{Name: "default-20250101", Version: "1.32", AMIType: "AL2_x86_64"},
+		{Name: "removed", Version: "1.32", AMIType: "AL2023_x86_64_STANDARD"},
+	}
+	pools := []tfvars.NodePool{{Name: "default"}, {Name: "cas", CPUType: "AL2023_ARM_64_STANDARD"}}
+
+	assert.Equal(t, []NodeGroup{
+		{Name: "cas", CPUType: "AL2023_ARM_64_STANDARD"},
+		{Name: "default", Version: "1.32", AMIType: "AL2_x86_64", CPUType: "AL2023_x86_64_STANDARD"},
+		{Name: "removed", Version: "1.32", AMIType: "AL2023_x86_64_STANDARD"},
+	}, MergeNodeGroups(state, pools))
+}
+
+func TestFromState(t *testing.T) {
+	t.Parallel()
+
+	state, err := statefile.Load("../statefile/testdata/terraform.tfstate")
+	require.NoError(t, err)
+
+	current, err := ClusterVersion(state)
+	require.NoError(t, err)
+	assert.Equal(t, &Version{1, 32}, current)
+	assert.Equal(t, []NodeGroup{
+		{Name: "cas", Version: "1.31", AMIType: "AL2023_x86_64_STANDARD"},
+		{Name: "default", Version: "1.32", AMIType: "AL2_x86_64"},
+	}, NodeGroupsFromState(state))
+
+	none, err := ClusterVersion(&statefile.State{})
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+// locals.tf converts the major and minor numbers of kubernetes_version to gate
+// features, the validation rejects the versions that conversion fails for
+func TestKubernetesVersionValidation(t *testing.T) {
+	t.Parallel()
+
+	variables, err := tfvars.LoadVariables("../..")
+	require.NoError(t, err)
+
+	tests := map[string]bool{
+		"1.35":   true,
+		"1.4":    true,
+		"1.30.2": false,
+		"1":      false,
+		"1.x":    false,
+		"v1.35":  false,
+		"1.35.":  false,
+		"":       false,
+	}
+	for version, expected := range tests {
+		t.Run(version, func(t *testing.T) {
+			valid, err := variables["kubernetes_version"].Valid(cty.StringVal(version))
+			require.NoError(t, err)
+			assert.Equal(t, expected, valid)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a Kubernetes minor version. Versions compare by number, so 1.4
+// is older than 1.30, unlike the string comparison terraform makes.
+type Version struct {
+	Major int
+	Minor int
+}
+
+// ParseVersion parses versions like 1.35, v1.35 or 1.35.2, dropping the patch
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid Kubernetes version %q, expected major.minor", s)
+	}
+	var numbers [2]int
+	for i := range numbers {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid Kubernetes version %q, expected major.minor", s)
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1]}, nil
+}
+
+// MustParseVersion is ParseVersion for versions known to be valid
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Compare returns -1, 0 or 1 when v is older, equal or newer than o
+func (v Version) Compare(o Version) int {
+	if v.Major != o.Major {
+		return sign(v.Major - o.Major)
+	}
+	return sign(v.Minor - o.Minor)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast reports whether v is o or newer
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// Next returns the following minor version
+func (v Version) Next() Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// Skew returns the number of minor versions v is newer than o, negative when
+// it is older. Versions of different major versions have no defined skew.
+func (v Version) Skew(o Version) (int, bool) {
+	if v.Major != o.Major {
+		return 0, false
+	}
+	return v.Minor - o.Minor, true
+}
@@ -0,0 +1,221 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Severity of a Finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	// Advisory is a newer release from the index
+	Advisory Severity = "advisory"
+)
+
+// Finding is an inconsistency between pins or an upgrade advisory
+type Finding struct {
+	Severity  Severity `json:"severity"`
+	Component string   `json:"component"`
+	Message   string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Component, f.Message)
+}
+
+// Result is the inventory with its findings
+type Result struct {
+	*Inventory
+	Findings []Finding `json:"findings"`
+}
+
+// Passed reports whether no finding is an error
+func (r *Result) Passed() bool {
+	for _, f := range r.Findings {
+		if f.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+// Index maps component names to their newest release, like
+// {"terraform": "1.13.4", "hashicorp/aws": "6.17.0"}
+type Index map[string]string
+
+// LoadIndex reads an index from a JSON file
+func LoadIndex(path string) (Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return index, nil
+}
+
+// Options of a check
+type Options struct {
+	// KubernetesVersion overrides the kubernetes_version default, for the
+	// value of a tfvars file
+	KubernetesVersion *Pin
+	// Index holds the newest releases for the advisories, optional
+	Index Index
+}
+
+// MaxKubectlSkew is the number of minor versions kubectl supports older or
+// newer than the cluster
+const MaxKubectlSkew = 1
+
+type checker struct {
+	result *Result
+}
+
+func (c *checker) add(severity Severity, component, format string, args ...interface{}) {
+	c.result.Findings = append(c.result.Findings, Finding{Severity: severity, Component: component, Message: fmt.Sprintf(format, args...)})
+}
+
+// Check reports the pins of the inventory that contradict each other, and
+// the newer releases in the index
+func Check(inv *Inventory, options Options) *Result {
+	c := &checker{result: &Result{Inventory: inv, Findings: []Finding{}}}
+	if options.KubernetesVersion != nil {
+		if k := inv.Component("kubernetes"); k != nil {
+			k.Pins = []Pin{*options.KubernetesVersion}
+		} else {
+			inv.add("kubernetes", Kubernetes, *options.KubernetesVersion)
+		}
+	}
+
+	for _, comp := range inv.Components {
+		c.checkVersions(comp)
+		c.checkConstraints(comp)
+		if comp.Kind == Provider && inv.LockFile && len(comp.Versions()) == 0 {
+			c.add(Warning, comp.Name, "missing from %s, run terraform init -upgrade", LockFile)
+		}
+		if latest, ok := options.Index[comp.Name]; ok {
+			comp.Latest = latest
+			c.checkLatest(comp)
+		}
+	}
+	c.checkKubectlSkew(inv)
+	return c.result
+}
+
+// checkVersions reports exact pins that disagree
+func (c *checker) checkVersions(comp *Component) {
+	pins := comp.Versions()
+	for _, p := range pins[min(1, len(pins)):] {
+		if !sameVersion(p.Version, pins[0].Version) {
+			c.add(Error, comp.Name, "%s disagrees with %s", p, pins[0])
+		}
+	}
+}
+
+func sameVersion(a, b string) bool {
+	va, errA := goversion.NewVersion(a)
+	vb, errB := goversion.NewVersion(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return va.Equal(vb)
+}
+
+// checkConstraints reports exact pins outside a constraint, and registry
+// modules called with different constraints
+func (c *checker) checkConstraints(comp *Component) {
+	constraints := comp.Constraints()
+	for _, cp := range constraints {
+		constraint, err := goversion.NewConstraint(cp.Constraint)
+		if err != nil {
+			c.add(Error, comp.Name, "invalid constraint %s: %v", cp, err)
+			continue
+		}
+		for _, vp := range comp.Versions() {
+			v, err := goversion.NewVersion(vp.Version)
+			if err != nil {
+				c.add(Error, comp.Name, "invalid version %s: %v", vp, err)
+				continue
+			}
+			if !constraint.Check(v) {
+				c.add(Error, comp.Name, "%s does not satisfy %s", vp, cp)
+			}
+		}
+	}
+	if comp.Kind == Module {
+		for _, cp := range constraints[min(1, len(constraints)):] {
+			if normalize(cp.Constraint) != normalize(constraints[0].Constraint) {
+				c.add(Warning, comp.Name, "called with %s and %s", constraints[0], cp)
+			}
+		}
+	}
+}
+
+func normalize(constraint string) string {
+	return strings.Join(strings.Fields(constraint), "")
+}
+
+// checkLatest advises on a newer release, and on releases the constraints
+// keep out
+func (c *checker) checkLatest(comp *Component) {
+	latest, err := goversion.NewVersion(comp.Latest)
+	if err != nil {
+		c.add(Warning, comp.Name, "invalid index version %q", comp.Latest)
+		return
+	}
+	for _, vp := range comp.Versions() {
+		if v, err := goversion.NewVersion(vp.Version); err == nil && v.LessThan(latest) && !sameMinorRelease(comp, v, latest) {
+			c.add(Advisory, comp.Name, "%s is available, %s pins %s", comp.Latest, vp.Source, vp.Version)
+		}
+	}
+	for _, cp := range comp.Constraints() {
+		if constraint, err := goversion.NewConstraint(cp.Constraint); err == nil && !constraint.Check(latest) {
+			c.add(Advisory, comp.Name, "%s is outside %s, upgrading needs a constraint change", comp.Latest, cp)
+		}
+	}
+}
+
+// sameMinorRelease reports whether a Kubernetes version like 1.35 already
+// names the minor release of the latest patch version
+func sameMinorRelease(comp *Component, v, latest *goversion.Version) bool {
+	return comp.Kind == Kubernetes && len(v.Segments()) >= 2 && v.Segments()[0] == latest.Segments()[0] && v.Segments()[1] == latest.Segments()[1]
+}
+
+// checkKubectlSkew reports a kubectl more than one minor version away from
+// the cluster
+func (c *checker) checkKubectlSkew(inv *Inventory) {
+	kubectl, kubernetes := inv.Component("kubectl"), inv.Component("kubernetes")
+	if kubectl == nil || kubernetes == nil {
+		return
+	}
+	for _, kp := range kubernetes.Versions() {
+		cluster, err := goversion.NewVersion(kp.Version)
+		if err != nil {
+			c.add(Error, "kubernetes", "invalid version %s: %v", kp, err)
+			continue
+		}
+		for _, vp := range kubectl.Versions() {
+			client, err := goversion.NewVersion(vp.Version)
+			if err != nil {
+				continue
+			}
+			cs, ks := client.Segments(), cluster.Segments()
+			skew := cs[1] - ks[1]
+			if cs[0] != ks[0] || skew > MaxKubectlSkew || skew < -MaxKubectlSkew {
+				c.add(Error, "kubectl", "%s is more than %d minor version from kubernetes_version %s", vp, MaxKubectlSkew, kp)
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package versions collects the versions of Terraform, the providers, the
+// registry modules and the container tooling pinned across the repository
+// into one inventory and reports the pins that contradict each other.
+//
+// The sources are the terraform blocks and module calls of the root module and
+// modules/*, .terraform.lock.hcl, the Dockerfile ARGs, the version checks of
+// container-structure-test.yaml and the kubernetes_version default. Nothing is
+// fetched, newer releases come from an optional local index.
+package versions
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// Kind of a component
+type Kind string
+
+const (
+	Terraform  Kind = "terraform"
+	Provider   Kind = "provider"
+	Module     Kind = "module"
+	Tool       Kind = "tool"
+	Kubernetes Kind = "kubernetes"
+)
+
+// Pin is a version requirement of a component found in one source
+type Pin struct {
+	// Source is the file and line of the pin, relative to the repository
+	Source string `json:"source"`
+	// Version is an exact version, like the Dockerfile ARGs or the lock file
+	Version string `json:"version,omitempty"`
+	// Constraint is a version constraint, like required_version
+	Constraint string `json:"constraint,omitempty"`
+}
+
+func (p Pin) String() string {
+	if p.Version != "" {
+		return fmt.Sprintf("%s (%s)", p.Version, p.Source)
+	}
+	return fmt.Sprintf("%q (%s)", p.Constraint, p.Source)
+}
+
+// Component is a versioned dependency with all its pins
+type Component struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+	Pins []Pin  `json:"pins"`
+	// Latest is the newest release according to the index
+	Latest string `json:"latest,omitempty"`
+}
+
+// Versions returns the exact pins
+func (c *Component) Versions() []Pin {
+	var pins []Pin
+	for _, p := range c.Pins {
+		if p.Version != "" {
+			pins = append(pins, p)
+		}
+	}
+	return pins
+}
+
+// Constraints returns the constraint pins
+func (c *Component) Constraints() []Pin {
+	var pins []Pin
+	for _, p := range c.Pins {
+		if p.Constraint != "" {
+			pins = append(pins, p)
+		}
+	}
+	return pins
+}
+
+// Inventory is the versions pinned in a repository
+type Inventory struct {
+	Components []*Component `json:"components"`
+	// LockFile tells whether .terraform.lock.hcl was found
+	LockFile bool `json:"lockFile"`
+}
+
+// Component returns the named component, nil when it is not pinned anywhere
+func (inv *Inventory) Component(name string) *Component {
+	for _, c := range inv.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (inv *Inventory) add(name string, kind Kind, pin Pin) {
+	c := inv.Component(name)
+	if c == nil {
+		c = &Component{Name: name, Kind: kind}
+		inv.Components = append(inv.Components, c)
+	}
+	c.Pins = append(c.Pins, pin)
+}
+
+var kindOrder = map[Kind]int{Terraform: 0, Provider: 1, Module: 2, Tool: 3, Kubernetes: 4}
+
+func (inv *Inventory) sort() {
+	sort.SliceStable(inv.Components, func(i, j int) bool {
+		a, b := inv.Components[i], inv.Components[j]
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Load reads the pins of the repository in dir. Missing optional sources,
+// like the lock file, are skipped.
+func Load(dir string) (*Inventory, error) {
+	inv := &Inventory{Components: []*Component{}}
+	files, err := terraformFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no terraform files found in %s", dir)
+	}
+	for _, file := range files {
+		if err := inv.loadTerraformFile(dir, file); err != nil {
+			return nil, err
+		}
+	}
+	if err := inv.loadKubernetesVersion(dir); err != nil {
+		return nil, err
+	}
+	for _, load := range []func(dir string) error{inv.loadLockFile, inv.loadDockerfile, inv.loadStructureTest} {
+		if err := load(dir); err != nil {
+			return nil, err
+		}
+	}
+	inv.sort()
+	return inv, nil
+}
+
+// terraformFiles returns the *.tf files of the root module and modules/*
+func terraformFiles(dir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.tf", filepath.Join("modules", "*", "*.tf")} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// relative returns the source of a pin, path relative to dir and line
+func relative(dir, path string, line int) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		path = rel
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(path), line)
+}
@@ -0,0 +1,43 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package versions
+
+import (
+	"strings"
+
+	"test/report"
+)
+
+// Tables renders the inventory and the findings
+func (r *Result) Tables() []*report.Table {
+	inventory := &report.Table{
+		Title: "Version inventory",
+		Columns: []report.Column{
+			{Header: "Component"},
+			{Header: "Kind"},
+			{Header: "Pinned"},
+			{Header: "Latest"},
+		},
+	}
+	for _, c := range r.Components {
+		pins := make([]string, len(c.Pins))
+		for i, p := range c.Pins {
+			pins[i] = p.String()
+		}
+		inventory.AddRow(c.Name, string(c.Kind), strings.Join(pins, "; "), c.Latest)
+	}
+	tables := []*report.Table{inventory}
+
+	if len(r.Findings) > 0 {
+		findings := &report.Table{
+			Title:   "Findings",
+			Columns: []report.Column{{Header: "Severity"}, {Header: "Component"}, {Header: "Message"}},
+		}
+		for _, f := range r.Findings {
+			findings.AddRow(string(f.Severity), f.Component, f.Message)
+		}
+		tables = append(tables, findings)
+	}
+	return tables
+}
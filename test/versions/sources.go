@@ -0,0 +1,268 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package versions
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"test/tfvars"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// LockFile is the dependency lock file written by terraform init
+	LockFile = ".terraform.lock.hcl"
+	// Dockerfile is the image the tooling ships in
+	Dockerfile = "Dockerfile"
+	// StructureTest checks the versions installed in the image
+	StructureTest = "container-structure-test.yaml"
+)
+
+var fileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "required_version"}},
+	Blocks:     []hcl.BlockHeaderSchema{{Type: "required_providers"}},
+}
+
+var moduleSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "source"}, {Name: "version"}},
+}
+
+func parseHCL(path string) (hcl.Body, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, diags := hclparse.NewParser().ParseHCL(src, path)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return f.Body, nil
+}
+
+// stringAttr returns the value of a literal string attribute
+func stringAttr(attrs hcl.Attributes, name string) (string, int, bool) {
+	attr, ok := attrs[name]
+	if !ok {
+		return "", 0, false
+	}
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return "", 0, false
+	}
+	return v.AsString(), attr.Range.Start.Line, true
+}
+
+// loadTerraformFile reads required_version, required_providers and the
+// versions of registry module calls
+func (inv *Inventory) loadTerraformFile(dir, path string) error {
+	body, err := parseHCL(path)
+	if err != nil {
+		return err
+	}
+	content, _, diags := body.PartialContent(fileSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "terraform":
+			if err := inv.loadTerraformBlock(dir, path, block); err != nil {
+				return err
+			}
+		case "module":
+			attrs, _, diags := block.Body.PartialContent(moduleSchema)
+			if diags.HasErrors() {
+				return diags
+			}
+			source, _, _ := stringAttr(attrs.Attributes, "source")
+			constraint, line, ok := stringAttr(attrs.Attributes, "version")
+			if !ok || isLocalSource(source) {
+				continue
+			}
+			inv.add(moduleName(source), Module, Pin{Source: relative(dir, path, line), Constraint: constraint})
+		}
+	}
+	return nil
+}
+
+func (inv *Inventory) loadTerraformBlock(dir, path string, block *hcl.Block) error {
+	content, _, diags := block.Body.PartialContent(terraformSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+	if constraint, line, ok := stringAttr(content.Attributes, "required_version"); ok {
+		inv.add("terraform", Terraform, Pin{Source: relative(dir, path, line), Constraint: constraint})
+	}
+	for _, providers := range content.Blocks {
+		attrs, diags := providers.Body.JustAttributes()
+		if diags.HasErrors() {
+			return diags
+		}
+		for name, attr := range attrs {
+			v, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !v.Type().IsObjectType() {
+				continue
+			}
+			source := "hashicorp/" + name
+			if v.Type().HasAttribute("source") {
+				source = v.GetAttr("source").AsString()
+			}
+			if !v.Type().HasAttribute("version") {
+				continue
+			}
+			inv.add(providerName(source), Provider, Pin{
+				Source:     relative(dir, path, attr.Range.Start.Line),
+				Constraint: v.GetAttr("version").AsString(),
+			})
+		}
+	}
+	return nil
+}
+
+func isLocalSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
+// moduleName drops the submodule of a registry module source, so
+// terraform-aws-modules/iam/aws//modules/iam-assumable-role-with-oidc
+// becomes terraform-aws-modules/iam/aws
+func moduleName(source string) string {
+	name, _, _ := strings.Cut(source, "//")
+	return name
+}
+
+// providerName drops the default registry host of a provider source
+func providerName(source string) string {
+	return strings.TrimPrefix(strings.ToLower(source), "registry.terraform.io/")
+}
+
+var lockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"source"}}},
+}
+
+var lockProviderSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "version"}, {Name: "constraints"}, {Name: "hashes"}},
+}
+
+// loadLockFile reads the selected provider versions
+func (inv *Inventory) loadLockFile(dir string) error {
+	path := filepath.Join(dir, LockFile)
+	body, err := parseHCL(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	inv.LockFile = true
+	content, _, diags := body.PartialContent(lockSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+	for _, block := range content.Blocks {
+		attrs, _, diags := block.Body.PartialContent(lockProviderSchema)
+		if diags.HasErrors() {
+			return diags
+		}
+		if version, line, ok := stringAttr(attrs.Attributes, "version"); ok {
+			inv.add(providerName(block.Labels[0]), Provider, Pin{Source: relative(dir, path, line), Version: version})
+		}
+	}
+	return nil
+}
+
+// loadKubernetesVersion reads the default of the kubernetes_version variable
+func (inv *Inventory) loadKubernetesVersion(dir string) error {
+	variables, err := tfvars.LoadVariables(dir)
+	if err != nil {
+		return err
+	}
+	v, ok := variables["kubernetes_version"]
+	if !ok || v.Default.IsNull() || !v.Default.Type().Equals(cty.String) {
+		return nil
+	}
+	inv.add("kubernetes", Kubernetes, Pin{Source: relative(dir, v.Range.Filename, v.Range.Start.Line), Version: v.Default.AsString()})
+	return nil
+}
+
+// dockerArgs maps the Dockerfile build arguments to the tools they pin
+var dockerArgs = map[string]string{
+	"TERRAFORM_VERSION": "terraform",
+	"AWS_CLI_VERSION":   "aws-cli",
+	"KUBECTL_VERSION":   "kubectl",
+}
+
+var argPattern = regexp.MustCompile(`^ARG\s+([A-Z_]+)=(\S+)`)
+
+// loadDockerfile reads the tool versions from the ARG defaults
+func (inv *Inventory) loadDockerfile(dir string) error {
+	return scanLines(filepath.Join(dir, Dockerfile), func(line int, text string) {
+		m := argPattern.FindStringSubmatch(strings.TrimSpace(text))
+		if m == nil {
+			return
+		}
+		if name, ok := dockerArgs[m[1]]; ok {
+			inv.add(name, toolKind(name), Pin{Source: relative(dir, filepath.Join(dir, Dockerfile), line), Version: m[2]})
+		}
+	})
+}
+
+// structureTestPatterns match the version output the structure test expects
+var structureTestPatterns = map[string]*regexp.Regexp{
+	"terraform": regexp.MustCompile(`Terraform v(\d+\.\d+\.\d+)`),
+	"aws-cli":   regexp.MustCompile(`aws-cli/(\d+\.\d+\.\d+)`),
+	"kubectl":   regexp.MustCompile(`Client Version: v(\d+\.\d+\.\d+)`),
+}
+
+// loadStructureTest reads the versions the structure test expects
+func (inv *Inventory) loadStructureTest(dir string) error {
+	return scanLines(filepath.Join(dir, StructureTest), func(line int, text string) {
+		for _, name := range []string{"terraform", "aws-cli", "kubectl"} {
+			if m := structureTestPatterns[name].FindStringSubmatch(text); m != nil {
+				inv.add(name, toolKind(name), Pin{Source: relative(dir, filepath.Join(dir, StructureTest), line), Version: m[1]})
+			}
+		}
+	})
+}
+
+func toolKind(name string) Kind {
+	if name == "terraform" {
+		return Terraform
+	}
+	return Tool
+}
+
+// scanLines calls fn with every line of a file, a missing file is skipped
+func scanLines(path string, fn func(line int, text string)) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fn(line, scanner.Text())
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,13 @@
+variable "kubernetes_version" {
+  type    = string
+  default = "1.32"
+}
+
+module "eks" {
+  source  = "terraform-aws-modules/eks/aws"
+  version = "~> 20.0"
+}
+
+module "vpc" {
+  source = "./modules/storage"
+}
@@ -0,0 +1,116 @@
+// Copyright © 2026, SAS Institute Inc., Cary, NC, USA. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The pins shipped in the repository must agree with each other
+func TestRepositoryVersionsAgree(t *testing.T) {
+	t.Parallel()
+
+	inv, err := Load("../..")
+	require.NoError(t, err)
+	for _, name := range []string{"terraform", "hashicorp/aws", "terraform-aws-modules/eks/aws", "kubectl", "aws-cli", "kubernetes"} {
+		assert.NotNil(t, inv.Component(name), name)
+	}
+	result := Check(inv, Options{})
+	assert.Empty(t, result.Findings)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	inv, err := Load("testdata/drift")
+	require.NoError(t, err)
+	assert.True(t, inv.LockFile)
+
+	names := make([]string, len(inv.Components))
+	for i, c := range inv.Components {
+		names[i] = c.Name
+	}
+	assert.Equal(t, []string{
+		"terraform",
+		"hashicorp/aws",
+		"hashicorp/tls",
+		"terraform-aws-modules/eks/aws",
+		"terraform-aws-modules/iam/aws",
+		"aws-cli",
+		"kubectl",
+		"kubernetes",
+	}, names, "local module sources are left out")
+
+	assert.Equal(t, []Pin{
+		{Source: "versions.tf:2", Constraint: ">= 1.11.0"},
+		{Source: "Dockerfile:1", Version: "1.10.5"},
+		{Source: "container-structure-test.yaml:7", Version: "1.10.5"},
+	}, inv.Component("terraform").Pins)
+	assert.Equal(t, []Pin{
+		{Source: "versions.tf:4", Constraint: "~> 5.0"},
+		{Source: ".terraform.lock.hcl:5", Version: "6.2.0"},
+	}, inv.Component("hashicorp/aws").Pins)
+	assert.Equal(t, []Pin{
+		{Source: "modules/storage/main.tf:3", Constraint: "~> 5.0"},
+		{Source: "modules/storage/main.tf:8", Constraint: ">= 5.30"},
+	}, inv.Component("terraform-aws-modules/iam/aws").Pins, "submodules count as their registry module")
+	assert.Equal(t, []Pin{{Source: "main.tf:1", Version: "1.32"}}, inv.Component("kubernetes").Pins)
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	inv, err := Load("testdata/drift")
+	require.NoError(t, err)
+	result := Check(inv, Options{})
+	assert.False(t, result.Passed())
+	assert.Equal(t, []Finding{
+		{Error, "terraform", `1.10.5 (Dockerfile:1) does not satisfy ">= 1.11.0" (versions.tf:2)`},
+		{Error, "terraform", `1.10.5 (container-structure-test.yaml:7) does not satisfy ">= 1.11.0" (versions.tf:2)`},
+		{Error, "hashicorp/aws", `6.2.0 (.terraform.lock.hcl:5) does not satisfy "~> 5.0" (versions.tf:4)`},
+		{Warning, "hashicorp/tls", "missing from .terraform.lock.hcl, run terraform init -upgrade"},
+		{Warning, "terraform-aws-modules/iam/aws", `called with "~> 5.0" (modules/storage/main.tf:3) and ">= 5.30" (modules/storage/main.tf:8)`},
+		{Error, "aws-cli", "2.24.15 (container-structure-test.yaml:14) disagrees with 2.24.16 (Dockerfile:2)"},
+		{Error, "kubectl", "1.35.6 (Dockerfile:6) is more than 1 minor version from kubernetes_version 1.32 (main.tf:1)"},
+	}, result.Findings)
+}
+
+func TestCheckKubernetesVersionOverride(t *testing.T) {
+	t.Parallel()
+
+	inv, err := Load("testdata/drift")
+	require.NoError(t, err)
+	result := Check(inv, Options{KubernetesVersion: &Pin{Source: "terraform.tfvars", Version: "1.34"}})
+	for _, f := range result.Findings {
+		assert.NotEqual(t, "kubectl", f.Component, "1.35 is within the supported skew of 1.34")
+	}
+	assert.Equal(t, []Pin{{Source: "terraform.tfvars", Version: "1.34"}}, inv.Component("kubernetes").Pins)
+}
+
+func TestCheckAdvisories(t *testing.T) {
+	t.Parallel()
+
+	index, err := LoadIndex("testdata/index.json")
+	require.NoError(t, err)
+	inv, err := Load("testdata/drift")
+	require.NoError(t, err)
+
+	var advisories []Finding
+	for _, f := range Check(inv, Options{Index: index, KubernetesVersion: &Pin{Source: "terraform.tfvars", Version: "1.35"}}).Findings {
+		if f.Severity == Advisory {
+			advisories = append(advisories, f)
+		}
+	}
+	assert.Equal(t, []Finding{
+		{Advisory, "terraform", "1.13.4 is available, Dockerfile:1 pins 1.10.5"},
+		{Advisory, "terraform", "1.13.4 is available, container-structure-test.yaml:7 pins 1.10.5"},
+		{Advisory, "hashicorp/aws", "6.17.0 is available, .terraform.lock.hcl:5 pins 6.2.0"},
+		{Advisory, "hashicorp/aws", `6.17.0 is outside "~> 5.0" (versions.tf:4), upgrading needs a constraint change`},
+		{Advisory, "terraform-aws-modules/eks/aws", `21.3.1 is outside "~> 20.0" (main.tf:8), upgrading needs a constraint change`},
+	}, advisories, "kubernetes 1.35 already is the 1.35.2 minor release")
+	assert.Equal(t, "4.1.0", inv.Component("hashicorp/tls").Latest)
+}
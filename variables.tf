@@ -180,6 +180,12 @@ variable "kubernetes_version" {
   description = "The EKS cluster Kubernetes version."
   type        = string
   default     = "1.35"
+
+  # locals.tf compares the major and minor numbers to gate features
+  validation {
+    condition     = can(regex("^[0-9]+\\.[0-9]+$", var.kubernetes_version))
+    error_message = "ERROR: Value of 'kubernetes_version' must be a major.minor version, like \"1.35\"."
+  }
 }
 
 # Map of tags to apply to all resources. Used for cost allocation, project tracking, etc.
@@ -290,6 +296,14 @@ variable "default_nodepool_labels" {
   default = {
     "kubernetes.azure.com/mode" = "system"
   }
+
+  # The labels are joined into the --node-labels kubelet flag, which splits at commas and '='
+  validation {
+    condition = alltrue([for k, v in var.default_nodepool_labels :
+      can(regex(local.label_key_regex, k)) && length(split("/", k)[0]) <= 253 && can(regex(local.label_value_regex, v))
+    ])
+    error_message = "ERROR: Keys and values of `default_nodepool_labels` must follow the Kubernetes label syntax, see docs/CONFIG-VARS.md."
+  }
 }
 
 # Additional user data that will be appended to the default user data.
@@ -411,6 +425,14 @@ variable "node_pools" {
       "metadata_http_put_response_hop_limit" = 1
     }
   }
+
+  # The labels are joined into the --node-labels kubelet flag, which splits at commas and '='
+  validation {
+    condition = alltrue([for np in values(var.node_pools) : alltrue([for k, v in np.node_labels :
+      can(regex(local.label_key_regex, k)) && length(split("/", k)[0]) <= 253 && can(regex(local.label_value_regex, v))
+    ])])
+    error_message = "ERROR: Keys and values of `node_labels` in `node_pools` must follow the Kubernetes label syntax, see docs/CONFIG-VARS.md."
+  }
 }
 
 # Networking